@@ -36,7 +36,7 @@ import (
 
 var (
 	configJSON = []byte(`{
-  "version": "33",
+  "version": "42",
   "credential": {
     "accessKey": "minio",
     "secretKey": "minio123"
@@ -108,12 +108,15 @@ var (
         "tls": {
           "enable": false,
           "skipVerify": false,
-          "clientAuth": 0
+          "clientAuth": 0,
+          "clientTLSCert": "",
+          "clientTLSKey": ""
         },
         "sasl": {
           "enable": false,
           "username": "",
-          "password": ""
+          "password": "",
+          "mechanism": ""
         }
       }
     },
@@ -194,6 +197,30 @@ var (
         "queueLimit": 0
       }
     },
+    "pubsub": {
+      "1": {
+        "enable": false,
+        "projectId": "",
+        "topic": "",
+        "credentialsFile": "",
+        "emulatorHost": "",
+        "queueDir": "",
+        "queueLimit": 0
+      }
+    },
+    "pulsar": {
+      "1": {
+        "enable": false,
+        "endpoint": "",
+        "topic": "",
+        "token": "",
+        "tls": {
+          "skipVerify": false
+        },
+        "queueDir": "",
+        "queueLimit": 0
+      }
+    },
     "redis": {
       "1": {
         "enable": false,
@@ -228,7 +255,8 @@ var (
   "compress": {
     "enabled": false,
     "extensions":[".txt",".log",".csv",".json"],
-    "mime-types":["text/csv","text/plain","application/json"]
+    "mime-types":["text/csv","text/plain","application/json"],
+    "allow-encryption": false
   },
   "openid": {
     "jwks": {
@@ -240,6 +268,17 @@ var (
       "url": "",
       "authToken": ""
     }
+  },
+  "tier": {},
+  "replication": {},
+  "ldapserverconfig": {
+    "serverAddr": "",
+    "serverInsecure": false,
+    "tlsSkipVerify": false,
+    "usernameFormat": "",
+    "groupSearchBaseDN": "",
+    "groupSearchFilter": "",
+    "groupPolicies": null
   }
 }
 `)
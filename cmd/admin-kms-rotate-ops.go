@@ -0,0 +1,512 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// a key rotation with this many un-consumed result items
+	// blocks until status consumption resumes or is aborted
+	// due to timeout.
+	maxUnconsumedKMSKeyRotateItems = 1000
+
+	// if no results are consumed for this timeout duration,
+	// the key rotation is aborted.
+	kmsKeyRotateUnconsumedTimeout = 24 * time.Hour
+
+	// time-duration to keep key rotation state after it
+	// completes.
+	keepKMSKeyRotateStateDuration = 10 * time.Minute
+)
+
+var (
+	errKMSKeyRotateIdleTimeout   = fmt.Errorf("key rotation results were not consumed for too long")
+	errKMSKeyRotateStopSignalled = fmt.Errorf("key rotation stop signaled")
+)
+
+// structure to hold state of all KMS key rotations in server memory
+type allKMSKeyRotateState struct {
+	sync.Mutex
+
+	// map of rotation path to key rotation sequence
+	rotateSeqMap map[string]*kmsKeyRotateSequence
+}
+
+// initKMSKeyRotateState - initialize the KMS key rotation state.
+func initKMSKeyRotateState() *allKMSKeyRotateState {
+	rotateState := &allKMSKeyRotateState{
+		rotateSeqMap: make(map[string]*kmsKeyRotateSequence),
+	}
+
+	go rotateState.periodicRotateSeqsClean()
+
+	return rotateState
+}
+
+func (krs *allKMSKeyRotateState) periodicRotateSeqsClean() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			now := UTCNow()
+			krs.Lock()
+			for path, k := range krs.rotateSeqMap {
+				if k.hasEnded() && k.endTime.Add(keepKMSKeyRotateStateDuration).Before(now) {
+					delete(krs.rotateSeqMap, path)
+				}
+			}
+			krs.Unlock()
+		case <-GlobalServiceDoneCh:
+			return
+		}
+	}
+}
+
+// getRotateSequence - Retrieve a key rotation by path. The second
+// argument returns if the key rotation actually exists.
+func (krs *allKMSKeyRotateState) getRotateSequence(path string) (k *kmsKeyRotateSequence, exists bool) {
+	krs.Lock()
+	defer krs.Unlock()
+	k, exists = krs.rotateSeqMap[path]
+	return k, exists
+}
+
+// stopRotateSequence - stops the key rotation on the given path and
+// waits until it has ended.
+func (krs *allKMSKeyRotateState) stopRotateSequence(path string) ([]byte, APIError) {
+	var ksp madmin.KMSKeyRotateStopSuccess
+	k, exists := krs.getRotateSequence(path)
+	if !exists {
+		ksp = madmin.KMSKeyRotateStopSuccess{
+			ClientToken: "invalid",
+			StartTime:   UTCNow(),
+		}
+	} else {
+		ksp = madmin.KMSKeyRotateStopSuccess{
+			ClientToken:   k.clientToken,
+			ClientAddress: k.clientAddress,
+			StartTime:     k.startTime,
+		}
+
+		k.stop()
+		for !k.hasEnded() {
+			time.Sleep(1 * time.Second)
+		}
+		krs.Lock()
+		defer krs.Unlock()
+		// Key rotation explicitly stopped, remove it.
+		delete(krs.rotateSeqMap, path)
+	}
+
+	b, err := json.Marshal(&ksp)
+	return b, toAdminAPIErr(context.Background(), err)
+}
+
+// LaunchNewRotateSequence - launches a background routine that
+// re-wraps the sealed keys of the objects below the path of the
+// key rotation. Its state is kept in `globalAllKMSKeyRotateState`
+// for `keepKMSKeyRotateStateDuration` after it has ended.
+func (krs *allKMSKeyRotateState) LaunchNewRotateSequence(k *kmsKeyRotateSequence) (
+	respBytes []byte, apiErr APIError, errMsg string) {
+
+	if ke, exists := krs.getRotateSequence(k.path); exists && !ke.hasEnded() {
+		if !k.forceStarted {
+			errMsg = "KMS key rotation is already running on the given path " +
+				"(use force-start option to stop and start afresh). " +
+				fmt.Sprintf("The key rotation was started by IP %s at %s, token is %s",
+					ke.clientAddress, ke.startTime.Format(http.TimeFormat), ke.clientToken)
+			return nil, errorCodes.ToAPIErr(ErrKMSKeyRotateAlreadyRunning), errMsg
+		}
+		// stop the running key rotation - wait for it to finish.
+		ke.stop()
+		for !ke.hasEnded() {
+			time.Sleep(1 * time.Second)
+		}
+	}
+
+	krs.Lock()
+	defer krs.Unlock()
+
+	// Check if the new key rotation overlaps with any existing,
+	// running key rotation.
+	for path, kSeq := range krs.rotateSeqMap {
+		if !kSeq.hasEnded() && (strings.HasPrefix(path, k.path) || strings.HasPrefix(k.path, path)) {
+			errMsg = "The provided key rotation path overlaps with an existing " +
+				fmt.Sprintf("key rotation path: %s", path)
+			return nil, errorCodes.ToAPIErr(ErrKMSKeyRotateOverlappingPaths), errMsg
+		}
+	}
+
+	krs.rotateSeqMap[k.path] = k
+
+	go k.rotateSequenceStart()
+
+	b, err := json.Marshal(madmin.KMSKeyRotateStartSuccess{
+		ClientToken:   k.clientToken,
+		ClientAddress: k.clientAddress,
+		StartTime:     k.startTime,
+	})
+	if err != nil {
+		logger.LogIf(k.ctx, err)
+		return nil, toAPIError(k.ctx, err), ""
+	}
+	return b, noError, ""
+}
+
+// PopRotateStatusJSON - returns the JSON representation of the status
+// of the key rotation on the given path, including all result items
+// since the last call.
+func (krs *allKMSKeyRotateState) PopRotateStatusJSON(path, clientToken string) ([]byte, APIErrorCode) {
+	k, exists := krs.getRotateSequence(path)
+	if !exists {
+		return nil, ErrKMSKeyRotateNoSuchProcess
+	}
+	if clientToken != k.clientToken {
+		return nil, ErrKMSKeyRotateInvalidClientToken
+	}
+
+	k.updateLock.Lock()
+	defer k.updateLock.Unlock()
+
+	jbytes, err := json.Marshal(k.currentStatus)
+	if err != nil {
+		logger.LogIf(k.ctx, err)
+		return nil, ErrInternalError
+	}
+
+	// Record the index of the last result sent to the client.
+	if numItems := len(k.currentStatus.Items); numItems > 0 {
+		k.lastSentResultIndex = k.currentStatus.Items[numItems-1].ResultIndex
+	}
+	k.currentStatus.Items = nil
+	return jbytes, ErrNone
+}
+
+// kmsKeyRotateSequence - state of a KMS key rotation initiated on
+// the server.
+type kmsKeyRotateSequence struct {
+	// bucket and prefix on which the key rotation was initiated,
+	// path is just pathJoin(bucket, prefix).
+	bucket, prefix, path string
+
+	// time at which the key rotation was started and has ended
+	startTime, endTime time.Time
+
+	// client info
+	clientToken, clientAddress string
+
+	// was this key rotation force started?
+	forceStarted bool
+
+	// lock to update currentStatus and lastSentResultIndex
+	updateLock sync.RWMutex
+
+	// current accumulated status of the key rotation
+	currentStatus madmin.KMSKeyRotateStatus
+
+	// the last result index sent to client
+	lastSentResultIndex int64
+
+	// channel signaled by the background routine when the walk
+	// has completed
+	rotateDoneCh chan error
+
+	// channel to signal the key rotation to stop
+	stopSignalCh chan struct{}
+
+	// Holds the request-info for logging
+	ctx context.Context
+}
+
+// newKMSKeyRotateSequence - creates a key rotation, assumes bucket and
+// prefix are already validated.
+func newKMSKeyRotateSequence(bucket, prefix, clientAddr string, forceStart bool) *kmsKeyRotateSequence {
+	reqInfo := &logger.ReqInfo{RemoteHost: clientAddr, API: "KMSKeyRotate", BucketName: bucket}
+	reqInfo.AppendTags("prefix", prefix)
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	return &kmsKeyRotateSequence{
+		bucket:        bucket,
+		prefix:        prefix,
+		path:          pathJoin(bucket, prefix),
+		startTime:     UTCNow(),
+		clientToken:   mustGetUUID(),
+		clientAddress: clientAddr,
+		forceStarted:  forceStart,
+		currentStatus: madmin.KMSKeyRotateStatus{
+			Summary: string(healNotStartedStatus),
+		},
+		rotateDoneCh: make(chan error),
+		stopSignalCh: make(chan struct{}),
+		ctx:          ctx,
+	}
+}
+
+// isQuitting - determines if the key rotation is quitting due to an
+// external signal.
+func (k *kmsKeyRotateSequence) isQuitting() bool {
+	select {
+	case <-k.stopSignalCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// check if the key rotation has ended
+func (k *kmsKeyRotateSequence) hasEnded() bool {
+	k.updateLock.RLock()
+	summary := k.currentStatus.Summary
+	k.updateLock.RUnlock()
+	return summary == healStoppedStatus || summary == healFinishedStatus
+}
+
+// stops the key rotation - safe to call multiple times.
+func (k *kmsKeyRotateSequence) stop() {
+	select {
+	case <-k.stopSignalCh:
+	default:
+		close(k.stopSignalCh)
+	}
+}
+
+// pushResultItem - pushes a result item for consumption through the
+// status API. Like heal sequences, it blocks while there are
+// maxUnconsumedKMSKeyRotateItems results, which pauses the key
+// rotation until the client consumes the results.
+func (k *kmsKeyRotateSequence) pushResultItem(r madmin.KMSKeyRotateResultItem) error {
+	unconsumedTimer := time.NewTimer(kmsKeyRotateUnconsumedTimeout)
+	defer unconsumedTimer.Stop()
+
+	for {
+		k.updateLock.Lock()
+		if len(k.currentStatus.Items) < maxUnconsumedKMSKeyRotateItems {
+			break
+		}
+		k.updateLock.Unlock()
+
+		select {
+		case <-time.After(time.Second):
+		case <-k.stopSignalCh:
+			return errKMSKeyRotateStopSignalled
+		case <-unconsumedTimer.C:
+			return errKMSKeyRotateIdleTimeout
+		}
+	}
+
+	if itemsLen := len(k.currentStatus.Items); itemsLen > 0 {
+		r.ResultIndex = 1 + k.currentStatus.Items[itemsLen-1].ResultIndex
+	} else {
+		r.ResultIndex = 1 + k.lastSentResultIndex
+	}
+	k.currentStatus.Items = append(k.currentStatus.Items, r)
+	k.updateLock.Unlock()
+	return nil
+}
+
+// rotateSequenceStart - the top-level background routine of a key
+// rotation. It launches the walk over the objects and sets the final
+// status once the walk completes or an external stop signal is
+// received.
+func (k *kmsKeyRotateSequence) rotateSequenceStart() {
+	k.updateLock.Lock()
+	k.currentStatus.Summary = healRunningStatus
+	k.currentStatus.StartTime = UTCNow()
+	k.updateLock.Unlock()
+
+	go func() {
+		if err := k.rotateKeys(); err != nil {
+			if k.isQuitting() {
+				err = errKMSKeyRotateStopSignalled
+			}
+			k.rotateDoneCh <- err
+		}
+		close(k.rotateDoneCh)
+	}()
+
+	select {
+	case err, ok := <-k.rotateDoneCh:
+		k.updateLock.Lock()
+		k.endTime = UTCNow()
+		if ok {
+			k.currentStatus.Summary = healStoppedStatus
+			k.currentStatus.FailureDetail = err.Error()
+		} else {
+			k.currentStatus.Summary = healFinishedStatus
+		}
+		k.updateLock.Unlock()
+
+	case <-k.stopSignalCh:
+		k.updateLock.Lock()
+		k.endTime = UTCNow()
+		k.currentStatus.Summary = healStoppedStatus
+		k.currentStatus.FailureDetail = errKMSKeyRotateStopSignalled.Error()
+		k.updateLock.Unlock()
+
+		// drain the channel so the walk go-routine does not leak.
+		go func() {
+			<-k.rotateDoneCh
+		}()
+	}
+}
+
+// rotateKeys - walks all versions of the objects below the path of
+// the key rotation and re-wraps the sealed keys of SSE-S3 and SSE-KMS
+// objects. Failures of single objects are reported as result items.
+func (k *kmsKeyRotateSequence) rotateKeys() error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+
+	buckets := []string{k.bucket}
+	if k.bucket == "" {
+		bucketsInfo, err := objectAPI.ListBuckets(k.ctx)
+		if err != nil {
+			return err
+		}
+		buckets = buckets[:0]
+		for _, bucketInfo := range bucketsInfo {
+			buckets = append(buckets, bucketInfo.Name)
+		}
+	}
+
+	for _, bucket := range buckets {
+		var marker, versionIDMarker string
+		for {
+			result, err := objectAPI.ListObjectVersions(k.ctx, bucket, k.prefix, marker, versionIDMarker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			for _, objInfo := range result.Objects {
+				if k.isQuitting() {
+					return errKMSKeyRotateStopSignalled
+				}
+				if err = k.rotateObjectKey(objectAPI, objInfo); err != nil {
+					return err
+				}
+			}
+			if !result.IsTruncated {
+				break
+			}
+			marker, versionIDMarker = result.NextMarker, result.NextVersionIDMarker
+		}
+	}
+	return nil
+}
+
+// rotateObjectKey - re-wraps the sealed key of a single object version
+// and reports the result, unless the object is not encrypted by the
+// KMS or its sealed key has not changed.
+func (k *kmsKeyRotateSequence) rotateObjectKey(objectAPI ObjectLayer, objInfo ObjectInfo) error {
+	if objInfo.IsDir || objInfo.DeleteMarker {
+		return nil
+	}
+
+	k.updateLock.Lock()
+	k.currentStatus.ObjectsScanned++
+	k.updateLock.Unlock()
+
+	if !crypto.S3.IsEncrypted(objInfo.UserDefined) {
+		return nil
+	}
+
+	keyID, rotated, err := rotateKMSSealedKey(k.ctx, objectAPI, objInfo)
+	if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+		// The object was deleted in the meantime.
+		return nil
+	}
+	if err == nil && !rotated {
+		return nil
+	}
+
+	item := madmin.KMSKeyRotateResultItem{
+		Bucket:    objInfo.Bucket,
+		Object:    objInfo.Name,
+		VersionID: objInfo.VersionID,
+		KeyID:     keyID,
+	}
+	k.updateLock.Lock()
+	if err != nil {
+		item.Detail = err.Error()
+		k.currentStatus.ObjectsFailed++
+	} else {
+		k.currentStatus.ObjectsRotated++
+	}
+	k.updateLock.Unlock()
+	return k.pushResultItem(item)
+}
+
+// rotateKMSSealedKey - re-wraps the KMS-sealed key of an SSE-S3 or
+// SSE-KMS object version with the current version of its KMS master
+// key. The object data and the object key do not change, only the
+// metadata of the object version is updated, unless the object was
+// overwritten in the meantime.
+func rotateKMSSealedKey(ctx context.Context, objectAPI ObjectLayer, objInfo ObjectInfo) (keyID string, rotated bool, err error) {
+	keyID, kmsKey, _, err := crypto.S3.ParseMetadata(objInfo.UserDefined)
+	if err != nil {
+		return keyID, false, err
+	}
+	kmsCtx, err := objectKMSContext(objInfo.Bucket, objInfo.Name, objInfo.UserDefined)
+	if err != nil {
+		return keyID, false, err
+	}
+	rotatedKey, err := GlobalKMS.UpdateKey(keyID, kmsKey, kmsCtx)
+	if err != nil {
+		return keyID, false, err
+	}
+	if bytes.Equal(rotatedKey, kmsKey) {
+		return keyID, false, nil
+	}
+
+	// The sealed key is compared and replaced under the object lock.
+	opts := ObjectOptions{VersionID: objInfo.VersionID}
+	if opts.VersionID == "" {
+		opts.VersionID = nullVersionID
+	}
+	opts.CheckUpdatePrecondFn = func(current ObjectInfo) bool {
+		return current.UserDefined[crypto.S3KMSSealedKey] != objInfo.UserDefined[crypto.S3KMSSealedKey]
+	}
+	_, err = objectAPI.UpdateObjectMetadata(ctx, objInfo.Bucket, objInfo.Name, map[string]string{
+		crypto.S3KMSSealedKey: base64.StdEncoding.EncodeToString(rotatedKey),
+	}, opts)
+	if err != nil {
+		if _, ok := err.(PreConditionFailed); ok {
+			return keyID, false, nil
+		}
+		return keyID, false, err
+	}
+	return keyID, true, nil
+}
@@ -0,0 +1,224 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// rotatedKMS - a KMS whose master key has been rotated once, keys
+// sealed with the previous version are re-wrapped by UpdateKey.
+type rotatedKMS struct{ crypto.KMS }
+
+var rotatedKeyPrefix = []byte("v2:")
+
+func (kms rotatedKMS) UnsealKey(keyID string, sealedKey []byte, ctx crypto.Context) ([32]byte, error) {
+	return kms.KMS.UnsealKey(keyID, bytes.TrimPrefix(sealedKey, rotatedKeyPrefix), ctx)
+}
+
+func (kms rotatedKMS) UpdateKey(keyID string, sealedKey []byte, ctx crypto.Context) ([]byte, error) {
+	if _, err := kms.UnsealKey(keyID, sealedKey, ctx); err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(sealedKey, rotatedKeyPrefix) {
+		return sealedKey, nil
+	}
+	return append(append([]byte{}, rotatedKeyPrefix...), sealedKey...), nil
+}
+
+// runKMSKeyRotation - runs a key rotation on bucket/prefix to completion
+// and returns its final status.
+func runKMSKeyRotation(t *testing.T, bucket, prefix string) madmin.KMSKeyRotateStatus {
+	state := &allKMSKeyRotateState{rotateSeqMap: make(map[string]*kmsKeyRotateSequence)}
+	seq := newKMSKeyRotateSequence(bucket, prefix, "127.0.0.1", false)
+	if _, apiErr, errMsg := state.LaunchNewRotateSequence(seq); apiErr != noError {
+		t.Fatalf("Failed to launch key rotation: %v %s", apiErr, errMsg)
+	}
+	for deadline := time.Now().Add(30 * time.Second); !seq.hasEnded(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Key rotation did not finish in time")
+		}
+	}
+	if _, errCode := state.PopRotateStatusJSON(seq.path, "invalid"); errCode != ErrKMSKeyRotateInvalidClientToken {
+		t.Fatalf("Expected ErrKMSKeyRotateInvalidClientToken, got %v", errCode)
+	}
+	b, errCode := state.PopRotateStatusJSON(seq.path, seq.clientToken)
+	if errCode != ErrNone {
+		t.Fatalf("Failed to get key rotation status: %v", errCode)
+	}
+	var status madmin.KMSKeyRotateStatus
+	if err := json.Unmarshal(b, &status); err != nil {
+		t.Fatal(err)
+	}
+	return status
+}
+
+func TestKMSKeyRotation(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = objLayer
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	info := ObjectInfo{Size: int64(len(data))}
+	encrypted := info.EncryptedSize()
+	objectKeys := make(map[string][]byte)
+	for _, object := range []string{"dir/sse-s3", "dir/sse-kms", "sse-s3"} {
+		req := &http.Request{Header: http.Header{}}
+		req.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+		if object == "dir/sse-kms" {
+			req.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmKMS)
+			req.Header.Set(crypto.SSEKmsID, "team-key")
+		}
+		metadata := make(map[string]string)
+		reader, objectKey, err := EncryptRequest(bytes.NewReader(data), req, bucket, object, metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objectKeys[object] = objectKey
+		if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, reader, encrypted, "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = objLayer.PutObject(ctx, bucket, "dir/plain", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	GlobalKMS = rotatedKMS{GlobalKMS}
+
+	status := runKMSKeyRotation(t, bucket, "dir/")
+	if status.Summary != healFinishedStatus || status.ObjectsScanned != 3 || status.ObjectsRotated != 2 || status.ObjectsFailed != 0 {
+		t.Fatalf("Unexpected key rotation status: %+v", status)
+	}
+	if len(status.Items) != 2 || status.Items[0].KeyID != "team-key" || status.Items[1].KeyID != "my-key" {
+		t.Fatalf("Unexpected key rotation results: %+v", status.Items)
+	}
+
+	for object, objectKey := range objectKeys {
+		objInfo, err := objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		kmsKey, err := base64.StdEncoding.DecodeString(objInfo.UserDefined[crypto.S3KMSSealedKey])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rotated := bytes.HasPrefix(kmsKey, rotatedKeyPrefix); rotated != (object != "sse-s3") {
+			t.Errorf("%s: unexpected re-wrapping of the sealed key: %v", object, rotated)
+		}
+		key, err := decryptObjectInfo(nil, bucket, object, objInfo.UserDefined)
+		if err != nil {
+			t.Fatalf("%s: failed to decrypt object key: %v", object, err)
+		}
+		if !bytes.Equal(key, objectKey) {
+			t.Errorf("%s: object key changed by the key rotation", object)
+		}
+	}
+
+	// Keys already sealed with the current master key are not changed again.
+	status = runKMSKeyRotation(t, "", "")
+	if status.Summary != healFinishedStatus || status.ObjectsScanned != 4 || status.ObjectsRotated != 1 || len(status.Items) != 1 {
+		t.Fatalf("Unexpected key rotation status: %+v", status)
+	}
+}
+
+// Wrapper for calling the stale key rotation tests for both XL multiple disks and single node setup.
+func TestKMSRotateOverwrittenObject(t *testing.T) {
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+	ExecObjectLayerTest(t, testKMSRotateOverwrittenObject)
+}
+
+// Tests that the sealed key of an object overwritten after it was listed is not replaced.
+func testKMSRotateOverwrittenObject(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	info := ObjectInfo{Size: int64(len(data))}
+	encrypted := info.EncryptedSize()
+	putObject := func() ObjectInfo {
+		req := &http.Request{Header: http.Header{}}
+		req.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+		metadata := make(map[string]string)
+		reader, _, err := EncryptRequest(bytes.NewReader(data), req, bucket, object, metadata)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, reader, encrypted, "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return objInfo
+	}
+
+	stale := putObject()
+	current := putObject()
+
+	kms := GlobalKMS
+	GlobalKMS = rotatedKMS{kms}
+	defer func() { GlobalKMS = kms }()
+
+	if _, rotated, err := rotateKMSSealedKey(ctx, obj, stale); err != nil || rotated {
+		t.Fatalf("%s: expected the overwritten object not to be rotated, got %v %v", instanceType, rotated, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if objInfo.UserDefined[crypto.S3KMSSealedKey] != current.UserDefined[crypto.S3KMSSealedKey] {
+		t.Fatalf("%s: sealed key of the current object was replaced", instanceType)
+	}
+
+	if _, rotated, err := rotateKMSSealedKey(ctx, obj, current); err != nil || !rotated {
+		t.Fatalf("%s: expected the current object to be rotated, got %v %v", instanceType, rotated, err)
+	}
+}
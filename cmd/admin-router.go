@@ -110,10 +110,44 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		// List users
 		adminV1Router.Methods(http.MethodGet).Path("/list-users").HandlerFunc(httpTraceHdrs(adminAPI.ListUsers))
 
+		// Add/Remove members from group
+		adminV1Router.Methods(http.MethodPut).Path("/update-group-members").HandlerFunc(httpTraceHdrs(adminAPI.UpdateGroupMembers))
+
+		// Get group info
+		adminV1Router.Methods(http.MethodGet).Path("/group").HandlerFunc(httpTraceHdrs(adminAPI.GetGroup)).Queries("group", "{group:.*}")
+
+		// List groups
+		adminV1Router.Methods(http.MethodGet).Path("/groups").HandlerFunc(httpTraceHdrs(adminAPI.ListGroups))
+
+		// Set group status and policy
+		adminV1Router.Methods(http.MethodPut).Path("/set-group-status").HandlerFunc(httpTraceHdrs(adminAPI.SetGroupStatus)).
+			Queries("group", "{group:.*}").Queries("status", "{status:.*}")
+		adminV1Router.Methods(http.MethodPut).Path("/set-group-policy").HandlerFunc(httpTraceHdrs(adminAPI.SetGroupPolicy)).
+			Queries("group", "{group:.*}").Queries("name", "{name:.*}")
+
 		// List policies
 		adminV1Router.Methods(http.MethodGet).Path("/list-canned-policies").HandlerFunc(httpTraceHdrs(adminAPI.ListCannedPolicies))
 	}
 
+	if !globalIsGateway {
+		// KMS key rotation
+		adminV1Router.Methods(http.MethodPost).Path("/kms/key/rotate/").HandlerFunc(httpTraceAll(adminAPI.KMSKeyRotateHandler))
+		adminV1Router.Methods(http.MethodPost).Path("/kms/key/rotate/{bucket}").HandlerFunc(httpTraceAll(adminAPI.KMSKeyRotateHandler))
+		adminV1Router.Methods(http.MethodPost).Path("/kms/key/rotate/{bucket}/{prefix:.*}").HandlerFunc(httpTraceAll(adminAPI.KMSKeyRotateHandler))
+
+		// Data usage info
+		adminV1Router.Methods(http.MethodGet).Path("/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
+
+		// -- Bucket quota APIs --
+
+		// Set bucket quota
+		adminV1Router.Methods(http.MethodPut).Path("/set-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.SetBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+		// Get bucket quota
+		adminV1Router.Methods(http.MethodGet).Path("/get-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+		// Remove bucket quota
+		adminV1Router.Methods(http.MethodDelete).Path("/remove-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.RemoveBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+	}
+
 	// -- Top APIs --
 	// Top locks
 	adminV1Router.Methods(http.MethodGet).Path("/top/locks").HandlerFunc(httpTraceHdrs(adminAPI.TopLocksHandler))
@@ -93,6 +93,10 @@ const (
 	ErrNoSuchBucket
 	ErrNoSuchBucketPolicy
 	ErrNoSuchBucketLifecycle
+	ErrObjectLockConfigurationNotFound
+	ErrReplicationConfigurationNotFoundError
+	ErrReplicationTargetNotFound
+	ErrBucketSSEConfigNotFound
 	ErrNoSuchKey
 	ErrNoSuchUpload
 	ErrNoSuchVersion
@@ -139,6 +143,18 @@ const (
 	ErrMaximumExpires
 	ErrSlowDown
 	ErrInvalidPrefixMarker
+	ErrInvalidVersionIDMarker
+	ErrObjectLocked
+	ErrObjectLockNotEnabled
+	ErrObjectLockVersioningNotEnabled
+	ErrObjectLockVersioningSuspend
+	ErrVersioningFIFOQuota
+	ErrObjectLockInvalidHeaders
+	ErrObjectLockInvalidRetention
+	ErrObjectLockInvalidLegalHold
+	ErrNoSuchObjectLockConfiguration
+	ErrInvalidTag
+	ErrInvalidTagDirective
 	ErrBadRequest
 	ErrKeyTooLongError
 	// Add new error codes here.
@@ -173,6 +189,9 @@ const (
 	ErrFilterNamePrefix
 	ErrFilterNameSuffix
 	ErrFilterValueInvalid
+	ErrObjectFilterNameInvalid
+	ErrObjectFilterNameDuplicate
+	ErrObjectFilterValueInvalid
 	ErrOverlappingConfigs
 	ErrUnsupportedNotification
 
@@ -203,6 +222,11 @@ const (
 
 	ErrMalformedJSON
 	ErrAdminNoSuchUser
+	ErrAdminNoSuchGroup
+	ErrAdminGroupNotEmpty
+	ErrAdminNoSuchQuotaConfiguration
+	ErrAdminBucketQuotaExceeded
+	ErrAdminBucketQuotaVersioned
 	ErrAdminNoSuchPolicy
 	ErrAdminInvalidArgument
 	ErrAdminInvalidAccessKey
@@ -221,6 +245,10 @@ const (
 	ErrHealMissingBucket
 	ErrHealAlreadyRunning
 	ErrHealOverlappingPaths
+	ErrKMSKeyRotateNoSuchProcess
+	ErrKMSKeyRotateInvalidClientToken
+	ErrKMSKeyRotateAlreadyRunning
+	ErrKMSKeyRotateOverlappingPaths
 	ErrIncorrectContinuationToken
 
 	// S3 Select Errors
@@ -473,6 +501,26 @@ var errorCodes = errorCodeMap{
 		Description:    "The bucket lifecycle configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrObjectLockConfigurationNotFound: {
+		Code:           "ObjectLockConfigurationNotFoundError",
+		Description:    "Object Lock configuration does not exist for this bucket",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrReplicationConfigurationNotFoundError: {
+		Code:           "ReplicationConfigurationNotFoundError",
+		Description:    "The replication configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrReplicationTargetNotFound: {
+		Code:           "InvalidRequest",
+		Description:    "The remote target of the replication destination is not configured",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketSSEConfigNotFound: {
+		Code:           "ServerSideEncryptionConfigurationNotFoundError",
+		Description:    "The server side encryption configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrNoSuchKey: {
 		Code:           "NoSuchKey",
 		Description:    "The specified key does not exist.",
@@ -685,6 +733,66 @@ var errorCodes = errorCodeMap{
 		Description:    "Invalid marker prefix combination",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidVersionIDMarker: {
+		Code:           "InvalidArgument",
+		Description:    "A version-id marker cannot be specified without a key marker.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLocked: {
+		Code:           "AccessDenied",
+		Description:    "Object is WORM protected and cannot be overwritten or deleted",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrObjectLockNotEnabled: {
+		Code:           "InvalidRequest",
+		Description:    "Bucket is missing ObjectLockConfiguration",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLockVersioningNotEnabled: {
+		Code:           "InvalidBucketState",
+		Description:    "Versioning must be 'Enabled' on the bucket to apply a Object Lock configuration",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrObjectLockVersioningSuspend: {
+		Code:           "InvalidBucketState",
+		Description:    "An Object Lock configuration is present on this bucket, so the versioning state cannot be changed.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrVersioningFIFOQuota: {
+		Code:           "InvalidBucketState",
+		Description:    "A FIFO quota is configured on this bucket, so versioning cannot be enabled.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrObjectLockInvalidHeaders: {
+		Code:           "InvalidArgument",
+		Description:    "x-amz-object-lock-retain-until-date and x-amz-object-lock-mode must both be supplied with a valid mode and a future date",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLockInvalidRetention: {
+		Code:           "InvalidRequest",
+		Description:    "The retention period can not be shortened, removed or have its mode changed while it is in effect",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLockInvalidLegalHold: {
+		Code:           "InvalidArgument",
+		Description:    "Legal Hold must be either of 'ON' or 'OFF'",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchObjectLockConfiguration: {
+		Code:           "NoSuchObjectLockConfiguration",
+		Description:    "The specified object does not have a ObjectLock configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag. This error can occur if the tag did not pass input validation.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTagDirective: {
+		Code:           "InvalidArgument",
+		Description:    "Unknown tag directive.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrBadRequest: {
 		Code:           "BadRequest",
 		Description:    "400 BadRequest",
@@ -759,6 +867,21 @@ var errorCodes = errorCodeMap{
 		Description:    "Size of filter rule value cannot exceed 1024 bytes in UTF-8 representation",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrObjectFilterNameInvalid: {
+		Code:           "InvalidArgument",
+		Description:    "object filter rule name must be either min-size, max-size, content-type or start with x-amz-meta-",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectFilterNameDuplicate: {
+		Code:           "InvalidArgument",
+		Description:    "Cannot specify more than one object filter rule with the same name in a filter.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectFilterValueInvalid: {
+		Code:           "InvalidArgument",
+		Description:    "min-size and max-size filter rule values must be sizes in bytes with min-size not exceeding max-size, and other values cannot exceed 1024 bytes in UTF-8 representation",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrOverlappingConfigs: {
 		Code:           "InvalidArgument",
 		Description:    "Configurations overlap. Configurations on the same bucket cannot share a common event type.",
@@ -923,6 +1046,31 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified user does not exist.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchGroup: {
+		Code:           "XMinioAdminNoSuchGroup",
+		Description:    "The specified group does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminGroupNotEmpty: {
+		Code:           "XMinioAdminGroupNotEmpty",
+		Description:    "The specified group is not empty - cannot remove it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchQuotaConfiguration: {
+		Code:           "XMinioAdminNoSuchQuotaConfiguration",
+		Description:    "The quota configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminBucketQuotaExceeded: {
+		Code:           "XMinioAdminBucketQuotaExceeded",
+		Description:    "Bucket quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminBucketQuotaVersioned: {
+		Code:           "XMinioAdminBucketQuotaVersioned",
+		Description:    "FIFO quotas are not supported on buckets with versioning",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrAdminNoSuchPolicy: {
 		Code:           "XMinioAdminNoSuchPolicy",
 		Description:    "The canned policy does not exist.",
@@ -1043,6 +1191,26 @@ var errorCodes = errorCodeMap{
 		Description:    "",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrKMSKeyRotateNoSuchProcess: {
+		Code:           "XMinioKMSKeyRotateNoSuchProcess",
+		Description:    "No such KMS key rotation is running on the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrKMSKeyRotateInvalidClientToken: {
+		Code:           "XMinioKMSKeyRotateInvalidClientToken",
+		Description:    "Client token mismatch",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrKMSKeyRotateAlreadyRunning: {
+		Code:           "XMinioKMSKeyRotateAlreadyRunning",
+		Description:    "",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrKMSKeyRotateOverlappingPaths: {
+		Code:           "XMinioKMSKeyRotateOverlappingPaths",
+		Description:    "",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrBackendDown: {
 		Code:           "XMinioBackendDown",
 		Description:    "Object storage backend is unreachable",
@@ -1500,6 +1668,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminInvalidArgument
 	case errNoSuchUser:
 		apiErr = ErrAdminNoSuchUser
+	case errNoSuchGroup:
+		apiErr = ErrAdminNoSuchGroup
+	case errGroupNotEmpty:
+		apiErr = ErrAdminGroupNotEmpty
 	case errNoSuchPolicy:
 		apiErr = ErrAdminNoSuchPolicy
 	case errSignatureMismatch:
@@ -1595,6 +1767,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchKey
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
+	case VersionNotFound:
+		apiErr = ErrNoSuchVersion
+	case MethodNotAllowed:
+		apiErr = ErrMethodNotAllowed
+	case ObjectLocked:
+		apiErr = ErrObjectLocked
 	case ObjectNameInvalid:
 		apiErr = ErrInvalidObjectName
 	case ObjectNamePrefixAsSlash:
@@ -1635,6 +1813,20 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketPolicy
 	case BucketLifecycleNotFound:
 		apiErr = ErrNoSuchBucketLifecycle
+	case RemoteTierNotFound:
+		apiErr = ErrInvalidStorageClass
+	case BucketObjectLockConfigNotFound:
+		apiErr = ErrObjectLockConfigurationNotFound
+	case BucketReplicationConfigNotFound:
+		apiErr = ErrReplicationConfigurationNotFoundError
+	case ReplicationTargetNotFound:
+		apiErr = ErrReplicationTargetNotFound
+	case BucketSSEConfigNotFound:
+		apiErr = ErrBucketSSEConfigNotFound
+	case BucketQuotaConfigNotFound:
+		apiErr = ErrAdminNoSuchQuotaConfiguration
+	case BucketQuotaExceeded:
+		apiErr = ErrAdminBucketQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -1651,6 +1843,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrFilterNameSuffix
 	case *event.ErrInvalidFilterValue:
 		apiErr = ErrFilterValueInvalid
+	case *event.ErrInvalidObjectFilterName:
+		apiErr = ErrObjectFilterNameInvalid
+	case *event.ErrDuplicateObjectFilterName:
+		apiErr = ErrObjectFilterNameDuplicate
+	case *event.ErrInvalidObjectFilterValue:
+		apiErr = ErrObjectFilterValueInvalid
 	case *event.ErrDuplicateEventName:
 		apiErr = ErrOverlappingConfigs
 	case *event.ErrDuplicateQueueConfiguration:
@@ -99,21 +99,34 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 			// values to client.
 			continue
 		}
+		if k == xhttp.AmzObjectTagging {
+			// Tags are returned by GetObjectTagging, only
+			// their count is sent along with the object.
+			continue
+		}
 		w.Header().Set(k, v)
 	}
 
+	if objInfo.TransitionTier != "" {
+		w.Header().Set(amzStorageClassCanonical, objInfo.TransitionTier)
+	}
+
+	if tags := getObjectTags(objInfo.UserDefined); tags.Count() > 0 {
+		w.Header().Set(xhttp.AmzTagCount, strconv.Itoa(tags.Count()))
+	}
+
 	var totalObjectSize int64
 	switch {
-	case crypto.IsEncrypted(objInfo.UserDefined):
-		totalObjectSize, err = objInfo.DecryptedSize()
-		if err != nil {
-			return err
-		}
 	case objInfo.IsCompressed():
 		totalObjectSize = objInfo.GetActualSize()
 		if totalObjectSize < 0 {
 			return errInvalidDecompressedSize
 		}
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		totalObjectSize, err = objInfo.DecryptedSize()
+		if err != nil {
+			return err
+		}
 	default:
 		totalObjectSize = objInfo.Size
 	}
@@ -133,3 +146,20 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 
 	return nil
 }
+
+// setVersionHeaders - sets the version ID and delete marker headers of an
+// object version, only objects in buckets which have versioning
+// configured report a version ID.
+func setVersionHeaders(w http.ResponseWriter, objInfo ObjectInfo, opts ObjectOptions) {
+	if !opts.isVersioningConfigured() && objInfo.VersionID == "" {
+		return
+	}
+	versionID := objInfo.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	w.Header().Set(xhttp.AmzVersionID, versionID)
+	if objInfo.DeleteMarker {
+		w.Header().Set(xhttp.AmzDeleteMarker, "true")
+	}
+}
@@ -313,11 +313,13 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 	}
 
 	if cred.AccessKey == "" {
+		conditionValues := getConditionValues(r, locationConstraint, "")
+		addExistingObjectTagConditions(ctx, r, action, bucketName, objectName, conditionValues)
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, locationConstraint, ""),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -326,11 +328,16 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 		return ErrAccessDenied
 	}
 
+	conditionValues := getConditionValues(r, "", cred.AccessKey)
+	if !owner {
+		// Policies never apply to the owner, avoid looking up the object.
+		addExistingObjectTagConditions(ctx, r, action, bucketName, objectName, conditionValues)
+	}
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
@@ -458,6 +465,13 @@ func (a authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // call verifies bucket policies and IAM policies, supports multi user
 // checks etc.
 func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request) (s3Err APIErrorCode) {
+	return isActionAllowed(atype, bucketName, objectName, r, policy.PutObjectAction)
+}
+
+// isActionAllowed - check if an additional action of an already authenticated
+// request, for example a PUT operation which sets object lock or tags, is allowed
+// on the resource, the request signature is not verified again.
+func isActionAllowed(atype authType, bucketName, objectName string, r *http.Request, action policy.Action) (s3Err APIErrorCode) {
 	var cred auth.Credentials
 	var owner bool
 	switch atype {
@@ -481,7 +495,7 @@ func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request
 	if cred.AccessKey == "" {
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
-			Action:          policy.PutObjectAction,
+			Action:          action,
 			BucketName:      bucketName,
 			ConditionValues: getConditionValues(r, "", ""),
 			IsOwner:         false,
@@ -494,7 +508,7 @@ func isPutAllowed(atype authType, bucketName, objectName string, r *http.Request
 
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
-		Action:          policy.PutObjectAction,
+		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
 		ConditionValues: getConditionValues(r, "", cred.AccessKey),
 		ObjectName:      objectName,
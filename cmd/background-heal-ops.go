@@ -21,9 +21,13 @@ import (
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 )
 
+// User agent of events sent for healed objects.
+const healUserAgent = "Internal: [HEAL]"
+
 // healTask represents what to heal along with options
 //   path: '/' =>  Heal disk formats along with metadata
 //   path: 'bucket/' or '/bucket/' => Heal bucket
@@ -156,5 +160,24 @@ func bgHealObject(ctx context.Context, bucket, object string, opts madmin.HealOp
 	if objectAPI == nil {
 		return madmin.HealResultItem{}, errServerNotInitialized
 	}
-	return objectAPI.HealObject(ctx, bucket, object, opts.DryRun, opts.Remove, opts.ScanMode)
+	res, err := objectAPI.HealObject(ctx, bucket, object, opts.DryRun, opts.Remove, opts.ScanMode)
+	if err != nil || opts.DryRun || isMinioMetaBucketName(bucket) {
+		return res, err
+	}
+
+	// Notify object healed event, if any drive was repaired.
+	if before, after := res.GetOnlineCounts(); after > before {
+		sendEvent(eventArgs{
+			EventName:  event.ObjectHealed,
+			BucketName: bucket,
+			Object: ObjectInfo{
+				Bucket: bucket,
+				Name:   object,
+				Size:   res.ObjectSize,
+			},
+			Host:      globalMinioHost,
+			UserAgent: healUserAgent,
+		})
+	}
+	return res, nil
 }
@@ -0,0 +1,231 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/sse"
+)
+
+const (
+	// Encryption configuration file.
+	bucketSSEConfig = "bucket-encryption.xml"
+)
+
+// BucketSSEConfigSys - Bucket default encryption subsystem.
+type BucketSSEConfigSys struct {
+	sync.RWMutex
+	bucketSSEConfigMap map[string]sse.Config
+}
+
+// Set - sets encryption config to given bucket name.
+func (sys *BucketSSEConfigSys) Set(bucketName string, config sse.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketSSEConfigMap[bucketName] = config
+}
+
+// Get - gets encryption config associated to a given bucket name.
+func (sys *BucketSSEConfigSys) Get(bucketName string) (config sse.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketSSEConfigMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes encryption config for given bucket name.
+func (sys *BucketSSEConfigSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketSSEConfigMap, bucketName)
+}
+
+// validateBucketSSEConfig - verifies that the server is able to
+// apply the default encryption of the configuration.
+func validateBucketSSEConfig(config *sse.Config) error {
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+	return nil
+}
+
+// setBucketDefaultEncryption - requests server side encryption of an
+// object written to a bucket with a default encryption configuration,
+// unless the request specifies an encryption itself.
+func setBucketDefaultEncryption(bucket string, header http.Header) {
+	if crypto.SSEC.IsRequested(header) || crypto.S3.IsRequested(header) || crypto.S3KMS.IsRequested(header) {
+		return
+	}
+	config, ok := globalBucketSSEConfigSys.Get(bucket)
+	if !ok {
+		return
+	}
+	if config.Algorithm() == sse.AWSKms {
+		header.Set(crypto.SSEHeader, crypto.SSEAlgorithmKMS)
+		if keyID := config.KeyID(); keyID != "" {
+			header.Set(crypto.SSEKmsID, keyID)
+		}
+		return
+	}
+	header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+}
+
+func saveBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *sse.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketSSEConfig - get encryption config for given bucket name.
+func getBucketSSEConfig(objAPI ObjectLayer, bucketName string) (*sse.Config, error) {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return sse.ParseConfig(bytes.NewReader(configData))
+}
+
+func removeBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketSSEConfigSys - creates new encryption system.
+func NewBucketSSEConfigSys() *BucketSSEConfigSys {
+	return &BucketSSEConfigSys{
+		bucketSSEConfigMap: make(map[string]sse.Config),
+	}
+}
+
+// Init - initializes encryption system from bucket-encryption.xml of all buckets.
+func (sys *BucketSSEConfigSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	defer func() {
+		// Refresh BucketSSEConfigSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketSSEConfigInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing encryption needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketSSEConfigSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for encryption subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// Refresh BucketSSEConfigSys.
+func (sys *BucketSSEConfigSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketSSEConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketSSEConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the encryption
+// config for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding encryption config during sys.refresh()
+func (sys *BucketSSEConfigSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketSSEConfigMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketSSEConfigMap, bucket)
+		}
+	}
+}
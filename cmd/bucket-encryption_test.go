@@ -0,0 +1,134 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/sse"
+)
+
+// Wrapper for calling bucket encryption config tests for both XL multiple disks and single node setup.
+func TestBucketSSEConfig(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketSSEConfig)
+}
+
+// Unit test for storing, loading and removing bucket encryption configurations.
+func testBucketSSEConfig(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if _, err := obj.GetBucketSSEConfig(ctx, bucket); err != (BucketSSEConfigNotFound{Bucket: bucket}) {
+		t.Fatalf("%s: expected BucketSSEConfigNotFound, got %v", instanceType, err)
+	}
+
+	config, err := sse.ParseConfig(strings.NewReader(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>` +
+		`<SSEAlgorithm>aws:kms</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`))
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err = obj.SetBucketSSEConfig(ctx, bucket, config); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	stored, err := obj.GetBucketSSEConfig(ctx, bucket)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if stored.Algorithm() != sse.AWSKms {
+		t.Fatalf("%s: expected algorithm %s, got %s", instanceType, sse.AWSKms, stored.Algorithm())
+	}
+
+	sys := NewBucketSSEConfigSys()
+	if err = sys.Init(obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, ok := sys.Get(bucket); !ok {
+		t.Fatalf("%s: expected encryption config to be loaded", instanceType)
+	}
+
+	if err = obj.DeleteBucketSSEConfig(ctx, bucket); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err = obj.DeleteBucketSSEConfig(ctx, bucket); err != (BucketSSEConfigNotFound{Bucket: bucket}) {
+		t.Fatalf("%s: expected BucketSSEConfigNotFound, got %v", instanceType, err)
+	}
+}
+
+func TestValidateBucketSSEConfig(t *testing.T) {
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+
+	sseS3 := &sse.Config{Rules: []sse.Rule{{DefaultEncryptionAction: &sse.ApplySSEByDefault{SSEAlgorithm: sse.AES256}}}}
+	sseKMS := &sse.Config{Rules: []sse.Rule{{DefaultEncryptionAction: &sse.ApplySSEByDefault{SSEAlgorithm: sse.AWSKms, KMSMasterKeyID: "my-key"}}}}
+
+	GlobalKMS = nil
+	if err := validateBucketSSEConfig(sseS3); err != errKMSNotConfigured {
+		t.Fatalf("expected errKMSNotConfigured, got %v", err)
+	}
+
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+	if err := validateBucketSSEConfig(sseS3); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateBucketSSEConfig(sseKMS); err != nil {
+		t.Fatal(err)
+	}
+	// SSE-KMS may use any key of the KMS.
+	globalKMSKeyID = "other-key"
+	if err := validateBucketSSEConfig(sseKMS); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetBucketDefaultEncryption(t *testing.T) {
+	defer func(sys *BucketSSEConfigSys) { globalBucketSSEConfigSys = sys }(globalBucketSSEConfigSys)
+
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+	globalBucketSSEConfigSys.Set("encrypted", sse.Config{Rules: []sse.Rule{{DefaultEncryptionAction: &sse.ApplySSEByDefault{SSEAlgorithm: sse.AES256}}}})
+	globalBucketSSEConfigSys.Set("kms", sse.Config{Rules: []sse.Rule{{DefaultEncryptionAction: &sse.ApplySSEByDefault{SSEAlgorithm: sse.AWSKms, KMSMasterKeyID: "my-key"}}}})
+
+	testCases := []struct {
+		bucket      string
+		header      http.Header
+		expectSSE   string
+		expectKeyID string
+	}{
+		{"plain", http.Header{}, "", ""},
+		{"encrypted", http.Header{}, crypto.SSEAlgorithmAES256, ""},
+		{"kms", http.Header{}, crypto.SSEAlgorithmKMS, "my-key"},
+		// SSE-C requests are left untouched.
+		{"encrypted", http.Header{crypto.SSECAlgorithm: []string{crypto.SSEAlgorithmAES256}}, "", ""},
+		{"encrypted", http.Header{crypto.SSEHeader: []string{crypto.SSEAlgorithmAES256}}, crypto.SSEAlgorithmAES256, ""},
+		{"kms", http.Header{crypto.SSEHeader: []string{crypto.SSEAlgorithmAES256}}, crypto.SSEAlgorithmAES256, ""},
+	}
+
+	for i, testCase := range testCases {
+		setBucketDefaultEncryption(testCase.bucket, testCase.header)
+		if sseHeader := testCase.header.Get(crypto.SSEHeader); sseHeader != testCase.expectSSE {
+			t.Errorf("Test %d: expected SSE header %q, got %q", i+1, testCase.expectSSE, sseHeader)
+		}
+		if keyID := testCase.header.Get(crypto.SSEKmsID); keyID != testCase.expectKeyID {
+			t.Errorf("Test %d: expected KMS key ID %q, got %q", i+1, testCase.expectKeyID, keyID)
+		}
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
@@ -311,20 +312,32 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	}
 
 	deleteObjectsFn := objectAPI.DeleteObjects
+	deleteObjectFn := objectAPI.DeleteObject
 	if api.CacheAPI() != nil {
 		deleteObjectsFn = api.CacheAPI().DeleteObjects
+		deleteObjectFn = api.CacheAPI().DeleteObject
 	}
 
+	opts := ObjectOptions{}
+	setVersioningOpts(bucket, &opts)
+
 	type delObj struct {
 		origIndex int
 		name      string
+		versionID string
+		bypass    bool
 	}
 
 	var objectsToDelete []delObj
 	var dErrs = make([]APIErrorCode, len(deleteObjects.Objects))
+	var dInfos = make([]ObjectInfo, len(deleteObjects.Objects))
+
+	// Objects are deleted one by one when versioning is involved,
+	// each of them may result in a delete marker.
+	perObject := opts.isVersioningConfigured()
 
 	for index, object := range deleteObjects.Objects {
-		if dErrs[index] = checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object.ObjectName); dErrs[index] != ErrNone {
+		if dErrs[index] = checkRequestAuthType(ctx, r, objectVersionAction(policy.DeleteObjectAction, object.VersionID), bucket, object.ObjectName); dErrs[index] != ErrNone {
 			if dErrs[index] == ErrSignatureDoesNotMatch || dErrs[index] == ErrInvalidAccessKeyID {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(dErrs[index]), r.URL, guessIsBrowserReq(r))
 				return
@@ -332,43 +345,70 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			continue
 		}
 
-		objectsToDelete = append(objectsToDelete, delObj{index, object.ObjectName})
-	}
-
-	toNames := func(input []delObj) (output []string) {
-		output = make([]string, len(input))
-		for i := range input {
-			output[i] = input[i].name
+		if object.VersionID != "" {
+			perObject = true
+		}
+		bypass := isBypassGovernanceAllowed(r, bucket, object.ObjectName)
+		objectsToDelete = append(objectsToDelete, delObj{index, object.ObjectName, object.VersionID, bypass})
+	}
+
+	if perObject {
+		for _, obj := range objectsToDelete {
+			objOpts := opts
+			objOpts.VersionID = obj.versionID
+			objOpts.BypassGovernance = obj.bypass
+			objInfo, err := deleteObjectFn(ctx, bucket, obj.name, objOpts)
+			dErrs[obj.origIndex] = toAPIErrorCode(ctx, err)
+			dInfos[obj.origIndex] = objInfo
+		}
+	} else {
+		toNames := func(input []delObj) (output []string) {
+			output = make([]string, len(input))
+			for i := range input {
+				output[i] = input[i].name
+			}
+			return
 		}
-		return
-	}
 
-	errs, err := deleteObjectsFn(ctx, bucket, toNames(objectsToDelete))
-	if err != nil {
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-		return
-	}
+		errs, err := deleteObjectsFn(ctx, bucket, toNames(objectsToDelete), opts)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
 
-	for i, obj := range objectsToDelete {
-		dErrs[obj.origIndex] = toAPIErrorCode(ctx, errs[i])
+		for i, obj := range objectsToDelete {
+			dErrs[obj.origIndex] = toAPIErrorCode(ctx, errs[i])
+		}
 	}
 
 	// Collect deleted objects and errors if any.
-	var deletedObjects []ObjectIdentifier
+	var deletedObjects []DeletedObject
 	var deleteErrors []DeleteError
 	for index, errCode := range dErrs {
 		object := deleteObjects.Objects[index]
 		// Success deleted objects are collected separately.
 		if errCode == ErrNone || errCode == ErrNoSuchKey {
-			deletedObjects = append(deletedObjects, object)
+			dobj := DeletedObject{
+				ObjectName: object.ObjectName,
+				VersionID:  object.VersionID,
+			}
+			if dInfos[index].DeleteMarker {
+				dobj.DeleteMarker = true
+				dobj.DeleteMarkerVersionID = dInfos[index].VersionID
+				if dobj.DeleteMarkerVersionID == "" {
+					dobj.DeleteMarkerVersionID = nullVersionID
+				}
+			}
+			deletedObjects = append(deletedObjects, dobj)
 			continue
 		}
 		apiErr := getAPIError(errCode)
 		// Error during delete should be collected separately.
 		deleteErrors = append(deleteErrors, DeleteError{
-			Code:    apiErr.Code,
-			Message: apiErr.Description,
-			Key:     object.ObjectName,
+			Code:      apiErr.Code,
+			Message:   apiErr.Description,
+			Key:       object.ObjectName,
+			VersionID: object.VersionID,
 		})
 	}
 
@@ -381,12 +421,22 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 	// Notify deleted event for objects.
 	for _, dobj := range deletedObjects {
+		if dobj.VersionID == "" && !isReplicaRequest(r) {
+			scheduleDeleteReplication(ctx, bucket, dobj.ObjectName)
+		}
+		eventName := event.ObjectRemovedDelete
+		objInfo := ObjectInfo{
+			Name:      dobj.ObjectName,
+			VersionID: dobj.VersionID,
+		}
+		if dobj.DeleteMarker && dobj.VersionID == "" {
+			eventName = event.ObjectRemovedDeleteMarkerCreated
+			objInfo.VersionID = dobj.DeleteMarkerVersionID
+		}
 		sendEvent(eventArgs{
-			EventName:  event.ObjectRemovedDelete,
-			BucketName: bucket,
-			Object: ObjectInfo{
-				Name: dobj.ObjectName,
-			},
+			EventName:    eventName,
+			BucketName:   bucket,
+			Object:       objInfo,
 			ReqParams:    extractReqParams(r),
 			RespElements: extractRespElements(w),
 			UserAgent:    r.UserAgent(),
@@ -431,6 +481,9 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Object lock may only be enabled while creating a bucket.
+	objectLockEnabled := objectlock.IsBucketObjectLockRequested(r.Header)
+
 	if globalDNSConfig != nil {
 		if _, err := globalDNSConfig.Get(bucket); err != nil {
 			if err == dns.ErrNoEntriesFound {
@@ -444,6 +497,12 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 					return
 				}
+				if objectLockEnabled {
+					if err = enableBucketObjectLock(ctx, objectAPI, bucket); err != nil {
+						writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+						return
+					}
+				}
 
 				// Make sure to add Location information here only for bucket
 				w.Header().Set(xhttp.Location,
@@ -467,6 +526,13 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if objectLockEnabled {
+		if err = enableBucketObjectLock(ctx, objectAPI, bucket); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// Make sure to add Location information here only for bucket
 	w.Header().Set(xhttp.Location, path.Clean(r.URL.Path)) // Clean any trailing slashes.
 
@@ -628,6 +694,9 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	// Apply the default retention of the bucket, if any.
+	setDefaultRetention(bucket, metadata)
+
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize, globalCLIContext.StrictS3Compat)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -642,6 +711,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	// The encryption of the upload is specified by the form fields.
+	setBucketDefaultEncryption(bucket, formValues)
 	// get gateway encryption options
 	var opts ObjectOptions
 	opts, err = putOpts(ctx, r, bucket, object, metadata)
@@ -649,8 +720,9 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setVersioningOpts(bucket, &opts)
 	if objectAPI.IsEncryptionSupported() {
-		if hasServerSideEncryptionHeader(formValues) && !hasSuffix(object, slashSeparator) { // handle SSE-C and SSE-S3 requests
+		if hasServerSideEncryptionHeader(formValues) && !hasSuffix(object, slashSeparator) { // handle SSE-C, SSE-S3 and SSE-KMS requests
 			var reader io.Reader
 			var key []byte
 			if crypto.SSEC.IsRequested(formValues) {
@@ -660,7 +732,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 					return
 				}
 			}
-			reader, objectEncryptionKey, err = newEncryptReader(hashReader, key, bucket, object, metadata, crypto.S3.IsRequested(formValues))
+			reader, objectEncryptionKey, err = newEncryptReader(formValues, hashReader, key, bucket, object, metadata)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -802,6 +874,16 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 	globalNotificationSys.DeleteBucket(ctx, bucket)
 	globalLifecycleSys.Remove(bucket)
 	globalNotificationSys.RemoveBucketLifecycle(ctx, bucket)
+	globalBucketVersioningSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketVersioning(ctx, bucket)
+	globalBucketObjectLockSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketObjectLockConfig(ctx, bucket)
+	globalBucketReplicationSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketReplication(ctx, bucket)
+	globalBucketSSEConfigSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketSSEConfig(ctx, bucket)
+	globalBucketQuotaSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketQuota(ctx, bucket)
 
 	// Write success response.
 	writeSuccessNoContent(w)
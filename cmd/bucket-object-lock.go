@@ -0,0 +1,404 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/objectlock"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+const (
+	// Object lock configuration file.
+	bucketObjectLockConfig = "object-lock.xml"
+)
+
+// BucketObjectLockSys - Bucket object lock subsystem.
+type BucketObjectLockSys struct {
+	sync.RWMutex
+	bucketObjectLockMap map[string]objectlock.Config
+}
+
+// Set - sets object lock config to given bucket name.
+func (sys *BucketObjectLockSys) Set(bucketName string, c objectlock.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketObjectLockMap[bucketName] = c
+}
+
+// Get - gets object lock config associated to a given bucket name.
+func (sys *BucketObjectLockSys) Get(bucketName string) (c objectlock.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	c, ok = sys.bucketObjectLockMap[bucketName]
+	return c, ok
+}
+
+// Enabled - returns true if object lock is enabled on the given bucket.
+func (sys *BucketObjectLockSys) Enabled(bucketName string) bool {
+	_, ok := sys.Get(bucketName)
+	return ok
+}
+
+// Remove - removes object lock config for given bucket name.
+func (sys *BucketObjectLockSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketObjectLockMap, bucketName)
+}
+
+func saveObjectLockConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *objectlock.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getObjectLockConfig - get object lock config for given bucket name.
+func getObjectLockConfig(objAPI ObjectLayer, bucketName string) (*objectlock.Config, error) {
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketObjectLockConfigNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return objectlock.ParseConfig(bytes.NewReader(configData))
+}
+
+// NewBucketObjectLockSys - creates new object lock system.
+func NewBucketObjectLockSys() *BucketObjectLockSys {
+	return &BucketObjectLockSys{
+		bucketObjectLockMap: make(map[string]objectlock.Config),
+	}
+}
+
+// Init - initializes object lock system from object-lock.xml of all buckets.
+func (sys *BucketObjectLockSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	defer func() {
+		// Refresh BucketObjectLockSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketObjectLockInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing object lock needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketObjectLockSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for object lock subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// Refresh BucketObjectLockSys.
+func (sys *BucketObjectLockSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketObjectLockConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketObjectLockConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the object lock
+// config for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding object lock config during sys.refresh()
+func (sys *BucketObjectLockSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketObjectLockMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketObjectLockMap, bucket)
+		}
+	}
+}
+
+// enableBucketObjectLock - enables versioning and object lock without
+// default retention on a newly created bucket.
+func enableBucketObjectLock(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	bucketVersioning := &versioning.Versioning{
+		XMLNS:  "http://s3.amazonaws.com/doc/2006-03-01/",
+		Status: versioning.Enabled,
+	}
+	if err := objAPI.SetBucketVersioning(ctx, bucket, bucketVersioning); err != nil {
+		return err
+	}
+	globalBucketVersioningSys.Set(bucket, *bucketVersioning)
+	globalNotificationSys.SetBucketVersioning(ctx, bucket, bucketVersioning)
+
+	config := objectlock.NewConfig()
+	if err := objAPI.SetBucketObjectLockConfig(ctx, bucket, config); err != nil {
+		return err
+	}
+	globalBucketObjectLockSys.Set(bucket, *config)
+	globalNotificationSys.SetBucketObjectLockConfig(ctx, bucket, config)
+	return nil
+}
+
+// enforceRetentionForDeletion - returns ObjectLocked if the object version
+// is under legal hold, or under a retention period which is not bypassed.
+// Delete markers are never protected.
+func enforceRetentionForDeletion(objInfo ObjectInfo, opts ObjectOptions) error {
+	if objInfo.DeleteMarker {
+		return nil
+	}
+
+	locked := ObjectLocked{Bucket: objInfo.Bucket, Object: objInfo.Name, VersionID: objInfo.VersionID}
+	if objectlock.GetObjectLegalHoldMeta(objInfo.UserDefined).IsOn() {
+		return locked
+	}
+
+	retention := objectlock.GetObjectRetentionMeta(objInfo.UserDefined)
+	if !retention.IsActive(UTCNow()) {
+		return nil
+	}
+	if retention.Mode == objectlock.Governance && opts.BypassGovernance {
+		return nil
+	}
+	return locked
+}
+
+// enforceRetentionForOverwrite - returns an error if writing a new object
+// destroys its latest version while it is protected by legacy WORM mode
+// or object lock, latestFn returns the latest version. Writes which move
+// the latest version into the version store are always allowed.
+func enforceRetentionForOverwrite(bucket, object string, opts ObjectOptions, latestFn func() (ObjectInfo, error)) error {
+	if opts.Versioned && !globalWORMEnabled {
+		return nil
+	}
+
+	objInfo, err := latestFn()
+	if err != nil || objInfo.DeleteMarker {
+		// Nothing to protect.
+		return nil
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		return ObjectAlreadyExists{Bucket: bucket, Object: object}
+	}
+
+	// Versions with an ID are archived while versioning is suspended.
+	if opts.VersionSuspended && objInfo.VersionID != "" {
+		return nil
+	}
+	return enforceRetentionForDeletion(objInfo, opts)
+}
+
+// isRetentionChangeAllowed - returns true if the current retention of an
+// object may be replaced by the given one. Retention in effect may only be
+// extended with the same mode, unless it is governance and bypassed.
+func isRetentionChangeAllowed(current, retention objectlock.ObjectRetention, bypassGovernance bool) bool {
+	if !current.IsActive(UTCNow()) {
+		return true
+	}
+	if current.Mode == objectlock.Governance && bypassGovernance {
+		return true
+	}
+	return retention.Mode == current.Mode && !retention.RetainUntilDate.Before(current.RetainUntilDate.Time)
+}
+
+// isBypassGovernanceAllowed - returns true if the request asks for governance
+// retention to be bypassed and is allowed to do so. Like all object lock
+// permissions it is checked after the request has been authenticated.
+func isBypassGovernanceAllowed(r *http.Request, bucket, object string) bool {
+	if !objectlock.IsBypassGovernanceRequested(r.Header) {
+		return false
+	}
+	return isActionAllowed(getRequestAuthType(r), bucket, object, r, policy.BypassGovernanceRetentionAction) == ErrNone
+}
+
+// setDefaultRetention - saves the default retention of the bucket, if
+// any, in the metadata of a new object.
+func setDefaultRetention(bucket string, metadata map[string]string) {
+	removeObjectLockMetadata(metadata)
+
+	config, ok := globalBucketObjectLockSys.Get(bucket)
+	if !ok {
+		return
+	}
+	if dr, ok := config.DefaultRetention(); ok {
+		metadata[objectlock.AmzObjectLockMode] = string(dr.Mode)
+		metadata[objectlock.AmzObjectLockRetainUntilDate] = objectlock.FormatRetainUntilDate(dr.RetainUntil(UTCNow()))
+	}
+}
+
+// setObjectLockMetadata - validates the object lock headers of a write
+// request and saves the requested retention and legal hold in metadata,
+// the default retention of the bucket applies if none is requested.
+func setObjectLockMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	if !objectlock.IsObjectLockRequested(r.Header) {
+		setDefaultRetention(bucket, metadata)
+		return ErrNone
+	}
+
+	removeObjectLockMetadata(metadata)
+	return parseObjectLockMetadata(r, bucket, object, metadata)
+}
+
+// updateObjectLockMetadata - keeps the retention and legal hold of an
+// object whose metadata is updated in place, current is the metadata
+// before the update. The object lock headers of the request replace
+// the retention or legal hold they specify.
+func updateObjectLockMetadata(r *http.Request, bucket, object string, current, metadata map[string]string) APIErrorCode {
+	lockMeta := make(map[string]string)
+	for _, k := range objectLockMetadataKeys {
+		if v, ok := current[k]; ok {
+			lockMeta[k] = v
+		}
+	}
+	removeObjectLockMetadata(metadata)
+	for k, v := range lockMeta {
+		metadata[k] = v
+	}
+
+	if !objectlock.IsObjectLockRequested(r.Header) {
+		return ErrNone
+	}
+	return parseObjectLockMetadata(r, bucket, object, metadata)
+}
+
+// parseObjectLockMetadata - saves the retention and legal hold of the
+// object lock headers of a write request in metadata.
+func parseObjectLockMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	if !globalBucketObjectLockSys.Enabled(bucket) {
+		return ErrObjectLockNotEnabled
+	}
+
+	retention, legalHold, err := objectlock.ParseObjectLockHeaders(r.Header)
+	if err != nil {
+		return ErrObjectLockInvalidHeaders
+	}
+
+	if !retention.IsEmpty() {
+		if s3Err := isActionAllowed(getRequestAuthType(r), bucket, object, r, policy.PutObjectRetentionAction); s3Err != ErrNone {
+			return s3Err
+		}
+		metadata[objectlock.AmzObjectLockMode] = string(retention.Mode)
+		metadata[objectlock.AmzObjectLockRetainUntilDate] = objectlock.FormatRetainUntilDate(retention.RetainUntilDate.Time)
+	}
+	if legalHold.Status != "" {
+		if s3Err := isActionAllowed(getRequestAuthType(r), bucket, object, r, policy.PutObjectLegalHoldAction); s3Err != ErrNone {
+			return s3Err
+		}
+		metadata[objectlock.AmzObjectLockLegalHold] = string(legalHold.Status)
+	}
+	return ErrNone
+}
+
+// enforceRetentionForUpdate - returns an error if an in-place update of
+// the metadata of an object changes its retention or legal hold while it
+// is protected by legacy WORM mode or object lock, objInfo is the object
+// before the update.
+func enforceRetentionForUpdate(objInfo ObjectInfo, metadata map[string]string, opts ObjectOptions) error {
+	changed := false
+	for _, k := range objectLockMetadataKeys {
+		if objInfo.UserDefined[k] != metadata[k] {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// The update replaces the version itself, versioning does not apply.
+	updateOpts := ObjectOptions{BypassGovernance: opts.BypassGovernance}
+	return enforceRetentionForOverwrite(objInfo.Bucket, objInfo.Name, updateOpts, func() (ObjectInfo, error) {
+		return objInfo, nil
+	})
+}
+
+// Metadata keys of the retention and legal hold of an object.
+var objectLockMetadataKeys = []string{
+	objectlock.AmzObjectLockMode,
+	objectlock.AmzObjectLockRetainUntilDate,
+	objectlock.AmzObjectLockLegalHold,
+}
+
+// removeObjectLockMetadata - removes retention and legal hold from metadata.
+func removeObjectLockMetadata(metadata map[string]string) {
+	for _, k := range objectLockMetadataKeys {
+		delete(metadata, k)
+	}
+}
@@ -0,0 +1,463 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Bucket quota configuration file.
+	bucketQuotaConfigFile = "quota.json"
+
+	// Last computed usage of the buckets with a quota.
+	bucketUsageFile = "bucket-usage.json"
+
+	// User agent of events sent for objects deleted by FIFO quotas.
+	bucketQuotaUserAgent = "Internal: [FIFO-QUOTA]"
+)
+
+// BucketQuotaSys - map of bucket and quota configuration, along
+// with the last computed usage of the buckets with a quota.
+type BucketQuotaSys struct {
+	sync.RWMutex
+	quotaMap map[string]madmin.BucketQuota
+	usageMap map[string]uint64
+}
+
+// Set - sets quota configuration to given bucket name.
+func (sys *BucketQuotaSys) Set(bucketName string, q madmin.BucketQuota) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.quotaMap[bucketName] = q
+}
+
+// Get - gets quota configuration associated to a given bucket name.
+func (sys *BucketQuotaSys) Get(bucketName string) (q madmin.BucketQuota, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	q, ok = sys.quotaMap[bucketName]
+	return q, ok
+}
+
+// Remove - removes quota configuration for given bucket name.
+func (sys *BucketQuotaSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.quotaMap, bucketName)
+}
+
+// SetUsage - replaces the usage of all buckets with a quota.
+func (sys *BucketQuotaSys) SetUsage(usage map[string]uint64) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.usageMap = usage
+}
+
+// GetUsage - gets the last computed usage of a bucket.
+func (sys *BucketQuotaSys) GetUsage(bucketName string) uint64 {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	return sys.usageMap[bucketName]
+}
+
+// parseBucketQuota - parses and validates a bucket quota configuration.
+func parseBucketQuota(data []byte) (q madmin.BucketQuota, err error) {
+	if err = json.Unmarshal(data, &q); err != nil {
+		return q, err
+	}
+	if !q.IsValid() {
+		return q, errInvalidArgument
+	}
+	return q, nil
+}
+
+func saveBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, q madmin.BucketQuota) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketQuotaConfig - get quota config for given bucket name.
+func getBucketQuotaConfig(objAPI ObjectLayer, bucketName string) (q madmin.BucketQuota, err error) {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+		return q, err
+	}
+
+	return parseBucketQuota(configData)
+}
+
+func removeBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfigFile)
+
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketQuotaSys - creates new quota system.
+func NewBucketQuotaSys() *BucketQuotaSys {
+	return &BucketQuotaSys{
+		quotaMap: make(map[string]madmin.BucketQuota),
+		usageMap: make(map[string]uint64),
+	}
+}
+
+// Init - initializes quota system from quota.json of all buckets
+// and the last computed bucket usage.
+func (sys *BucketQuotaSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	defer func() {
+		// Refresh BucketQuotaSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketQuotaInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing quotas needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketQuotaSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for quota subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+
+	usage, err := loadBucketUsage(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+	sys.SetUsage(usage)
+	return nil
+}
+
+// Refresh BucketQuotaSys.
+func (sys *BucketQuotaSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		q, err := getBucketQuotaConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketQuotaConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, q)
+	}
+
+	return nil
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the quota
+// config for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding quota config during sys.refresh()
+func (sys *BucketQuotaSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.quotaMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.quotaMap, bucket)
+		}
+	}
+}
+
+// enforceBucketQuota - returns BucketQuotaExceeded if writing size more
+// bytes to the bucket exceeds its hard quota. The check is made against
+// the last computed usage of the bucket.
+func enforceBucketQuota(bucket string, size int64) error {
+	q, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok || q.Type != madmin.HardQuota {
+		return nil
+	}
+	if size < 0 {
+		size = 0
+	}
+	if globalBucketQuotaSys.GetUsage(bucket)+uint64(size) > q.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+	return nil
+}
+
+func saveBucketUsage(ctx context.Context, objAPI ObjectLayer, usage map[string]uint64) error {
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, bucketUsageFile), data)
+}
+
+// loadBucketUsage - loads the last computed usage of the buckets with
+// a quota, the usage is empty if it was never computed.
+func loadBucketUsage(ctx context.Context, objAPI ObjectLayer) (map[string]uint64, error) {
+	usage := make(map[string]uint64)
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, bucketUsageFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return usage, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// initBucketQuotaScanner creates a go-routine which periodically
+// computes the usage of the buckets with a quota.
+func initBucketQuotaScanner() {
+	go bucketQuotaScanner()
+}
+
+// Compute the usage of the buckets with a quota periodically.
+func bucketQuotaScanner() {
+	var objAPI ObjectLayer
+
+	reqInfo := &logger.ReqInfo{API: "BucketQuotaScanner"}
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	// Wait until the object layer is ready
+	for {
+		objAPI = newObjectLayerFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	ticker := time.NewTicker(globalBucketUsageInterval)
+	defer ticker.Stop()
+	for {
+		if err := scanBucketUsage(ctx, objAPI); err != nil {
+			// Unable to hold the lock means that another
+			// instance is computing the bucket usage.
+			if _, ok := err.(OperationTimedOut); !ok {
+				logger.LogIf(ctx, err)
+			}
+		}
+
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanBucketUsage - computes the usage of the buckets with a quota,
+// deletes the oldest objects of buckets exceeding their FIFO quota and
+// shares the usage with all peers.
+func scanBucketUsage(ctx context.Context, objAPI ObjectLayer) error {
+	zeroDuration := time.Millisecond
+	zeroDynamicTimeout := newDynamicTimeout(zeroDuration, zeroDuration)
+
+	// General lock so we avoid parallel scans by different instances.
+	scanLock := globalNSMutex.NewNSLock(ctx, "system", "bucket-usage")
+	if err := scanLock.GetLock(zeroDynamicTimeout); err != nil {
+		return err
+	}
+	defer scanLock.Unlock()
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	usage := make(map[string]uint64)
+	for _, bucket := range buckets {
+		q, ok := globalBucketQuotaSys.Get(bucket.Name)
+		if !ok {
+			continue
+		}
+
+		// Noncurrent versions of objects use space as well, FIFO
+		// quotas are not allowed on buckets with versioning.
+		if isBucketVersioningConfigured(bucket.Name) {
+			size, err := getBucketVersionsSize(ctx, objAPI, bucket.Name)
+			if err != nil {
+				return err
+			}
+			usage[bucket.Name] = size
+			continue
+		}
+
+		var objects []ObjectInfo
+		var size uint64
+		marker := ""
+		for {
+			res, err := objAPI.ListObjects(ctx, bucket.Name, "", marker, "", 1000)
+			if err != nil {
+				return err
+			}
+			for _, obj := range res.Objects {
+				size += uint64(obj.Size)
+				if q.Type == madmin.FIFOQuota {
+					objects = append(objects, obj)
+				}
+			}
+			if !res.IsTruncated {
+				break
+			}
+			marker = res.NextMarker
+		}
+
+		if q.Type == madmin.FIFOQuota && size > q.Quota {
+			size -= enforceFIFOQuota(ctx, objAPI, bucket.Name, objects, size-q.Quota)
+		}
+		usage[bucket.Name] = size
+	}
+
+	if err = saveBucketUsage(ctx, objAPI, usage); err != nil {
+		return err
+	}
+
+	globalBucketQuotaSys.SetUsage(usage)
+	globalNotificationSys.SetBucketUsage(ctx, usage)
+	return nil
+}
+
+// getBucketVersionsSize - returns the size of all versions of the
+// objects of a bucket.
+func getBucketVersionsSize(ctx context.Context, objAPI ObjectLayer, bucket string) (size uint64, err error) {
+	marker, versionIDMarker := "", ""
+	for {
+		res, err := objAPI.ListObjectVersions(ctx, bucket, "", marker, versionIDMarker, "", 1000)
+		if err != nil {
+			return 0, err
+		}
+		for _, obj := range res.Objects {
+			size += uint64(obj.Size)
+		}
+		if !res.IsTruncated {
+			return size, nil
+		}
+		marker, versionIDMarker = res.NextMarker, res.NextVersionIDMarker
+	}
+}
+
+// enforceFIFOQuota - deletes the oldest objects of a bucket until at
+// least toFree bytes are freed, returns the number of bytes freed.
+func enforceFIFOQuota(ctx context.Context, objAPI ObjectLayer, bucket string, objects []ObjectInfo, toFree uint64) (freed uint64) {
+	// Objects are never deleted in WORM mode, deleting the objects of a
+	// bucket with versioning only adds delete markers and frees nothing.
+	if globalWORMEnabled || isBucketVersioningConfigured(bucket) {
+		return 0
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ModTime.Before(objects[j].ModTime)
+	})
+
+	for _, obj := range objects {
+		if freed >= toFree {
+			break
+		}
+
+		opts := ObjectOptions{}
+		if err := enforceRetentionForDeletion(obj, opts); err != nil {
+			continue
+		}
+		deleted, err := objAPI.DeleteObject(ctx, bucket, obj.Name, opts)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		// The object and its data are removed permanently.
+		freed += uint64(obj.Size)
+
+		// Notify object deleted event.
+		if deleted.Name == "" {
+			deleted = obj
+		}
+		sendEvent(eventArgs{
+			EventName:  event.ObjectRemovedServerDelete,
+			BucketName: bucket,
+			Object:     deleted,
+			Host:       globalMinioHost,
+			UserAgent:  bucketQuotaUserAgent,
+		})
+	}
+	return freed
+}
@@ -0,0 +1,218 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+func TestParseBucketQuota(t *testing.T) {
+	testCases := []struct {
+		data      string
+		shouldErr bool
+	}{
+		{`{"quota": 1024, "quotatype": "hard"}`, false},
+		{`{"quota": 1024, "quotatype": "fifo"}`, false},
+		{`{"quota": 0, "quotatype": "hard"}`, true},
+		{`{"quota": 1024, "quotatype": "soft"}`, true},
+		{`{"quota": 1024}`, true},
+		{`{"quota": -1, "quotatype": "hard"}`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseBucketQuota([]byte(testCase.data))
+		if testCase.shouldErr && err == nil {
+			t.Errorf("Test %d: expected error, got nil", i+1)
+		}
+		if !testCase.shouldErr && err != nil {
+			t.Errorf("Test %d: unexpected error %s", i+1, err)
+		}
+	}
+}
+
+// Wrapper for calling bucket quota tests for both XL multiple disks and single node setup.
+func TestBucketQuota(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketQuota)
+}
+
+// Unit test for enforcing hard quotas on writes and for deleting the
+// oldest objects of buckets exceeding their FIFO quota.
+func testBucketQuota(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	hardBucket, fifoBucket := "hard-bucket", "fifo-bucket"
+
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	defer func() { globalBucketQuotaSys = NewBucketQuotaSys() }()
+
+	for _, bucket := range []string{hardBucket, fifoBucket} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		for _, object := range []string{"object1", "object2", "object3"} {
+			if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+				4, "", ""), ObjectOptions{}); err != nil {
+				t.Fatalf("%s: %s", instanceType, err)
+			}
+			// Objects are deleted in the order of their modification time.
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	quotas := map[string]madmin.BucketQuota{
+		hardBucket: {Quota: 14, Type: madmin.HardQuota},
+		fifoBucket: {Quota: 8, Type: madmin.FIFOQuota},
+	}
+	for bucket, q := range quotas {
+		if err := saveBucketQuotaConfig(ctx, obj, bucket, q); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	if err := globalBucketQuotaSys.refresh(obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	for bucket, q := range quotas {
+		if got, ok := globalBucketQuotaSys.Get(bucket); !ok || got != q {
+			t.Fatalf("%s: expected quota %v for %s, got %v", instanceType, q, bucket, got)
+		}
+	}
+
+	if err := scanBucketUsage(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// The hard quota leaves room for 2 more bytes.
+	if usage := globalBucketQuotaSys.GetUsage(hardBucket); usage != 12 {
+		t.Fatalf("%s: expected usage 12, got %d", instanceType, usage)
+	}
+	if err := enforceBucketQuota(hardBucket, 2); err != nil {
+		t.Fatalf("%s: unexpected error %s", instanceType, err)
+	}
+	if err := enforceBucketQuota(hardBucket, 3); err != (BucketQuotaExceeded{Bucket: hardBucket}) {
+		t.Fatalf("%s: expected BucketQuotaExceeded, got %v", instanceType, err)
+	}
+	// FIFO quotas never reject writes.
+	if err := enforceBucketQuota(fifoBucket, 100); err != nil {
+		t.Fatalf("%s: unexpected error %s", instanceType, err)
+	}
+
+	// The oldest object of the FIFO bucket is deleted to get under quota.
+	if usage := globalBucketQuotaSys.GetUsage(fifoBucket); usage != 8 {
+		t.Fatalf("%s: expected usage 8, got %d", instanceType, usage)
+	}
+	if _, err := obj.GetObjectInfo(ctx, fifoBucket, "object1", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected oldest object to be deleted, got %v", instanceType, err)
+	}
+	for _, object := range []string{"object2", "object3"} {
+		if _, err := obj.GetObjectInfo(ctx, fifoBucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: expected object %s to remain, %s", instanceType, object, err)
+		}
+	}
+
+	// Nothing is deleted once versioning is configured on the bucket.
+	globalBucketVersioningSys.Set(fifoBucket, versioning.Versioning{Status: versioning.Suspended})
+	defer globalBucketVersioningSys.Remove(fifoBucket)
+	objInfo, err := obj.GetObjectInfo(ctx, fifoBucket, "object2", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if freed := enforceFIFOQuota(ctx, obj, fifoBucket, []ObjectInfo{objInfo}, 4); freed != 0 {
+		t.Fatalf("%s: expected nothing to be freed, got %d", instanceType, freed)
+	}
+	if _, err = obj.GetObjectInfo(ctx, fifoBucket, "object2", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: expected object2 to remain, %s", instanceType, err)
+	}
+
+	// The computed usage is persisted.
+	usage, err := loadBucketUsage(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if usage[hardBucket] != 12 || usage[fifoBucket] != 8 {
+		t.Fatalf("%s: unexpected persisted usage %v", instanceType, usage)
+	}
+
+	if err = removeBucketQuotaConfig(ctx, obj, hardBucket); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = getBucketQuotaConfig(obj, hardBucket); err != (BucketQuotaConfigNotFound{Bucket: hardBucket}) {
+		t.Fatalf("%s: expected BucketQuotaConfigNotFound, got %v", instanceType, err)
+	}
+}
+
+func TestBucketQuotaVersioned(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketQuotaVersioned)
+}
+
+// Unit test for enforcing hard quotas on buckets with versioning,
+// where noncurrent versions count towards the usage.
+func testBucketQuotaVersioned(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "versioned-bucket"
+
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	defer func() { globalBucketQuotaSys = NewBucketQuotaSys() }()
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+	defer globalBucketVersioningSys.Remove(bucket)
+
+	// Three versions of the same object.
+	for i := 0; i < 3; i++ {
+		opts := ObjectOptions{}
+		setVersioningOpts(bucket, &opts)
+		if _, err := obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewBufferString("data"),
+			4, "", ""), opts); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	// Deleting the object only adds a delete marker.
+	opts := ObjectOptions{}
+	setVersioningOpts(bucket, &opts)
+	if _, err := obj.DeleteObject(ctx, bucket, "object", opts); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if err := saveBucketQuotaConfig(ctx, obj, bucket, madmin.BucketQuota{Quota: 14, Type: madmin.HardQuota}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := globalBucketQuotaSys.refresh(obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := scanBucketUsage(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if usage := globalBucketQuotaSys.GetUsage(bucket); usage != 12 {
+		t.Fatalf("%s: expected usage 12, got %d", instanceType, usage)
+	}
+	if err := enforceBucketQuota(bucket, 2); err != nil {
+		t.Fatalf("%s: unexpected error %s", instanceType, err)
+	}
+	if err := enforceBucketQuota(bucket, 3); err != (BucketQuotaExceeded{Bucket: bucket}) {
+		t.Fatalf("%s: expected BucketQuotaExceeded, got %v", instanceType, err)
+	}
+}
@@ -0,0 +1,452 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/event/target"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// Replication status of objects, saved in object metadata.
+const (
+	// Object is waiting to be replicated.
+	replicationPending = "PENDING"
+	// Object is replicated to the destination bucket.
+	replicationCompleted = "COMPLETED"
+	// Replication of the object failed, it is retried.
+	replicationFailed = "FAILED"
+	// Object is a replica written by the replication of another bucket.
+	replicationReplica = "REPLICA"
+)
+
+const (
+	// Directory of the replication queue, relative to the config directory.
+	replicationQueueDir = "replication"
+
+	// File extension of queued replication tasks.
+	replicationTaskExt = ".task"
+
+	// Number of objects replicated concurrently.
+	replicationWorkers = 8
+
+	// Interval between retries of failed replications.
+	replicationRetryInterval = 30 * time.Second
+
+	// User agent of events sent for replicated objects.
+	replicationUserAgent = "Internal: [REPLICATION]"
+)
+
+// replicationOp - operation replicated to the destination bucket.
+type replicationOp string
+
+const (
+	replicationOpPut    replicationOp = "put"
+	replicationOpDelete replicationOp = "delete"
+)
+
+// replicationTask - replication of a written or deleted object, kept in
+// the replication queue until the destination bucket is up to date.
+type replicationTask struct {
+	Op        replicationOp `json:"op"`
+	Bucket    string        `json:"bucket"`
+	Object    string        `json:"object"`
+	VersionID string        `json:"versionId,omitempty"`
+	ETag      string        `json:"etag,omitempty"`
+}
+
+// replicationQueue - persistent queue of replication tasks, processed
+// by a pool of workers. Tasks which fail are retried from the store.
+type replicationQueue struct {
+	store *target.QueueStore
+	keyCh chan string
+
+	// Keys of the tasks sent to the workers.
+	sync.Mutex
+	inflight map[string]struct{}
+}
+
+// globalReplicationQueue - replication queue of this server, nil
+// until replication is started.
+var globalReplicationQueue *replicationQueue
+
+// newReplicationQueue - opens the replication queue in the given directory.
+func newReplicationQueue(directory string) (*replicationQueue, error) {
+	store := target.NewQueueStore(directory, 0, replicationTaskExt)
+	if err := store.Open(); err != nil {
+		return nil, err
+	}
+	return &replicationQueue{
+		store:    store,
+		keyCh:    make(chan string, 10000),
+		inflight: make(map[string]struct{}),
+	}, nil
+}
+
+// initBucketReplication - starts replicating objects of buckets with a
+// replication configuration, resuming the tasks queued before a restart.
+func initBucketReplication(objAPI ObjectLayer) error {
+	q, err := newReplicationQueue(filepath.Join(globalConfigDir.Get(), replicationQueueDir))
+	if err != nil {
+		return err
+	}
+	q.start(objAPI, GlobalServiceDoneCh)
+	globalReplicationQueue = q
+	return nil
+}
+
+// start - starts the workers of the queue and the retries of queued
+// tasks, until doneCh is closed.
+func (q *replicationQueue) start(objAPI ObjectLayer, doneCh <-chan struct{}) {
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "BucketReplication"})
+
+	for i := 0; i < replicationWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case key := <-q.keyCh:
+					q.process(ctx, objAPI, key)
+				case <-doneCh:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(replicationRetryInterval)
+		defer ticker.Stop()
+		for {
+			for _, key := range q.store.List() {
+				q.dispatch(key)
+			}
+			select {
+			case <-ticker.C:
+			case <-doneCh:
+				return
+			}
+		}
+	}()
+}
+
+// add - persists a replication task and hands it to the workers.
+func (q *replicationQueue) add(ctx context.Context, task replicationTask) {
+	key, err := q.store.Put(task)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to queue replication of %s/%s: %v", task.Bucket, task.Object, err))
+		return
+	}
+	q.dispatch(key)
+}
+
+// dispatch - hands a queued task to the workers unless it is already
+// being processed, tasks which do not fit into the channel are left
+// to be retried.
+func (q *replicationQueue) dispatch(key string) {
+	q.Lock()
+	defer q.Unlock()
+
+	if _, ok := q.inflight[key]; ok {
+		return
+	}
+	select {
+	case q.keyCh <- key:
+		q.inflight[key] = struct{}{}
+	default:
+	}
+}
+
+// process - replicates a queued task, the task is removed from the
+// queue unless it failed.
+func (q *replicationQueue) process(ctx context.Context, objAPI ObjectLayer, key string) {
+	defer func() {
+		q.Lock()
+		delete(q.inflight, key)
+		q.Unlock()
+	}()
+
+	var task replicationTask
+	if err := q.store.Get(key, &task); err != nil {
+		return
+	}
+
+	// Failed tasks stay queued and are retried, failed writes
+	// are reported by the replication status of the object.
+	switch task.Op {
+	case replicationOpDelete:
+		if err := replicateDelete(ctx, objAPI, task); err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+	default:
+		if err := replicateObject(ctx, objAPI, task); err != nil {
+			return
+		}
+	}
+	q.store.Del(key)
+}
+
+// queueReplication - queues a replication task, a no-op until
+// replication is started.
+func queueReplication(ctx context.Context, task replicationTask) {
+	if globalReplicationQueue != nil {
+		globalReplicationQueue.add(ctx, task)
+	}
+}
+
+// isReplicaRequest - returns true if the request is sent by the
+// replication of a bucket to its destination.
+func isReplicaRequest(r *http.Request) bool {
+	return r.Header.Get(xhttp.AmzBucketReplicationStatus) == replicationReplica
+}
+
+// setReplicationMetadata - saves the replication status of an object
+// written by the request in metadata, objects selected by a replication
+// rule of the bucket are marked as pending replication. Replicas are
+// never replicated again, objects encrypted with client provided keys
+// can not be replicated.
+func setReplicationMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	delete(metadata, xhttp.AmzBucketReplicationStatus)
+
+	if isReplicaRequest(r) {
+		if s3Err := isActionAllowed(getRequestAuthType(r), bucket, object, r, policy.ReplicateObjectAction); s3Err != ErrNone {
+			return s3Err
+		}
+		metadata[xhttp.AmzBucketReplicationStatus] = replicationReplica
+		return ErrNone
+	}
+
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok || crypto.SSEC.IsRequested(r.Header) {
+		return ErrNone
+	}
+	if _, ok = config.FilterActionableRule(object, getObjectTags(metadata).ToMap()); ok {
+		metadata[xhttp.AmzBucketReplicationStatus] = replicationPending
+	}
+	return ErrNone
+}
+
+// scheduleReplication - queues the replication of a written object
+// which is pending replication.
+func scheduleReplication(ctx context.Context, objInfo ObjectInfo) {
+	if objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] != replicationPending {
+		return
+	}
+	queueReplication(ctx, replicationTask{
+		Op:        replicationOpPut,
+		Bucket:    objInfo.Bucket,
+		Object:    objInfo.Name,
+		VersionID: objInfo.VersionID,
+		ETag:      objInfo.ETag,
+	})
+}
+
+// scheduleDeleteReplication - queues the replication of the delete of
+// an object if the bucket replicates deletes of the object.
+func scheduleDeleteReplication(ctx context.Context, bucket, object string) {
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok {
+		return
+	}
+	if rule, ok := config.FilterActionableRule(object, nil); !ok || !rule.ReplicatesDeletes() {
+		return
+	}
+	queueReplication(ctx, replicationTask{
+		Op:     replicationOpDelete,
+		Bucket: bucket,
+		Object: object,
+	})
+}
+
+// replicateObject - copies the object version of a task to the
+// destination bucket, and records the outcome as replication status
+// of the object.
+func replicateObject(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	config, ok := globalBucketReplicationSys.Get(task.Bucket)
+	if !ok {
+		// Replication was removed from the bucket.
+		return nil
+	}
+
+	opts := ObjectOptions{VersionID: task.VersionID}
+	gr, err := objAPI.GetObjectNInfo(ctx, task.Bucket, task.Object, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// The object was deleted since, a queued
+			// delete takes care of the destination.
+			return nil
+		}
+		return err
+	}
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+	if objInfo.ETag != task.ETag {
+		// The object was overwritten since, its new
+		// content is replicated by another task.
+		return nil
+	}
+
+	rule, ok := config.FilterActionableRule(task.Object, getObjectTags(objInfo.UserDefined).ToMap())
+	if !ok {
+		return nil
+	}
+	targetName, bucket, err := rule.Destination.Parse()
+	if err != nil {
+		return err
+	}
+
+	// Object data is replicated decrypted and decompressed.
+	size := objInfo.Size
+	switch {
+	case objInfo.IsCompressed():
+		size = objInfo.GetActualSize()
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		if size, err = objInfo.DecryptedSize(); err != nil {
+			return err
+		}
+	}
+
+	client, err := getReplicationClient(targetName)
+	if err == nil {
+		putOpts, headers := getReplicationPutOptions(objInfo, rule.Destination.StorageClass)
+		_, err = client.Client.PutObjectWithContext(context.WithValue(ctx, replicaHeadersKey{}, headers),
+			bucket, task.Object, gr, size, putOpts)
+	}
+
+	status, eventName := replicationCompleted, event.ReplicationOperationCompleted
+	if err != nil {
+		status, eventName = replicationFailed, event.ReplicationOperationFailed
+		err = fmt.Errorf("Unable to replicate %s/%s to %s: %v", task.Bucket, task.Object, rule.Destination.Bucket, err)
+	}
+	// Failures are only logged and notified once, when the status changes.
+	if objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] != status {
+		logger.LogIf(ctx, err)
+		setReplicationStatus(ctx, objAPI, objInfo, status)
+
+		if !objInfo.IsCompressed() {
+			objInfo.Size = size
+		}
+		sendEvent(eventArgs{
+			EventName:  eventName,
+			BucketName: task.Bucket,
+			Object:     objInfo,
+			Host:       globalMinioHost,
+			UserAgent:  replicationUserAgent,
+		})
+	}
+	return err
+}
+
+// setReplicationStatus - updates the replication status of an object
+// version unless it was overwritten in the meantime.
+func setReplicationStatus(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo, status string) {
+	opts := ObjectOptions{VersionID: objInfo.VersionID}
+	current, err := objAPI.GetObjectInfo(ctx, objInfo.Bucket, objInfo.Name, opts)
+	if err != nil || current.ETag != objInfo.ETag || !current.ModTime.Equal(objInfo.ModTime) {
+		return
+	}
+	_, err = objAPI.UpdateObjectMetadata(ctx, objInfo.Bucket, objInfo.Name, map[string]string{
+		xhttp.AmzBucketReplicationStatus: status,
+	}, opts)
+	if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// getReplicationPutOptions - returns the options to write the replica of
+// an object with, and the headers which the options can not carry.
+func getReplicationPutOptions(objInfo ObjectInfo, storageClass string) (miniogo.PutObjectOptions, http.Header) {
+	opts := miniogo.PutObjectOptions{
+		UserMetadata:    make(map[string]string),
+		ContentType:     objInfo.ContentType,
+		ContentEncoding: objInfo.ContentEncoding,
+		StorageClass:    storageClass,
+	}
+	// Replicas of objects encrypted by the server are encrypted by
+	// the destination server.
+	if crypto.S3.IsEncrypted(objInfo.UserDefined) || crypto.S3KMS.IsEncrypted(objInfo.UserDefined) {
+		opts.ServerSideEncryption = encrypt.NewSSE()
+	}
+	for k, v := range objInfo.UserDefined {
+		switch strings.ToLower(k) {
+		case "cache-control":
+			opts.CacheControl = v
+		case "content-disposition":
+			opts.ContentDisposition = v
+		case "content-language":
+			opts.ContentLanguage = v
+		default:
+			if hasPrefix(strings.ToLower(k), "x-amz-meta-") {
+				opts.UserMetadata[k] = v
+			}
+		}
+	}
+
+	headers := make(http.Header)
+	if tags := getObjectTags(objInfo.UserDefined); tags.Count() > 0 {
+		headers.Set(xhttp.AmzObjectTagging, tags.String())
+	}
+	if !objInfo.Expires.IsZero() {
+		headers.Set(xhttp.Expires, objInfo.Expires.UTC().Format(http.TimeFormat))
+	}
+	return opts, headers
+}
+
+// replicateDelete - removes the object of a task from the destination
+// bucket, unless the object was written again since.
+func replicateDelete(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	config, ok := globalBucketReplicationSys.Get(task.Bucket)
+	if !ok {
+		return nil
+	}
+	if _, err := objAPI.GetObjectInfo(ctx, task.Bucket, task.Object, ObjectOptions{}); err == nil {
+		return nil
+	} else if !isErrObjectNotFound(err) {
+		return err
+	}
+
+	rule, ok := config.FilterActionableRule(task.Object, nil)
+	if !ok || !rule.ReplicatesDeletes() {
+		return nil
+	}
+	targetName, bucket, err := rule.Destination.Parse()
+	if err != nil {
+		return err
+	}
+	client, err := getReplicationClient(targetName)
+	if err == nil {
+		err = client.RemoveObject(bucket, task.Object)
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to replicate delete of %s/%s to %s: %v", task.Bucket, task.Object, rule.Destination.Bucket, err)
+	}
+	return nil
+}
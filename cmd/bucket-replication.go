@@ -0,0 +1,305 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	miniogo "github.com/minio/minio-go/v6"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/replication"
+)
+
+const (
+	// Replication configuration file.
+	bucketReplicationConfig = "replication.xml"
+)
+
+var errReplicationInvalidEndpoint = errors.New("endpoint must be a http or https URL")
+
+// replicationTarget - remote S3 compatible endpoint objects are
+// replicated to, named by the ARN of replication destinations.
+type replicationTarget struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	Region    string `json:"region"`
+}
+
+// Validate - validates the remote replication target configuration.
+func (t replicationTarget) Validate() error {
+	u, err := xnet.ParseURL(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errReplicationInvalidEndpoint
+	}
+	return nil
+}
+
+// GetReplicationTarget - returns the configuration of the named remote replication target.
+func (s *serverConfig) GetReplicationTarget(name string) (replicationTarget, bool) {
+	if s == nil {
+		return replicationTarget{}, false
+	}
+	t, ok := s.Replication[name]
+	return t, ok
+}
+
+// Clients of remote replication targets, keyed by their configuration
+// so that configuration changes get a new client.
+var globalReplicationClients = struct {
+	sync.Mutex
+	clients map[replicationTarget]*miniogo.Core
+}{clients: make(map[replicationTarget]*miniogo.Core)}
+
+// getReplicationClient - returns a client of the named remote replication target.
+func getReplicationClient(name string) (*miniogo.Core, error) {
+	cfg, ok := globalServerConfig.GetReplicationTarget(name)
+	if !ok {
+		return nil, ReplicationTargetNotFound{Target: name}
+	}
+
+	globalReplicationClients.Lock()
+	defer globalReplicationClients.Unlock()
+
+	if client, ok := globalReplicationClients.clients[cfg]; ok {
+		return client, nil
+	}
+
+	client, err := newRemoteClient(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Region, replicaTransport{NewCustomHTTPTransport()})
+	if err != nil {
+		return nil, err
+	}
+	globalReplicationClients.clients[cfg] = client
+	return client, nil
+}
+
+// replicaHeadersKey - context key of headers added to the requests
+// of replication clients.
+type replicaHeadersKey struct{}
+
+// replicaTransport - transport of replication clients, marks all their
+// requests as replication requests and adds the headers found in the
+// request context, which the client API can not send otherwise.
+type replicaTransport struct {
+	http.RoundTripper
+}
+
+// RoundTrip - sends a replication request.
+func (t replicaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	if h, ok := req.Context().Value(replicaHeadersKey{}).(http.Header); ok {
+		for k, v := range h {
+			r.Header[k] = v
+		}
+	}
+	r.Header.Set(xhttp.AmzBucketReplicationStatus, replicationReplica)
+	return t.RoundTripper.RoundTrip(r)
+}
+
+// validateReplicationTargets - verifies that the destinations of all
+// rules name configured remote replication targets.
+func validateReplicationTargets(config *replication.Config) error {
+	for _, rule := range config.Rules {
+		target, _, err := rule.Destination.Parse()
+		if err != nil {
+			return err
+		}
+		if _, ok := globalServerConfig.GetReplicationTarget(target); !ok {
+			return ReplicationTargetNotFound{Target: target}
+		}
+	}
+	return nil
+}
+
+// BucketReplicationSys - Bucket replication subsystem.
+type BucketReplicationSys struct {
+	sync.RWMutex
+	bucketReplicationMap map[string]replication.Config
+}
+
+// Set - sets replication config to given bucket name.
+func (sys *BucketReplicationSys) Set(bucketName string, config replication.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketReplicationMap[bucketName] = config
+}
+
+// Get - gets replication config associated to a given bucket name.
+func (sys *BucketReplicationSys) Get(bucketName string) (config replication.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketReplicationMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes replication config for given bucket name.
+func (sys *BucketReplicationSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketReplicationMap, bucketName)
+}
+
+func saveReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *replication.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getReplicationConfig - get replication config for given bucket name.
+func getReplicationConfig(objAPI ObjectLayer, bucketName string) (*replication.Config, error) {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketReplicationConfigNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return replication.ParseConfig(bytes.NewReader(configData))
+}
+
+func removeReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketReplicationConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketReplicationSys - creates new replication system.
+func NewBucketReplicationSys() *BucketReplicationSys {
+	return &BucketReplicationSys{
+		bucketReplicationMap: make(map[string]replication.Config),
+	}
+}
+
+// Init - initializes replication system from replication.xml of all buckets.
+func (sys *BucketReplicationSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	defer func() {
+		// Refresh BucketReplicationSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketReplicationInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing replication needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketReplicationSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for replication subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// Refresh BucketReplicationSys.
+func (sys *BucketReplicationSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketReplication(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketReplicationConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the replication
+// config for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding replication config during sys.refresh()
+func (sys *BucketReplicationSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketReplicationMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketReplicationMap, bucket)
+		}
+	}
+}
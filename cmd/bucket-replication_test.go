@@ -0,0 +1,230 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/replication"
+)
+
+// Tests replicating writes and deletes of objects to a bucket served
+// by a second MinIO server.
+func TestReplicateObject(t *testing.T) {
+	ctx := context.Background()
+
+	remote := StartTestServer(t, "FS")
+	defer remote.Stop()
+	if err := remote.Obj.MakeBucketWithLocation(ctx, "backup", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalServerConfig.Replication = map[string]replicationTarget{
+		"dr": {
+			Endpoint:  remote.Server.URL,
+			AccessKey: remote.AccessKey,
+			SecretKey: remote.SecretKey,
+		},
+	}
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "docs/object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	config, err := replication.ParseConfig(strings.NewReader(`<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status>` +
+		`<Filter><Prefix>docs/</Prefix></Filter><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>` +
+		`<Destination><Bucket>arn:minio:replication::dr:backup</Bucket></Destination></Rule></ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketReplicationSys.Set(bucket, *config)
+	defer globalBucketReplicationSys.Remove(bucket)
+
+	data := make([]byte, 3*1024)
+	if _, err = rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	metadata := map[string]string{
+		xhttp.AmzBucketReplicationStatus: replicationPending,
+		xhttp.AmzObjectTagging:           "dr=yes",
+		"X-Amz-Meta-Color":               "blue",
+	}
+	objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tasks of overwritten objects are skipped.
+	task := replicationTask{Op: replicationOpPut, Bucket: bucket, Object: object, ETag: "stale"}
+	if err = replicateObject(ctx, obj, task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = remote.Obj.GetObjectInfo(ctx, "backup", object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected stale task to be skipped, got %v", err)
+	}
+
+	task.ETag = objInfo.ETag
+	if err = replicateObject(ctx, obj, task); err != nil {
+		t.Fatal(err)
+	}
+
+	replica, err := remote.Obj.GetObjectInfo(ctx, "backup", object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replica.Size != int64(len(data)) || replica.UserDefined[xhttp.AmzBucketReplicationStatus] != replicationReplica ||
+		replica.UserDefined["X-Amz-Meta-Color"] != "blue" || getObjectTags(replica.UserDefined).String() != "dr=yes" {
+		t.Fatalf("unexpected replica info %+v", replica)
+	}
+	var buf bytes.Buffer
+	if err = remote.Obj.GetObject(ctx, "backup", object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("replica data mismatch")
+	}
+
+	if objInfo, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if status := objInfo.UserDefined[xhttp.AmzBucketReplicationStatus]; status != replicationCompleted {
+		t.Fatalf("expected replication status %s, got %s", replicationCompleted, status)
+	}
+
+	// Deletes are replicated once the object is gone.
+	task = replicationTask{Op: replicationOpDelete, Bucket: bucket, Object: object}
+	if err = replicateDelete(ctx, obj, task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = remote.Obj.GetObjectInfo(ctx, "backup", object, ObjectOptions{}); err != nil {
+		t.Fatalf("expected replica to be kept while the object exists, got %v", err)
+	}
+	if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = replicateDelete(ctx, obj, task); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = remote.Obj.GetObjectInfo(ctx, "backup", object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected replica to be deleted, got %v", err)
+	}
+}
+
+// Tests that replicas of objects encrypted by the server are
+// encrypted by the destination server.
+func TestReplicateEncryptedObject(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+
+	remote := StartTestServer(t, "FS")
+	defer remote.Stop()
+	if err := remote.Obj.MakeBucketWithLocation(ctx, "backup", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalServerConfig.Replication = map[string]replicationTarget{
+		"dr": {
+			Endpoint:  remote.Server.URL,
+			AccessKey: remote.AccessKey,
+			SecretKey: remote.SecretKey,
+		},
+	}
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	config, err := replication.ParseConfig(strings.NewReader(`<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status>` +
+		`<Filter><Prefix></Prefix></Filter><Destination><Bucket>arn:minio:replication::dr:backup</Bucket></Destination></Rule></ReplicationConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketReplicationSys.Set(bucket, *config)
+	defer globalBucketReplicationSys.Remove(bucket)
+
+	data := make([]byte, 3*1024)
+	if _, err = rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	for i, algorithm := range []string{crypto.SSEAlgorithmAES256, crypto.SSEAlgorithmKMS} {
+		object := "object-" + algorithm
+		metadata := map[string]string{xhttp.AmzBucketReplicationStatus: replicationPending}
+		req, _ := http.NewRequest(http.MethodPut, "http://localhost/", nil)
+		req.Header.Set(crypto.SSEHeader, algorithm)
+		reader, _, err := EncryptRequest(bytes.NewReader(data), req, bucket, object, metadata)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		info := ObjectInfo{Size: int64(len(data))}
+		encrypted := info.EncryptedSize()
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, reader, encrypted, "", ""), ObjectOptions{UserDefined: metadata})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+
+		task := replicationTask{Op: replicationOpPut, Bucket: bucket, Object: object, ETag: objInfo.ETag}
+		if err = replicateObject(ctx, obj, task); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+
+		replica, err := remote.Obj.GetObjectInfo(ctx, "backup", object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !crypto.S3.IsEncrypted(replica.UserDefined) || replica.Size != encrypted {
+			t.Fatalf("Test %d: expected an SSE-S3 encrypted replica, got %+v", i+1, replica)
+		}
+		key, err := decryptObjectInfo(nil, "backup", object, replica.UserDefined)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		var buf bytes.Buffer
+		if err = remote.Obj.GetObject(ctx, "backup", object, 0, replica.Size, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := newDecryptReaderWithObjectKey(&buf, key, 0, replica.UserDefined)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		var plain bytes.Buffer
+		if _, err = plain.ReadFrom(got); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(plain.Bytes(), data) {
+			t.Fatalf("Test %d: replica data mismatch", i+1)
+		}
+	}
+}
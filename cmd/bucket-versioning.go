@@ -0,0 +1,244 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+const (
+	// Versioning configuration file.
+	bucketVersioningConfig = "versioning.xml"
+)
+
+// BucketVersioningSys - Bucket versioning subsystem.
+type BucketVersioningSys struct {
+	sync.RWMutex
+	bucketVersioningMap map[string]versioning.Versioning
+}
+
+// Set - sets versioning config to given bucket name.
+func (sys *BucketVersioningSys) Set(bucketName string, v versioning.Versioning) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketVersioningMap[bucketName] = v
+}
+
+// Get - gets versioning config associated to a given bucket name.
+func (sys *BucketVersioningSys) Get(bucketName string) (v versioning.Versioning, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	v, ok = sys.bucketVersioningMap[bucketName]
+	return v, ok
+}
+
+// Enabled - returns true if versioning is enabled on the given bucket.
+func (sys *BucketVersioningSys) Enabled(bucketName string) bool {
+	v, ok := sys.Get(bucketName)
+	return ok && v.Enabled()
+}
+
+// Suspended - returns true if versioning was enabled once and is
+// now suspended on the given bucket.
+func (sys *BucketVersioningSys) Suspended(bucketName string) bool {
+	v, ok := sys.Get(bucketName)
+	return ok && v.Suspended()
+}
+
+// Remove - removes versioning config for given bucket name.
+func (sys *BucketVersioningSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketVersioningMap, bucketName)
+}
+
+func saveVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, bucketVersioning *versioning.Versioning) error {
+	data, err := xml.Marshal(bucketVersioning)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getVersioningConfig - get versioning config for given bucket name.
+func getVersioningConfig(objAPI ObjectLayer, bucketName string) (*versioning.Versioning, error) {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketVersioningNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return versioning.ParseConfig(bytes.NewReader(configData))
+}
+
+func removeVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketVersioningNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketVersioningSys - creates new versioning system.
+func NewBucketVersioningSys() *BucketVersioningSys {
+	return &BucketVersioningSys{
+		bucketVersioningMap: make(map[string]versioning.Versioning),
+	}
+}
+
+// Init - initializes versioning system from versioning.xml of all buckets.
+func (sys *BucketVersioningSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	defer func() {
+		// Refresh BucketVersioningSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketVersioningInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing versioning needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketVersioningSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for versioning subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// Refresh BucketVersioningSys.
+func (sys *BucketVersioningSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketVersioning(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketVersioningNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the versioning
+// config for a deleted bucket. i.e if we miss a delete-bucket notification we should
+// delete the corresponding versioning config during sys.refresh()
+func (sys *BucketVersioningSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketVersioningMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketVersioningMap, bucket)
+		}
+	}
+}
+
+// setVersioningOpts - populates the versioning state of the given bucket
+// into the object options.
+func setVersioningOpts(bucket string, opts *ObjectOptions) {
+	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
+	opts.VersionSuspended = globalBucketVersioningSys.Suspended(bucket)
+}
+
+// isBucketVersioningConfigured - returns true if versioning was ever
+// enabled on the given bucket, whether it is suspended or not.
+func isBucketVersioningConfigured(bucket string) bool {
+	opts := ObjectOptions{}
+	setVersioningOpts(bucket, &opts)
+	return opts.isVersioningConfigured()
+}
+
+// objectVersionAction - returns the policy action of a request to an
+// object, reading or deleting a specific version of an object needs
+// s3:GetObjectVersion or s3:DeleteObjectVersion respectively.
+func objectVersionAction(action policy.Action, versionID string) policy.Action {
+	if versionID == "" {
+		return action
+	}
+	switch action {
+	case policy.GetObjectAction:
+		return policy.GetObjectVersionAction
+	case policy.DeleteObjectAction:
+		return policy.DeleteObjectVersionAction
+	}
+	return action
+}
@@ -0,0 +1,43 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/policy"
+)
+
+func TestObjectVersionAction(t *testing.T) {
+	testCases := []struct {
+		action         policy.Action
+		versionID      string
+		expectedAction policy.Action
+	}{
+		{policy.GetObjectAction, "", policy.GetObjectAction},
+		{policy.GetObjectAction, "null", policy.GetObjectVersionAction},
+		{policy.DeleteObjectAction, "", policy.DeleteObjectAction},
+		{policy.DeleteObjectAction, "6b8d5c1a-4f9e-4b4c-9d53-1f4a0e2d7c3b", policy.DeleteObjectVersionAction},
+		{policy.PutObjectAction, "null", policy.PutObjectAction},
+	}
+
+	for i, testCase := range testCases {
+		if action := objectVersionAction(testCase.action, testCase.versionID); action != testCase.expectedAction {
+			t.Errorf("test %v: expected: %v, got: %v", i+1, testCase.expectedAction, action)
+		}
+	}
+}
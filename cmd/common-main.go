@@ -50,23 +50,59 @@ func checkUpdate(mode string) {
 	}
 }
 
+// Validate - checks the queue settings of a HTTP logger target.
+func (l loggerHTTP) Validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	if l.QueueDir != "" && !filepath.IsAbs(l.QueueDir) {
+		return errors.New("queueDir path should be absolute")
+	}
+	if l.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+	return nil
+}
+
+// loggerQueueDir - returns the directory queueing the log entries of a
+// HTTP logger target, or an empty string if entries are not queued.
+func loggerQueueDir(queueDir, name string) string {
+	if queueDir == "" {
+		return ""
+	}
+	return filepath.Join(queueDir, "minio-"+name)
+}
+
+// newHTTPLogger - returns a HTTP logger target, queueing its log entries
+// in queueDir if set.
+func newHTTPLogger(endpoint, queueDir string, queueLimit uint64) *http.Target {
+	target, err := http.New(endpoint, NewCustomHTTPTransport(), queueDir, queueLimit)
+	logger.FatalIf(err, "Unable to initialize the HTTP logger queue in %s", queueDir)
+	return target
+}
+
 // Load logger targets based on user's configuration
 func loadLoggers() {
 	auditEndpoint, ok := os.LookupEnv("MINIO_AUDIT_LOGGER_HTTP_ENDPOINT")
 	if ok {
 		// Enable audit HTTP logging through ENV.
-		logger.AddAuditTarget(http.New(auditEndpoint, NewCustomHTTPTransport()))
+		l := loggerHTTP{Enabled: true, Endpoint: auditEndpoint, QueueDir: os.Getenv("MINIO_AUDIT_LOGGER_HTTP_QUEUE_DIR")}
+		logger.FatalIf(l.Validate(), "Invalid MINIO_AUDIT_LOGGER_HTTP_QUEUE_DIR")
+		logger.AddAuditTarget(newHTTPLogger(l.Endpoint, loggerQueueDir(l.QueueDir, "audit"), 0))
 	}
 
 	loggerEndpoint, ok := os.LookupEnv("MINIO_LOGGER_HTTP_ENDPOINT")
 	if ok {
 		// Enable HTTP logging through ENV.
-		logger.AddTarget(http.New(loggerEndpoint, NewCustomHTTPTransport()))
+		l := loggerHTTP{Enabled: true, Endpoint: loggerEndpoint, QueueDir: os.Getenv("MINIO_LOGGER_HTTP_QUEUE_DIR")}
+		logger.FatalIf(l.Validate(), "Invalid MINIO_LOGGER_HTTP_QUEUE_DIR")
+		logger.AddTarget(newHTTPLogger(l.Endpoint, loggerQueueDir(l.QueueDir, "logger"), 0))
 	} else {
-		for _, l := range globalServerConfig.Logger.HTTP {
+		for name, l := range globalServerConfig.Logger.HTTP {
 			if l.Enabled {
 				// Enable http logging
-				logger.AddTarget(http.New(l.Endpoint, NewCustomHTTPTransport()))
+				queueDir := loggerQueueDir(l.QueueDir, "logger-"+name)
+				logger.AddTarget(newHTTPLogger(l.Endpoint, queueDir, l.QueueLimit))
 			}
 		}
 	}
@@ -387,6 +423,10 @@ func handleCommonEnvVars() {
 		globalIsCompressionEnabled = strings.EqualFold(compress, "true")
 	}
 
+	if allowEncryption := os.Getenv("MINIO_COMPRESS_ALLOW_ENCRYPTION"); allowEncryption != "" {
+		globalCompressAllowEncryption = strings.EqualFold(allowEncryption, "true")
+	}
+
 	compressExtensions := os.Getenv("MINIO_COMPRESS_EXTENSIONS")
 	compressMimeTypes := os.Getenv("MINIO_COMPRESS_MIMETYPES")
 	if compressExtensions != "" || compressMimeTypes != "" {
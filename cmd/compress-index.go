@@ -0,0 +1,199 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// Metadata key of the block index of a compressed object.
+	compressionIndexKey = ReservedMetadataPrefix + "compression-index"
+
+	// Version of the binary encoding of the compression index.
+	compressionIndexV1 = 1
+
+	// Initial distance of the decompressed offsets of two indexed
+	// blocks. It is doubled whenever the index grows beyond
+	// compressionIndexMaxEntries, which bounds the metadata size
+	// of large objects.
+	compressionIndexInterval = 1 << 20
+
+	// Maximum number of indexed blocks.
+	compressionIndexMaxEntries = 1024
+)
+
+// snappyStreamHeader is the stream identifier chunk which begins every
+// snappy stream. It is prepended when reading a stream from the middle.
+var snappyStreamHeader = []byte("\xff\x06\x00\x00sNaPpY")
+
+var errInvalidCompressionIndex = errors.New("invalid compression index")
+
+// compressionIndexEntry is a block of a compressed stream, which starts
+// at a decompressed offset and at a compressed offset of the stream.
+type compressionIndexEntry struct {
+	decompressed int64
+	compressed   int64
+}
+
+// compressionIndex maps decompressed offsets of a snappy stream to the
+// chunks beginning there, so that a range read can start decompressing
+// at the nearest chunk instead of at the beginning of the stream.
+type compressionIndex struct {
+	interval int64
+	entries  []compressionIndexEntry
+}
+
+func newCompressionIndex() *compressionIndex {
+	return &compressionIndex{interval: compressionIndexInterval}
+}
+
+// add adds a block which starts at a multiple of the index interval.
+func (idx *compressionIndex) add(decompressed, compressed int64) {
+	if decompressed%idx.interval != 0 {
+		return
+	}
+	idx.entries = append(idx.entries, compressionIndexEntry{decompressed, compressed})
+	if len(idx.entries) <= compressionIndexMaxEntries {
+		return
+	}
+
+	// Keep every second block.
+	idx.interval *= 2
+	entries := idx.entries[:0]
+	for _, entry := range idx.entries {
+		if entry.decompressed%idx.interval == 0 {
+			entries = append(entries, entry)
+		}
+	}
+	idx.entries = entries
+}
+
+// find returns the last indexed block which starts at or before the
+// decompressed offset. Both offsets are zero if there is no such block.
+func (idx *compressionIndex) find(offset int64) (decompressed, compressed int64) {
+	for _, entry := range idx.entries {
+		if entry.decompressed > offset {
+			break
+		}
+		decompressed, compressed = entry.decompressed, entry.compressed
+	}
+	return decompressed, compressed
+}
+
+// MarshalBinary encodes the index as the version, the interval and the
+// deltas of the offsets of all blocks.
+func (idx *compressionIndex) MarshalBinary() ([]byte, error) {
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(data []byte, x uint64) []byte {
+		return append(data, buf[:binary.PutUvarint(buf[:], x)]...)
+	}
+
+	data := []byte{compressionIndexV1}
+	data = putUvarint(data, uint64(idx.interval))
+	data = putUvarint(data, uint64(len(idx.entries)))
+	var last compressionIndexEntry
+	for _, entry := range idx.entries {
+		data = putUvarint(data, uint64(entry.decompressed-last.decompressed))
+		data = putUvarint(data, uint64(entry.compressed-last.compressed))
+		last = entry
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes an index encoded by MarshalBinary.
+func (idx *compressionIndex) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != compressionIndexV1 {
+		return errInvalidCompressionIndex
+	}
+	data = data[1:]
+	uvarint := func() (int64, error) {
+		x, n := binary.Uvarint(data)
+		if n <= 0 || int64(x) < 0 {
+			return 0, errInvalidCompressionIndex
+		}
+		data = data[n:]
+		return int64(x), nil
+	}
+
+	interval, err := uvarint()
+	if err != nil {
+		return err
+	}
+	count, err := uvarint()
+	if err != nil {
+		return err
+	}
+	if count > compressionIndexMaxEntries {
+		return errInvalidCompressionIndex
+	}
+
+	entries := make([]compressionIndexEntry, 0, count)
+	var last compressionIndexEntry
+	for i := int64(0); i < count; i++ {
+		var entry compressionIndexEntry
+		if entry.decompressed, err = uvarint(); err != nil {
+			return err
+		}
+		if entry.compressed, err = uvarint(); err != nil {
+			return err
+		}
+		entry.decompressed += last.decompressed
+		entry.compressed += last.compressed
+		entries = append(entries, entry)
+		last = entry
+	}
+	if len(data) != 0 {
+		return errInvalidCompressionIndex
+	}
+
+	idx.interval, idx.entries = interval, entries
+	return nil
+}
+
+// setCompressionIndex stores the index returned by indexCB in the
+// metadata of a compressed object. The index is only known once all
+// object data has been read.
+func setCompressionIndex(metadata map[string]string, indexCB func() []byte) {
+	if indexCB == nil {
+		return
+	}
+	if index := indexCB(); len(index) > 0 {
+		metadata[compressionIndexKey] = base64.StdEncoding.EncodeToString(index)
+	}
+}
+
+// getCompressionIndex returns the block index of a compressed object, or
+// nil if the object has none. Objects compressed by older releases and
+// multipart objects have no index.
+func (o ObjectInfo) getCompressionIndex() *compressionIndex {
+	encoded, ok := o.UserDefined[compressionIndexKey]
+	if !ok || len(o.Parts) > 1 {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	idx := &compressionIndex{}
+	if err = idx.UnmarshalBinary(data); err != nil {
+		return nil
+	}
+	return idx
+}
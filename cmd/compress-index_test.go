@@ -0,0 +1,250 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+// compressibleData returns size bytes of text-like data which compresses
+// to roughly half of its size.
+func compressibleData(size int) []byte {
+	rng := rand.New(rand.NewSource(int64(size)))
+	words := []string{"minio ", "object ", "storage ", "bucket ", "compression ", "index "}
+	var buf bytes.Buffer
+	for buf.Len() < size {
+		if rng.Intn(4) == 0 {
+			fmt.Fprintf(&buf, "%d ", rng.Int63())
+		} else {
+			buf.WriteString(words[rng.Intn(len(words))])
+		}
+	}
+	return buf.Bytes()[:size]
+}
+
+// compressObject compresses data as PutObject does, and returns the
+// compressed data and the object info.
+func compressObject(t testing.TB, data []byte, withIndex bool) ([]byte, ObjectInfo) {
+	r := newSnappyCompressReader(bytes.NewReader(data))
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := map[string]string{
+		ReservedMetadataPrefix + "compression": compressionAlgorithmV1,
+		ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+	}
+	if withIndex {
+		setCompressionIndex(metadata, r.Index)
+	}
+	return compressed, ObjectInfo{Size: int64(len(compressed)), UserDefined: metadata}
+}
+
+// readCompressedRange reads a range of a compressed object as
+// GetObjectNInfo does, and returns the offset of the compressed data
+// which is read.
+func readCompressedRange(t testing.TB, compressed []byte, oi ObjectInfo, rs *HTTPRangeSpec) ([]byte, int64) {
+	fn, off, length, err := NewGetObjectReader(rs, oi, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gr, err := fn(bytes.NewReader(compressed[off:off+length]), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+
+	data, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, off
+}
+
+func TestCompressionIndex(t *testing.T) {
+	idx := newCompressionIndex()
+	for i := int64(1); i <= 4*compressionIndexMaxEntries+1; i++ {
+		idx.add(i*compressionIndexInterval, i*1000)
+	}
+	if len(idx.entries) > compressionIndexMaxEntries {
+		t.Fatalf("index has %d entries, more than %d", len(idx.entries), compressionIndexMaxEntries)
+	}
+	if idx.interval != 4*compressionIndexInterval {
+		t.Fatalf("expected interval %d, got %d", 4*compressionIndexInterval, idx.interval)
+	}
+
+	testCases := []struct {
+		offset       int64
+		decompressed int64
+		compressed   int64
+	}{
+		{0, 0, 0},
+		{4*compressionIndexInterval - 1, 0, 0},
+		{4 * compressionIndexInterval, 4 * compressionIndexInterval, 4000},
+		{9*compressionIndexInterval + 5, 8 * compressionIndexInterval, 8000},
+		{1 << 40, 4096 * compressionIndexInterval, 4096000},
+	}
+	for i, testCase := range testCases {
+		decompressed, compressed := idx.find(testCase.offset)
+		if decompressed != testCase.decompressed || compressed != testCase.compressed {
+			t.Errorf("Test %d: expected block (%d, %d), got (%d, %d)", i+1,
+				testCase.decompressed, testCase.compressed, decompressed, compressed)
+		}
+	}
+
+	data, err := idx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &compressionIndex{}
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(idx, decoded) {
+		t.Fatal("decoded index does not match the encoded index")
+	}
+	if err = decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("expected an error for a truncated index")
+	}
+}
+
+func TestCompressedRangeRead(t *testing.T) {
+	data := compressibleData(5*compressionIndexInterval + 1234)
+	compressed, oi := compressObject(t, data, true)
+	if oi.getCompressionIndex() == nil {
+		t.Fatal("expected a compression index")
+	}
+
+	size := int64(len(data))
+	testCases := []struct {
+		rs     *HTTPRangeSpec
+		seeked bool
+	}{
+		{nil, false},
+		{&HTTPRangeSpec{Start: 0, End: 99}, false},
+		{&HTTPRangeSpec{Start: compressionIndexInterval - 10, End: compressionIndexInterval + 10}, false},
+		{&HTTPRangeSpec{Start: compressionIndexInterval, End: compressionIndexInterval}, true},
+		{&HTTPRangeSpec{Start: 3*compressionIndexInterval + 77, End: 4*compressionIndexInterval + 77}, true},
+		{&HTTPRangeSpec{Start: size - 100, End: -1}, true},
+		{&HTTPRangeSpec{IsSuffixLength: true, Start: -2000}, true},
+	}
+	for i, testCase := range testCases {
+		start, length, err := testCase.rs.GetOffsetLength(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, off := readCompressedRange(t, compressed, oi, testCase.rs)
+		if !bytes.Equal(got, data[start:start+length]) {
+			t.Errorf("Test %d: decompressed range does not match", i+1)
+		}
+		if seeked := off > 0; seeked != testCase.seeked {
+			t.Errorf("Test %d: expected seeking to a block %v, got %v", i+1, testCase.seeked, seeked)
+		}
+	}
+
+	// Objects compressed without an index are read from the beginning.
+	_, oi = compressObject(t, data, false)
+	rs := &HTTPRangeSpec{Start: size - 100, End: -1}
+	if got, off := readCompressedRange(t, compressed, oi, rs); off != 0 || !bytes.Equal(got, data[size-100:]) {
+		t.Errorf("unexpected range read of an object without an index")
+	}
+}
+
+func BenchmarkCompressedRangeRead(b *testing.B) {
+	const objectSize = 256 << 20
+	const rangeSize = 1 << 20
+	data := compressibleData(objectSize)
+
+	for _, withIndex := range []bool{false, true} {
+		compressed, oi := compressObject(b, data, withIndex)
+		for _, start := range []int64{0, objectSize / 2, objectSize - rangeSize} {
+			rs := &HTTPRangeSpec{Start: start, End: start + rangeSize - 1}
+			name := fmt.Sprintf("index=%v/offset=%dMiB", withIndex, start>>20)
+			b.Run(name, func(b *testing.B) {
+				b.SetBytes(rangeSize)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					readCompressedRange(b, compressed, oi, rs)
+				}
+			})
+		}
+	}
+}
+
+// Wrapper for calling testPutObjectCompressionIndex for both XL and FS.
+func TestPutObjectCompressionIndex(t *testing.T) {
+	ExecObjectLayerTest(t, testPutObjectCompressionIndex)
+}
+
+// Tests that the object layer stores the compression index of an object.
+func testPutObjectCompressionIndex(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	data := compressibleData(2*compressionIndexInterval + 1)
+	r := newSnappyCompressReader(bytes.NewReader(data))
+	hashReader, err := hash.NewReader(r, -1, "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	opts := ObjectOptions{
+		UserDefined: map[string]string{
+			ReservedMetadataPrefix + "compression": compressionAlgorithmV1,
+			ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+		},
+		IndexCB: r.Index,
+	}
+	if _, err = obj.PutObject(context.Background(), bucket, object, NewPutObjReader(hashReader, nil, nil), opts); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	index := objInfo.getCompressionIndex()
+	if index == nil || len(index.entries) != 2 {
+		t.Fatalf("%s: expected a compression index with 2 blocks, got %v", instanceType, index)
+	}
+
+	var buf bytes.Buffer
+	rs := &HTTPRangeSpec{Start: int64(len(data)) - 10, End: -1}
+	gr, err := obj.GetObjectNInfo(context.Background(), bucket, object, rs, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	defer gr.Close()
+	if _, err = buf.ReadFrom(gr); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if !bytes.Equal(buf.Bytes(), data[len(data)-10:]) {
+		t.Fatalf("%s: range read of the compressed object does not match", instanceType)
+	}
+}
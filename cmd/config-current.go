@@ -44,9 +44,9 @@ import (
 // 6. Make changes in config-current_test.go for any test change
 
 // Config version
-const serverConfigVersion = "33"
+const serverConfigVersion = "42"
 
-type serverConfig = serverConfigV33
+type serverConfig = serverConfigV42
 
 var (
 	// globalServerConfig server config.
@@ -223,6 +223,18 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	for _, v := range s.Notify.PubSub {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("pubsub: %s", err)
+		}
+	}
+
+	for _, v := range s.Notify.Pulsar {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("pulsar: %s", err)
+		}
+	}
+
 	for _, v := range s.Notify.Redis {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("redis: %s", err)
@@ -235,6 +247,31 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	for k, v := range s.Logger.HTTP {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("logger http(%s): %s", k, err)
+		}
+	}
+
+	for k, v := range s.Tier {
+		if err := validateTierName(k); err != nil {
+			return fmt.Errorf("tier(%s): %s", k, err)
+		}
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("tier(%s): %s", k, err)
+		}
+	}
+
+	for k, v := range s.Replication {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("replication(%s): %s", k, err)
+		}
+	}
+
+	if err := s.LDAPServerConfig.Validate(); err != nil {
+		return fmt.Errorf("ldapserverconfig: %s", err)
+	}
+
 	return nil
 }
 
@@ -243,6 +280,7 @@ func (s *serverConfig) SetCompressionConfig(extensions []string, mimeTypes []str
 	s.Compression.Extensions = extensions
 	s.Compression.MimeTypes = mimeTypes
 	s.Compression.Enabled = globalIsCompressionEnabled
+	s.Compression.AllowEncryption = globalCompressAllowEncryption
 }
 
 // GetCompressionConfig gets the current compression config
@@ -293,6 +331,10 @@ func (s *serverConfig) loadFromEnvs() {
 			s.Policy.OPA.AuthToken = os.Getenv("MINIO_IAM_OPA_AUTHTOKEN")
 		}
 	}
+
+	ldapConfig, err := Environment.LookupLDAPConfig(s.LDAPServerConfig)
+	logger.FatalIf(err, "Unable to setup the LDAP identity provider")
+	s.LDAPServerConfig = ldapConfig
 }
 
 // TestNotificationTargets tries to establish connections to all notification
@@ -325,6 +367,7 @@ func (s *serverConfig) TestNotificationTargets() error {
 		if !v.Enable {
 			continue
 		}
+		v.TLS.RootCAs = globalRootCAs
 		t, err := target.NewKafkaTarget(k, v, GlobalServiceDoneCh)
 		if err != nil {
 			return fmt.Errorf("kafka(%s): %s", k, err.Error())
@@ -387,6 +430,28 @@ func (s *serverConfig) TestNotificationTargets() error {
 		t.Close()
 	}
 
+	for k, v := range s.Notify.PubSub {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewPubSubTarget(k, v, GlobalServiceDoneCh)
+		if err != nil {
+			return fmt.Errorf("pubsub(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
+	for k, v := range s.Notify.Pulsar {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewPulsarTarget(k, v, GlobalServiceDoneCh)
+		if err != nil {
+			return fmt.Errorf("pulsar(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
 	for k, v := range s.Notify.Redis {
 		if !v.Enable {
 			continue
@@ -431,6 +496,10 @@ func (s *serverConfig) ConfigDiff(t *serverConfig) string {
 		return "Redis Notification configuration differs"
 	case !reflect.DeepEqual(s.Notify.PostgreSQL, t.Notify.PostgreSQL):
 		return "PostgreSQL Notification configuration differs"
+	case !reflect.DeepEqual(s.Notify.PubSub, t.Notify.PubSub):
+		return "Pub/Sub Notification configuration differs"
+	case !reflect.DeepEqual(s.Notify.Pulsar, t.Notify.Pulsar):
+		return "Pulsar Notification configuration differs"
 	case !reflect.DeepEqual(s.Notify.Kafka, t.Notify.Kafka):
 		return "Kafka Notification configuration differs"
 	case !reflect.DeepEqual(s.Notify.Webhook, t.Notify.Webhook):
@@ -443,6 +512,12 @@ func (s *serverConfig) ConfigDiff(t *serverConfig) string {
 		return "Logger configuration differs"
 	case !reflect.DeepEqual(s.KMS, t.KMS):
 		return "KMS configuration differs"
+	case !reflect.DeepEqual(s.Tier, t.Tier):
+		return "Tier configuration differs"
+	case !reflect.DeepEqual(s.Replication, t.Replication):
+		return "Replication configuration differs"
+	case !reflect.DeepEqual(s.LDAPServerConfig, t.LDAPServerConfig):
+		return "LDAP server configuration differs"
 	case reflect.DeepEqual(s, t):
 		return ""
 	default:
@@ -494,6 +569,10 @@ func newServerConfig() *serverConfig {
 	srvCfg.Notify.NSQ["1"] = target.NSQArgs{}
 	srvCfg.Notify.PostgreSQL = make(map[string]target.PostgreSQLArgs)
 	srvCfg.Notify.PostgreSQL["1"] = target.PostgreSQLArgs{}
+	srvCfg.Notify.PubSub = make(map[string]target.PubSubArgs)
+	srvCfg.Notify.PubSub["1"] = target.PubSubArgs{}
+	srvCfg.Notify.Pulsar = make(map[string]target.PulsarArgs)
+	srvCfg.Notify.Pulsar["1"] = target.PulsarArgs{}
 	srvCfg.Notify.MySQL = make(map[string]target.MySQLArgs)
 	srvCfg.Notify.MySQL["1"] = target.MySQLArgs{}
 	srvCfg.Notify.Kafka = make(map[string]target.KafkaArgs)
@@ -501,6 +580,9 @@ func newServerConfig() *serverConfig {
 	srvCfg.Notify.Webhook = make(map[string]target.WebhookArgs)
 	srvCfg.Notify.Webhook["1"] = target.WebhookArgs{}
 
+	srvCfg.Tier = make(map[string]tierConfig)
+	srvCfg.Replication = make(map[string]replicationTarget)
+
 	srvCfg.Cache.Drives = make([]string, 0)
 	srvCfg.Cache.Exclude = make([]string, 0)
 	srvCfg.Cache.Expiry = globalCacheExpiry
@@ -545,6 +627,9 @@ func (s *serverConfig) loadToCachedConfigs() {
 		globalCompressMimeTypes = compressionConf.MimeTypes
 		globalIsCompressionEnabled = compressionConf.Enabled
 	}
+	if !globalCompressAllowEncryption {
+		globalCompressAllowEncryption = s.GetCompressionConfig().AllowEncryption
+	}
 
 	globalIAMValidators = getAuthValidators(s)
 
@@ -625,6 +710,15 @@ func getAuthValidators(config *serverConfig) *validator.Validators {
 	return validators
 }
 
+// compactNotificationTarget - returns the target sending compact events, if
+// configured to reduce the size of notifications.
+func compactNotificationTarget(t event.Target, compact bool) event.Target {
+	if compact {
+		return event.NewCompactTarget(t)
+	}
+	return t
+}
+
 // getNotificationTargets - returns TargetList which contains enabled targets in serverConfig.
 // A new notification target is added like below
 // * Add a new target in pkg/event/target package.
@@ -642,7 +736,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -657,7 +751,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				continue
 
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 
@@ -667,12 +761,13 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 
 	for id, args := range config.Notify.Kafka {
 		if args.Enable {
+			args.TLS.RootCAs = globalRootCAs
 			newTarget, err := target.NewKafkaTarget(id, args, GlobalServiceDoneCh)
 			if err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -687,7 +782,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -701,7 +796,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -715,7 +810,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -729,7 +824,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -743,7 +838,35 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
+	for id, args := range config.Notify.PubSub {
+		if args.Enable {
+			newTarget, err := target.NewPubSubTarget(id, args, GlobalServiceDoneCh)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
+	for id, args := range config.Notify.Pulsar {
+		if args.Enable {
+			newTarget, err := target.NewPulsarTarget(id, args, GlobalServiceDoneCh)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -757,7 +880,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
-			if err = targetList.Add(newTarget); err != nil {
+			if err = targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
@@ -768,7 +891,7 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		if args.Enable {
 			args.RootCAs = globalRootCAs
 			newTarget := target.NewWebhookTarget(id, args, GlobalServiceDoneCh)
-			if err := targetList.Add(newTarget); err != nil {
+			if err := targetList.Add(compactNotificationTarget(newTarget, args.Compact)); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
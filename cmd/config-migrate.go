@@ -2473,7 +2473,7 @@ func migrateConfigToMinioSys(objAPI ObjectLayer) (err error) {
 	return saveServerConfig(context.Background(), objAPI, config)
 }
 
-// Migrates '.minio.sys/config.json' to v33.
+// Migrates '.minio.sys/config.json' to v42.
 func migrateMinioSysConfig(objAPI ObjectLayer) error {
 	configFile := path.Join(minioConfigPrefix, minioConfigFile)
 
@@ -2513,7 +2513,34 @@ func migrateMinioSysConfig(objAPI ObjectLayer) error {
 	if err := migrateV31ToV32MinioSys(objAPI); err != nil {
 		return err
 	}
-	return migrateV32ToV33MinioSys(objAPI)
+	if err := migrateV32ToV33MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV33ToV34MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV34ToV35MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV35ToV36MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV36ToV37MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV37ToV38MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV38ToV39MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV39ToV40MinioSys(objAPI); err != nil {
+		return err
+	}
+	if err := migrateV40ToV41MinioSys(objAPI); err != nil {
+		return err
+	}
+	return migrateV41ToV42MinioSys(objAPI)
 }
 
 func checkConfigVersion(objAPI ObjectLayer, configFile string, version string) (bool, []byte, error) {
@@ -2742,3 +2769,321 @@ func migrateV32ToV33MinioSys(objAPI ObjectLayer) error {
 	logger.Info(configMigrateMSGTemplate, configFile, "32", "33")
 	return nil
 }
+
+func migrateV33ToV34MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "33")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV34{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "34"
+	cfg.Tier = make(map[string]tierConfig)
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘33’ to ‘34’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "33", "34")
+	return nil
+}
+
+func migrateV34ToV35MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "34")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV35{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "35"
+	cfg.Replication = make(map[string]replicationTarget)
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘34’ to ‘35’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "34", "35")
+	return nil
+}
+
+func migrateV35ToV36MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "35")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV36{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "36"
+	cfg.LDAPServerConfig = ldapServerConfig{}
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘35’ to ‘36’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "35", "36")
+	return nil
+}
+
+func migrateV36ToV37MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "36")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV37{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "37"
+	cfg.KMS.KMIP = crypto.KMIPConfig{}
+	cfg.KMS.KeyStore = crypto.KeyStoreConfig{}
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘36’ to ‘37’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "36", "37")
+	return nil
+}
+
+func migrateV37ToV38MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "37")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV38{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "38"
+	cfg.Compression.AllowEncryption = false
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘37’ to ‘38’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "37", "38")
+	return nil
+}
+
+func migrateV38ToV39MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "38")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV39{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "39"
+	// HTTP logger targets without a queue directory keep buffering
+	// their log entries in memory.
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘38’ to ‘39’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "38", "39")
+	return nil
+}
+
+func migrateV39ToV40MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "39")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV40{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "40"
+	cfg.Notify.PubSub = make(map[string]target.PubSubArgs)
+	cfg.Notify.PubSub["1"] = target.PubSubArgs{}
+	cfg.Notify.Pulsar = make(map[string]target.PulsarArgs)
+	cfg.Notify.Pulsar["1"] = target.PulsarArgs{}
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘39’ to ‘40’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "39", "40")
+	return nil
+}
+
+func migrateV40ToV41MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "40")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV41{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "41"
+	for k, args := range cfg.Notify.Kafka {
+		// Kafka targets authenticate with SASL/PLAIN as before.
+		if args.SASL.Enable && args.SASL.Mechanism == "" {
+			args.SASL.Mechanism = "PLAIN"
+		}
+		cfg.Notify.Kafka[k] = args
+	}
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘40’ to ‘41’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "40", "41")
+	return nil
+}
+
+func migrateV41ToV42MinioSys(objAPI ObjectLayer) error {
+	configFile := path.Join(minioConfigPrefix, minioConfigFile)
+
+	ok, data, err := checkConfigVersion(objAPI, configFile, "41")
+	if err == errConfigNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Unable to load config file. %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	cfg := &serverConfigV42{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return err
+	}
+
+	cfg.Version = "42"
+	// Existing notification targets keep sending the request
+	// parameters and response elements of events.
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = saveConfig(context.Background(), objAPI, configFile, data); err != nil {
+		return fmt.Errorf("Failed to migrate config from ‘41’ to ‘42’. %v", err)
+	}
+
+	logger.Info(configMigrateMSGTemplate, configFile, "41", "42")
+	return nil
+}
@@ -680,6 +680,11 @@ type loggerConsole struct {
 type loggerHTTP struct {
 	Enabled  bool   `json:"enabled"`
 	Endpoint string `json:"endpoint"`
+
+	// Directory persisting log entries until the endpoint
+	// accepted them, and the maximum number of entries.
+	QueueDir   string `json:"queueDir,omitempty"`
+	QueueLimit uint64 `json:"queueLimit,omitempty"`
 }
 
 type loggerConfig struct {
@@ -756,6 +761,10 @@ type compressionConfig struct {
 	Enabled    bool     `json:"enabled"`
 	Extensions []string `json:"extensions"`
 	MimeTypes  []string `json:"mime-types"`
+
+	// AllowEncryption allows compressing objects which are
+	// encrypted with SSE-C or SSE-S3.
+	AllowEncryption bool `json:"allow-encryption"`
 }
 
 // serverConfigV30 is just like version '29', stores additionally
@@ -838,6 +847,8 @@ type notifier struct {
 	NATS          map[string]target.NATSArgs          `json:"nats"`
 	NSQ           map[string]target.NSQArgs           `json:"nsq"`
 	PostgreSQL    map[string]target.PostgreSQLArgs    `json:"postgresql"`
+	PubSub        map[string]target.PubSubArgs        `json:"pubsub"`
+	Pulsar        map[string]target.PulsarArgs        `json:"pulsar"`
 	Redis         map[string]target.RedisArgs         `json:"redis"`
 	Webhook       map[string]target.WebhookArgs       `json:"webhook"`
 }
@@ -927,3 +938,471 @@ type serverConfigV33 struct {
 		// Add new external policy enforcements here.
 	} `json:"policy"`
 }
+
+// serverConfigV34 is just like version '33' with added remote tiers for lifecycle transitions.
+type serverConfigV34 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+}
+
+// serverConfigV35 is just like version '34' with added remote targets for bucket replication.
+type serverConfigV35 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+}
+
+// serverConfigV36 is just like version '35' with added LDAP identity provider configuration.
+type serverConfigV36 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV37 is just like version '36' with added KMIP and key store KMS configuration.
+type serverConfigV37 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV38 is just like version '37' with added opt-in compression of encrypted objects.
+type serverConfigV38 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV39 is just like version '38' with added queue directories for HTTP logger targets.
+type serverConfigV39 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV40 is just like version '39' with added Pulsar and Pub/Sub notification targets.
+type serverConfigV40 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV41 is just like version '40' with added SASL mechanisms and client certificates for Kafka targets.
+type serverConfigV41 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
+
+// serverConfigV42 is just like version '41' with added compact events for notification targets.
+type serverConfigV42 struct {
+	quick.Config `json:"-"` // ignore interfaces
+
+	Version string `json:"version"`
+
+	// S3 API configuration.
+	Credential auth.Credentials `json:"credential"`
+	Region     string           `json:"region"`
+	Worm       BoolFlag         `json:"worm"`
+
+	// Storage class configuration
+	StorageClass storageClassConfig `json:"storageclass"`
+
+	// Cache configuration
+	Cache CacheConfig `json:"cache"`
+
+	// KMS configuration
+	KMS crypto.KMSConfig `json:"kms"`
+
+	// Notification queue configuration.
+	Notify notifier `json:"notify"`
+
+	// Logger configuration
+	Logger loggerConfig `json:"logger"`
+
+	// Compression configuration
+	Compression compressionConfig `json:"compress"`
+
+	// OpenID configuration
+	OpenID struct {
+		// JWKS validator config.
+		JWKS validator.JWKSArgs `json:"jwks"`
+	} `json:"openid"`
+
+	// External policy enforcements.
+	Policy struct {
+		// OPA configuration.
+		OPA iampolicy.OpaArgs `json:"opa"`
+
+		// Add new external policy enforcements here.
+	} `json:"policy"`
+
+	// Remote tiers for lifecycle transitions.
+	Tier map[string]tierConfig `json:"tier"`
+
+	// Remote targets for bucket replication.
+	Replication map[string]replicationTarget `json:"replication"`
+
+	// LDAP identity provider configuration.
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+}
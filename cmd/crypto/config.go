@@ -14,8 +14,11 @@
 
 package crypto
 
-// KMSConfig has the KMS config for hashicorp vault
+// KMSConfig has the KMS config for hashicorp vault, a KMIP server
+// or a local key store. At most one of them must be configured.
 type KMSConfig struct {
-	AutoEncryption bool        `json:"-"`
-	Vault          VaultConfig `json:"vault"`
+	AutoEncryption bool           `json:"-"`
+	Vault          VaultConfig    `json:"vault"`
+	KMIP           KMIPConfig     `json:"kmip"`
+	KeyStore       KeyStoreConfig `json:"keystore"`
 }
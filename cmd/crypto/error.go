@@ -54,6 +54,10 @@ var (
 	// ErrIncompatibleEncryptionMethod indicates that both SSE-C headers and SSE-S3 headers were specified, and are incompatible
 	// The client needs to remove the SSE-S3 header or the SSE-C headers
 	ErrIncompatibleEncryptionMethod = errors.New("Server side encryption specified with both SSE-C and SSE-S3 headers")
+
+	// ErrInvalidEncryptionContext indicates that the SSE-KMS encryption context is not
+	// a base64-encoded JSON object of string values.
+	ErrInvalidEncryptionContext = errors.New("The SSE-KMS encryption context is invalid")
 )
 
 var (
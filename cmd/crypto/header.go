@@ -127,8 +127,10 @@ func (s3KMS) IsRequested(h http.Header) bool {
 }
 
 // ParseHTTP parses the SSE-KMS headers and returns the SSE-KMS key ID
-// and context, if present, on success.
-func (s3KMS) ParseHTTP(h http.Header) (string, interface{}, error) {
+// and the encryption context, if present, on success. The key ID is
+// empty if the client does not specify a KMS key. The encryption
+// context is sent as base64-encoded JSON object of string values.
+func (s3KMS) ParseHTTP(h http.Header) (string, Context, error) {
 	algorithm := h.Get(SSEHeader)
 	if algorithm != SSEAlgorithmKMS {
 		return "", nil, ErrInvalidEncryptionMethod
@@ -136,9 +138,13 @@ func (s3KMS) ParseHTTP(h http.Header) (string, interface{}, error) {
 
 	contextStr, ok := h[SSEKmsContext]
 	if ok {
-		var context map[string]interface{}
-		if err := json.Unmarshal([]byte(contextStr[0]), &context); err != nil {
-			return "", nil, err
+		b, err := base64.StdEncoding.DecodeString(contextStr[0])
+		if err != nil {
+			return "", nil, ErrInvalidEncryptionContext
+		}
+		var context Context
+		if err = json.Unmarshal(b, &context); err != nil {
+			return "", nil, ErrInvalidEncryptionContext
 		}
 		return h.Get(SSEKmsID), context, nil
 	}
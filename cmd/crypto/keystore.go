@@ -0,0 +1,263 @@
+// MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// KeyStoreConfig represents the configuration of a local key store.
+// The key store is a file containing named master keys which are
+// encrypted with a key derived from a passphrase.
+type KeyStoreConfig struct {
+	Path       string   `json:"path"` // The path of the key store file
+	Keys       []string `json:"keys"` // The names of the keys, the first one is used for SSE-S3.
+	Passphrase string   `json:"-"`    // The key store passphrase. Only available through ENV.
+}
+
+// IsEmpty returns true if the key store config struct is an
+// empty configuration.
+func (k *KeyStoreConfig) IsEmpty() bool {
+	return k.Path == "" && len(k.Keys) == 0 && k.Passphrase == ""
+}
+
+// Verify returns a nil error if the key store configuration
+// is valid. A valid configuration is either empty or
+// contains valid non-default values.
+func (k *KeyStoreConfig) Verify() (err error) {
+	if k.IsEmpty() {
+		return // an empty configuration is valid
+	}
+	switch {
+	case k.Path == "":
+		err = errors.New("crypto: missing key store path")
+	case len(k.Keys) == 0:
+		err = errors.New("crypto: missing key store key names")
+	case k.Passphrase == "":
+		err = errors.New("crypto: missing key store passphrase")
+	}
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(k.Keys))
+	for _, name := range k.Keys {
+		if name == "" {
+			return errors.New("crypto: invalid key store key name: The name must not be empty")
+		}
+		if names[name] {
+			return fmt.Errorf("crypto: invalid key store key names: %s is specified more than once", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// DefaultKey returns the name of the key used for SSE-S3.
+func (k *KeyStoreConfig) DefaultKey() string {
+	if len(k.Keys) == 0 {
+		return ""
+	}
+	return k.Keys[0]
+}
+
+const keyStoreVersion = 1
+
+var errKeyStorePassphrase = errors.New("crypto: failed to unseal key store: wrong passphrase or corrupted key store")
+
+// keyStoreFile is the on-disk format of a key store. Each key
+// is sealed with AES-256-GCM using a key derived from the
+// passphrase and salt by Argon2id and the key name as associated
+// data.
+type keyStoreFile struct {
+	Version int               `json:"version"`
+	Salt    []byte            `json:"salt"`
+	Keys    map[string][]byte `json:"keys"`
+}
+
+// keyStore is a KMS with multiple named master keys. Every named
+// key behaves like the master key KMS returned by NewKMS.
+type keyStore struct {
+	keys map[string]KMS
+}
+
+var _ KMS = (*keyStore)(nil) // compiler check that *keyStore implements KMS
+
+// NewKeyStore opens the key store at the path in config and returns a
+// KMS implementation using its named keys. The key store is created
+// if it does not exist and keys which are configured but not present
+// are generated and added to the key store. It fails if the key store
+// cannot be unsealed with the configured passphrase.
+func NewKeyStore(config KeyStoreConfig) (KMS, error) {
+	if config.IsEmpty() {
+		return nil, errors.New("crypto: the key store configuration must not be empty")
+	}
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	file, err := readKeyStoreFile(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	sealingKey, err := file.sealingKey(config.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &keyStore{keys: make(map[string]KMS, len(file.Keys))}
+	for name, sealedKey := range file.Keys {
+		nonceSize := sealingKey.NonceSize()
+		if len(sealedKey) < nonceSize {
+			return nil, errKeyStorePassphrase
+		}
+		plaintext, err := sealingKey.Open(nil, sealedKey[:nonceSize], sealedKey[nonceSize:], []byte(name))
+		if err != nil || len(plaintext) != 32 {
+			return nil, errKeyStorePassphrase
+		}
+		var masterKey [32]byte
+		copy(masterKey[:], plaintext)
+		store.keys[name] = NewKMS(masterKey)
+	}
+
+	modified := false
+	for _, name := range config.Keys {
+		if _, ok := store.keys[name]; ok {
+			continue
+		}
+		var masterKey [32]byte
+		nonce := make([]byte, sealingKey.NonceSize())
+		if _, err = io.ReadFull(rand.Reader, masterKey[:]); err != nil {
+			return nil, errOutOfEntropy
+		}
+		if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, errOutOfEntropy
+		}
+		file.Keys[name] = sealingKey.Seal(nonce, nonce, masterKey[:], []byte(name))
+		store.keys[name] = NewKMS(masterKey)
+		modified = true
+	}
+	if modified {
+		if err = writeKeyStoreFile(config.Path, file); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// readKeyStoreFile reads the key store at path. It returns a new,
+// empty key store if there is no file at path.
+func readKeyStoreFile(path string) (*keyStoreFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		file := &keyStoreFile{
+			Version: keyStoreVersion,
+			Salt:    make([]byte, 32),
+			Keys:    map[string][]byte{},
+		}
+		if _, err = io.ReadFull(rand.Reader, file.Salt); err != nil {
+			return nil, errOutOfEntropy
+		}
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file keyStoreFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("crypto: invalid key store %s: %v", path, err)
+	}
+	if file.Version != keyStoreVersion {
+		return nil, fmt.Errorf("crypto: unsupported key store version %d", file.Version)
+	}
+	if file.Keys == nil {
+		file.Keys = map[string][]byte{}
+	}
+	return &file, nil
+}
+
+// writeKeyStoreFile replaces the key store at path atomically.
+func writeKeyStoreFile(path string, file *keyStoreFile) error {
+	data, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+func (file *keyStoreFile) sealingKey(passphrase string) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), file.Salt, 1, 64*1024, 4, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (store *keyStore) namedKey(keyID string) (KMS, error) {
+	kms, ok := store.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("crypto: key '%s' does not exist in the key store", keyID)
+	}
+	return kms, nil
+}
+
+// GenerateKey generates a new random key sealed with the named
+// key referenced by keyID.
+func (store *keyStore) GenerateKey(keyID string, ctx Context) (key [32]byte, sealedKey []byte, err error) {
+	kms, err := store.namedKey(keyID)
+	if err != nil {
+		return key, nil, err
+	}
+	return kms.GenerateKey(keyID, ctx)
+}
+
+// UnsealKey unseals the sealedKey using the named key referenced
+// by keyID.
+func (store *keyStore) UnsealKey(keyID string, sealedKey []byte, ctx Context) (key [32]byte, err error) {
+	kms, err := store.namedKey(keyID)
+	if err != nil {
+		return key, err
+	}
+	return kms.UnsealKey(keyID, sealedKey, ctx)
+}
+
+// UpdateKey returns the sealedKey unmodified since the keys of a
+// key store are never rotated. It fails if the sealedKey cannot be
+// unsealed.
+func (store *keyStore) UpdateKey(keyID string, sealedKey []byte, ctx Context) ([]byte, error) {
+	kms, err := store.namedKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return kms.UpdateKey(keyID, sealedKey, ctx)
+}
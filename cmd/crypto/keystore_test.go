@@ -0,0 +1,144 @@
+// MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var verifyKeyStoreConfigTests = []struct {
+	Config     KeyStoreConfig
+	ShouldFail bool
+}{
+	{
+		ShouldFail: false, // 0
+		Config:     KeyStoreConfig{},
+	},
+	{
+		ShouldFail: true, // 1
+		Config:     KeyStoreConfig{Keys: []string{"my-key"}, Passphrase: "secret"},
+	},
+	{
+		ShouldFail: true, // 2
+		Config:     KeyStoreConfig{Path: "keystore.json", Passphrase: "secret"},
+	},
+	{
+		ShouldFail: true, // 3
+		Config:     KeyStoreConfig{Path: "keystore.json", Keys: []string{"my-key"}},
+	},
+	{
+		ShouldFail: true, // 4
+		Config:     KeyStoreConfig{Path: "keystore.json", Keys: []string{"my-key", ""}, Passphrase: "secret"},
+	},
+	{
+		ShouldFail: true, // 5
+		Config:     KeyStoreConfig{Path: "keystore.json", Keys: []string{"my-key", "my-key"}, Passphrase: "secret"},
+	},
+	{
+		ShouldFail: false, // 6
+		Config:     KeyStoreConfig{Path: "keystore.json", Keys: []string{"my-key", "other-key"}, Passphrase: "secret"},
+	},
+}
+
+func TestVerifyKeyStoreConfig(t *testing.T) {
+	for i, test := range verifyKeyStoreConfigTests {
+		test := test
+		t.Run(fmt.Sprintf("Test-%d", i), func(t *testing.T) {
+			err := test.Config.Verify()
+			if test.ShouldFail && err == nil {
+				t.Errorf("Verify should fail but returned 'err == nil'")
+			}
+			if !test.ShouldFail && err != nil {
+				t.Errorf("Verify should succeed but returned err: %s", err)
+			}
+		})
+	}
+}
+
+func TestKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := KeyStoreConfig{
+		Path:       filepath.Join(dir, "keystore.json"),
+		Keys:       []string{"my-key"},
+		Passphrase: "correct horse battery staple",
+	}
+	kms, err := NewKeyStore(config)
+	if err != nil {
+		t.Fatalf("Failed to create key store: %v", err)
+	}
+	ctx := Context{"bucket": "object"}
+	key, sealedKey, err := kms.GenerateKey("my-key", ctx)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if _, _, err = kms.GenerateKey("other-key", ctx); err == nil {
+		t.Errorf("Generating a key with an unknown key ID should fail")
+	}
+	keyStoreFile, err := ioutil.ReadFile(config.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-open the key store and add another key.
+	config.Keys = append(config.Keys, "other-key")
+	if kms, err = NewKeyStore(config); err != nil {
+		t.Fatalf("Failed to open key store: %v", err)
+	}
+	unsealedKey, err := kms.UnsealKey("my-key", sealedKey, ctx)
+	if err != nil {
+		t.Fatalf("Failed to unseal key: %v", err)
+	}
+	if unsealedKey != key {
+		t.Fatalf("Unsealed key does not match the generated key")
+	}
+	if _, err = kms.UnsealKey("other-key", sealedKey, ctx); err == nil {
+		t.Errorf("Unsealing with a different key ID should fail")
+	}
+	if _, err = kms.UnsealKey("my-key", sealedKey, Context{"bucket": "other-object"}); err == nil {
+		t.Errorf("Unsealing with a different context should fail")
+	}
+	rotatedKey, err := kms.UpdateKey("my-key", sealedKey, ctx)
+	if err != nil {
+		t.Fatalf("Failed to update key: %v", err)
+	}
+	if !bytes.Equal(rotatedKey, sealedKey) {
+		t.Errorf("Key should not change since key store keys are never rotated")
+	}
+	if _, _, err = kms.GenerateKey("other-key", ctx); err != nil {
+		t.Errorf("Failed to generate key with the added key: %v", err)
+	}
+	updatedFile, err := ioutil.ReadFile(config.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(keyStoreFile, updatedFile) {
+		t.Errorf("Key store has not been updated with the added key")
+	}
+
+	config.Passphrase = "wrong passphrase"
+	if _, err = NewKeyStore(config); err != errKeyStorePassphrase {
+		t.Errorf("Opening the key store with a wrong passphrase should fail with %v but got: %v", errKeyStorePassphrase, err)
+	}
+}
@@ -0,0 +1,468 @@
+// MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// KMIPConfig represents the configuration of a KMIP server.
+// MinIO authenticates to the KMIP server with a client
+// certificate over mTLS.
+type KMIPConfig struct {
+	Endpoint   string `json:"endpoint"`    // The KMIP server address as host:port
+	KeyName    string `json:"key-name"`    // The name of the default key used for SSE-S3.
+	ClientCert string `json:"client-cert"` // The path to the PEM-encoded client certificate
+	ClientKey  string `json:"client-key"`  // The path to the PEM-encoded client private key
+	CAPath     string `json:"capath"`      // The path to a PEM-encoded CA cert file or a directory of them. Optional.
+}
+
+// empty/default KMIP configuration used to check whether a particular is empty.
+var emptyKMIPConfig = KMIPConfig{}
+
+// IsEmpty returns true if the KMIP config struct is an
+// empty configuration.
+func (k *KMIPConfig) IsEmpty() bool { return *k == emptyKMIPConfig }
+
+// Verify returns a nil error if the KMIP configuration
+// is valid. A valid configuration is either empty or
+// contains valid non-default values.
+func (k *KMIPConfig) Verify() (err error) {
+	if k.IsEmpty() {
+		return // an empty configuration is valid
+	}
+	switch {
+	case k.Endpoint == "":
+		err = errors.New("crypto: missing KMIP endpoint")
+	case k.KeyName == "":
+		err = errors.New("crypto: missing KMIP key name")
+	case k.ClientCert == "":
+		err = errors.New("crypto: missing KMIP client certificate")
+	case k.ClientKey == "":
+		err = errors.New("crypto: missing KMIP client private key")
+	default:
+		if _, _, err = net.SplitHostPort(k.Endpoint); err != nil {
+			err = fmt.Errorf("crypto: invalid KMIP endpoint: %v", err)
+		}
+	}
+	return
+}
+
+const (
+	kmipTimeout      = 10 * time.Second
+	kmipMaxIdleConns = 16
+)
+
+// kmipService represents a connection to a KMIP server. It
+// encrypts the data keys with AES-GCM keys stored on the KMIP
+// server which are located by their name.
+type kmipService struct {
+	config    KMIPConfig
+	tlsConfig *tls.Config
+	idleConns chan net.Conn
+}
+
+var _ KMS = (*kmipService)(nil) // compiler check that *kmipService implements KMS
+
+// kmipSealedKey is the sealed data key. It references the KMIP
+// object used to seal it by its unique identifier such that a key
+// remains unsealable after the named key has been re-keyed.
+type kmipSealedKey struct {
+	UID  string `json:"uid"`
+	IV   []byte `json:"iv"`
+	Tag  []byte `json:"tag"`
+	Data []byte `json:"data"`
+}
+
+// NewKMIP returns a KMS implementation backed by the KMIP server
+// in config. It fails if the default key does not exist on the
+// KMIP server.
+func NewKMIP(config KMIPConfig) (KMS, error) {
+	if config.IsEmpty() {
+		return nil, errors.New("crypto: the KMIP configuration must not be empty")
+	}
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: failed to load KMIP client certificate: %v", err)
+	}
+	host, _, _ := net.SplitHostPort(config.Endpoint)
+	tlsConfig := &tls.Config{
+		ServerName:   host,
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if config.CAPath != "" {
+		if tlsConfig.RootCAs, err = loadCAPath(config.CAPath); err != nil {
+			return nil, fmt.Errorf("crypto: failed to load KMIP CA certificates: %v", err)
+		}
+	}
+	k := &kmipService{
+		config:    config,
+		tlsConfig: tlsConfig,
+		idleConns: make(chan net.Conn, kmipMaxIdleConns),
+	}
+	if _, err = k.locate(config.KeyName); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// loadCAPath returns a certificate pool of the PEM-encoded
+// certificates in the file or directory at path.
+func loadCAPath(path string) (*x509.CertPool, error) {
+	files := []string{path}
+	if stat, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if stat.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*")); err != nil {
+			return nil, err
+		}
+	}
+	pool := x509.NewCertPool()
+	for _, file := range files {
+		pemBytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(pemBytes) && len(files) == 1 {
+			return nil, fmt.Errorf("%s does not contain a PEM-encoded certificate", file)
+		}
+	}
+	return pool, nil
+}
+
+// GenerateKey returns a new random plaintext key and the key
+// sealed with the named key referenced by keyID on the KMIP
+// server. The context is bound to the sealed key as additional
+// authenticated data.
+func (k *kmipService) GenerateKey(keyID string, ctx Context) (key [32]byte, sealedKey []byte, err error) {
+	if _, err = io.ReadFull(rand.Reader, key[:]); err != nil {
+		logger.CriticalIf(context.Background(), errOutOfEntropy)
+	}
+	uid, err := k.locate(keyID)
+	if err != nil {
+		return key, nil, err
+	}
+	sealedKey, err = k.seal(uid, keyID, key, ctx)
+	return key, sealedKey, err
+}
+
+// UnsealKey decrypts the sealedKey on the KMIP server using the
+// key which has been used to seal it. The context must be the
+// same context as the one provided while generating the key.
+func (k *kmipService) UnsealKey(keyID string, sealedKey []byte, ctx Context) (key [32]byte, err error) {
+	var sealed kmipSealedKey
+	if err = json.Unmarshal(sealedKey, &sealed); err != nil {
+		return key, errKMIPInvalidSealedKey
+	}
+	plaintext, err := k.decrypt(sealed, kmipAAD(keyID, ctx))
+	if err != nil {
+		return key, err
+	}
+	if len(plaintext) != len(key) {
+		return key, errKMIPInvalidSealedKey
+	}
+	copy(key[:], plaintext)
+	return key, nil
+}
+
+// UpdateKey re-seals the sealedKey with the key currently named
+// keyID on the KMIP server if the named key has been re-keyed since
+// the sealedKey was created. Otherwise it returns the sealedKey
+// unmodified.
+func (k *kmipService) UpdateKey(keyID string, sealedKey []byte, ctx Context) ([]byte, error) {
+	var sealed kmipSealedKey
+	if err := json.Unmarshal(sealedKey, &sealed); err != nil {
+		return nil, errKMIPInvalidSealedKey
+	}
+	uid, err := k.locate(keyID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.UnsealKey(keyID, sealedKey, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if uid == sealed.UID {
+		return sealedKey, nil
+	}
+	return k.seal(uid, keyID, key, ctx)
+}
+
+// kmipAAD returns the additional authenticated data binding
+// a sealed key to the keyID and context.
+func kmipAAD(keyID string, ctx Context) []byte {
+	var aad bytes.Buffer
+	aad.WriteString(keyID)
+	ctx.WriteTo(&aad)
+	return aad.Bytes()
+}
+
+func (k *kmipService) seal(uid, keyID string, key [32]byte, ctx Context) ([]byte, error) {
+	sealed, err := k.encrypt(uid, key[:], kmipAAD(keyID, ctx))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
+}
+
+var (
+	errKMIPInvalidSealedKey = errors.New("crypto: invalid KMIP sealed key")
+	errKMIPInvalidResponse  = errors.New("crypto: invalid KMIP server response")
+)
+
+// KMIP 1.4 tags, types and enumeration values used by the client.
+const (
+	kmipTagAttribute                  = 0x420008
+	kmipTagAttributeName              = 0x42000A
+	kmipTagAttributeValue             = 0x42000B
+	kmipTagBatchCount                 = 0x42000D
+	kmipTagBatchItem                  = 0x42000F
+	kmipTagBlockCipherMode            = 0x420011
+	kmipTagCryptographicAlgorithm     = 0x420028
+	kmipTagCryptographicParameters    = 0x42002B
+	kmipTagIVCounterNonce             = 0x42003D
+	kmipTagNameType                   = 0x420054
+	kmipTagNameValue                  = 0x420055
+	kmipTagOperation                  = 0x42005C
+	kmipTagProtocolVersion            = 0x420069
+	kmipTagProtocolVersionMajor       = 0x42006A
+	kmipTagProtocolVersionMinor       = 0x42006B
+	kmipTagRequestHeader              = 0x420077
+	kmipTagRequestMessage             = 0x420078
+	kmipTagRequestPayload             = 0x420079
+	kmipTagResponseHeader             = 0x42007A
+	kmipTagResponseMessage            = 0x42007B
+	kmipTagResponsePayload            = 0x42007C
+	kmipTagResultMessage              = 0x42007D
+	kmipTagResultReason               = 0x42007E
+	kmipTagResultStatus               = 0x42007F
+	kmipTagTimeStamp                  = 0x420092
+	kmipTagUniqueIdentifier           = 0x420094
+	kmipTagTagLength                  = 0x4200A7
+	kmipTagData                       = 0x4200C2
+	kmipTagAuthenticatedEncryptionAD  = 0x4200FE
+	kmipTagAuthenticatedEncryptionTag = 0x4200FF
+
+	kmipTypeStructure   = 0x01
+	kmipTypeInteger     = 0x02
+	kmipTypeLongInteger = 0x03
+	kmipTypeBigInteger  = 0x04
+	kmipTypeEnumeration = 0x05
+	kmipTypeBoolean     = 0x06
+	kmipTypeTextString  = 0x07
+	kmipTypeByteString  = 0x08
+	kmipTypeDateTime    = 0x09
+	kmipTypeInterval    = 0x0A
+
+	kmipOperationLocate  = 0x08
+	kmipOperationEncrypt = 0x1F
+	kmipOperationDecrypt = 0x20
+
+	kmipResultStatusSuccess = 0x00
+	kmipNameTypeText        = 0x01
+	kmipAlgorithmAES        = 0x03
+	kmipBlockCipherModeGCM  = 0x09
+	kmipGCMTagLength        = 16
+)
+
+// locate returns the unique identifier of the KMIP object named name.
+func (k *kmipService) locate(name string) (string, error) {
+	payload, err := k.do(kmipOperationLocate,
+		kmipStruct(kmipTagAttribute,
+			kmipText(kmipTagAttributeName, "Name"),
+			kmipStruct(kmipTagAttributeValue,
+				kmipText(kmipTagNameValue, name),
+				kmipEnum(kmipTagNameType, kmipNameTypeText),
+			),
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	uid, ok := payload.Child(kmipTagUniqueIdentifier)
+	if !ok {
+		return "", fmt.Errorf("crypto: KMIP key '%s' does not exist", name)
+	}
+	if s, ok := uid.Value.(string); ok {
+		return s, nil
+	}
+	return "", errKMIPInvalidResponse
+}
+
+func kmipGCMParameters() ttlv {
+	return kmipStruct(kmipTagCryptographicParameters,
+		kmipEnum(kmipTagBlockCipherMode, kmipBlockCipherModeGCM),
+		kmipEnum(kmipTagCryptographicAlgorithm, kmipAlgorithmAES),
+		kmipInt(kmipTagTagLength, kmipGCMTagLength),
+	)
+}
+
+// encrypt encrypts plaintext with the KMIP object uid using AES-GCM.
+// The IV is generated by the KMIP server.
+func (k *kmipService) encrypt(uid string, plaintext, aad []byte) (kmipSealedKey, error) {
+	payload, err := k.do(kmipOperationEncrypt,
+		kmipText(kmipTagUniqueIdentifier, uid),
+		kmipGCMParameters(),
+		kmipBytes(kmipTagData, plaintext),
+		kmipBytes(kmipTagAuthenticatedEncryptionAD, aad),
+	)
+	if err != nil {
+		return kmipSealedKey{}, err
+	}
+	sealed := kmipSealedKey{UID: uid}
+	var ok [3]bool
+	sealed.Data, ok[0] = payload.Bytes(kmipTagData)
+	sealed.IV, ok[1] = payload.Bytes(kmipTagIVCounterNonce)
+	sealed.Tag, ok[2] = payload.Bytes(kmipTagAuthenticatedEncryptionTag)
+	if !ok[0] || !ok[1] || !ok[2] {
+		return kmipSealedKey{}, errKMIPInvalidResponse
+	}
+	return sealed, nil
+}
+
+// decrypt decrypts the sealed key with the KMIP object referenced
+// by the sealed key.
+func (k *kmipService) decrypt(sealed kmipSealedKey, aad []byte) ([]byte, error) {
+	payload, err := k.do(kmipOperationDecrypt,
+		kmipText(kmipTagUniqueIdentifier, sealed.UID),
+		kmipGCMParameters(),
+		kmipBytes(kmipTagData, sealed.Data),
+		kmipBytes(kmipTagIVCounterNonce, sealed.IV),
+		kmipBytes(kmipTagAuthenticatedEncryptionAD, aad),
+		kmipBytes(kmipTagAuthenticatedEncryptionTag, sealed.Tag),
+	)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, ok := payload.Bytes(kmipTagData)
+	if !ok {
+		return nil, errKMIPInvalidResponse
+	}
+	return plaintext, nil
+}
+
+// do sends a request with a single batch item for operation to
+// the KMIP server and returns the response payload on success.
+func (k *kmipService) do(operation uint32, payload ...ttlv) (ttlv, error) {
+	request := kmipStruct(kmipTagRequestMessage,
+		kmipStruct(kmipTagRequestHeader,
+			kmipStruct(kmipTagProtocolVersion,
+				kmipInt(kmipTagProtocolVersionMajor, 1),
+				kmipInt(kmipTagProtocolVersionMinor, 4),
+			),
+			kmipInt(kmipTagBatchCount, 1),
+		),
+		kmipStruct(kmipTagBatchItem,
+			kmipEnum(kmipTagOperation, operation),
+			kmipStruct(kmipTagRequestPayload, payload...),
+		),
+	)
+	response, err := k.roundTrip(request.Marshal())
+	if err != nil {
+		return ttlv{}, err
+	}
+	if response.Tag != kmipTagResponseMessage {
+		return ttlv{}, errKMIPInvalidResponse
+	}
+	item, ok := response.Child(kmipTagBatchItem)
+	if !ok {
+		return ttlv{}, errKMIPInvalidResponse
+	}
+	if status, ok := item.Child(kmipTagResultStatus); !ok || status.Value != uint32(kmipResultStatusSuccess) {
+		var reason, message interface{}
+		if r, ok := item.Child(kmipTagResultReason); ok {
+			reason = r.Value
+		}
+		if m, ok := item.Child(kmipTagResultMessage); ok {
+			message = m.Value
+		}
+		return ttlv{}, fmt.Errorf("crypto: KMIP operation 0x%02x failed: reason 0x%02x: %v", operation, reason, message)
+	}
+	response, _ = item.Child(kmipTagResponsePayload)
+	return response, nil
+}
+
+// roundTrip writes the request to a connection to the KMIP server
+// and reads the response. Connections are kept open and reused for
+// subsequent requests.
+func (k *kmipService) roundTrip(request []byte) (ttlv, error) {
+	var (
+		conn   net.Conn
+		pooled bool
+		err    error
+	)
+	select {
+	case conn = <-k.idleConns:
+		pooled = true
+	default:
+		if conn, err = k.dial(); err != nil {
+			return ttlv{}, err
+		}
+	}
+
+	response, err := kmipWriteRead(conn, request)
+	if err != nil && pooled { // The server may have closed the idle connection - retry once.
+		conn.Close()
+		if conn, err = k.dial(); err != nil {
+			return ttlv{}, err
+		}
+		response, err = kmipWriteRead(conn, request)
+	}
+	if err != nil {
+		conn.Close()
+		return ttlv{}, err
+	}
+
+	select {
+	case k.idleConns <- conn:
+	default:
+		conn.Close()
+	}
+	return response, nil
+}
+
+func (k *kmipService) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: kmipTimeout}
+	return tls.DialWithDialer(dialer, "tcp", k.config.Endpoint, k.tlsConfig)
+}
+
+func kmipWriteRead(conn net.Conn, request []byte) (ttlv, error) {
+	conn.SetDeadline(time.Now().Add(kmipTimeout))
+	defer conn.SetDeadline(time.Time{})
+	if _, err := conn.Write(request); err != nil {
+		return ttlv{}, err
+	}
+	return readTTLV(conn)
+}
@@ -0,0 +1,475 @@
+// MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var ttlvEncodingTests = []struct {
+	Item     ttlv
+	Encoding string
+}{
+	{ // 0 - see the TTLV encoding examples of the KMIP specification
+		Item:     kmipInt(0x420020, 8),
+		Encoding: "42002002000000040000000800000000",
+	},
+	{ // 1
+		Item:     ttlv{Tag: 0x420020, Type: kmipTypeLongInteger, Value: int64(123456789000000000)},
+		Encoding: "420020030000000801B69B4BA5749200",
+	},
+	{ // 2
+		Item:     kmipEnum(0x420020, 255),
+		Encoding: "4200200500000004000000FF00000000",
+	},
+	{ // 3
+		Item:     ttlv{Tag: 0x420020, Type: kmipTypeBoolean, Value: true},
+		Encoding: "42002006000000080000000000000001",
+	},
+	{ // 4
+		Item:     kmipText(0x420020, "Hello World"),
+		Encoding: "420020070000000B48656C6C6F20576F726C640000000000",
+	},
+	{ // 5
+		Item:     kmipBytes(0x420020, []byte{1, 2, 3}),
+		Encoding: "42002008000000030102030000000000",
+	},
+	{ // 6
+		Item:     ttlv{Tag: 0x420020, Type: kmipTypeDateTime, Value: time.Date(2008, time.March, 14, 11, 56, 40, 0, time.UTC)},
+		Encoding: "42002009000000080000000047DA67F8",
+	},
+	{ // 7
+		Item:     ttlv{Tag: 0x420020, Type: kmipTypeInterval, Value: uint32(864000)},
+		Encoding: "4200200A00000004000D2F0000000000",
+	},
+	{ // 8
+		Item:     kmipStruct(0x420020, kmipEnum(0x420004, 254), kmipInt(0x420005, 255)),
+		Encoding: "42002001000000204200040500000004000000FE000000004200050200000004000000FF00000000",
+	},
+}
+
+func TestTTLVEncoding(t *testing.T) {
+	for i, test := range ttlvEncodingTests {
+		encoding := strings.ToUpper(hex.EncodeToString(test.Item.Marshal()))
+		if encoding != test.Encoding {
+			t.Errorf("Test %d: encoding mismatch: got %s - want %s", i, encoding, test.Encoding)
+		}
+		item, err := readTTLV(bytes.NewReader(test.Item.Marshal()))
+		if err != nil {
+			t.Fatalf("Test %d: failed to parse TTLV: %v", i, err)
+		}
+		if !bytes.Equal(item.Marshal(), test.Item.Marshal()) {
+			t.Errorf("Test %d: parsed item does not match: got %v - want %v", i, item, test.Item)
+		}
+	}
+}
+
+var invalidTTLVTests = []string{
+	"420020020000000400000008",                 // 0 - truncated value
+	"42002002000000080000000800000000",         // 1 - invalid integer length
+	"420020FF000000040000000800000000",         // 2 - unknown type
+	"42002001000000104200200200000004000000FF", // 3 - truncated structure
+}
+
+func TestInvalidTTLV(t *testing.T) {
+	for i, test := range invalidTTLVTests {
+		encoding, _ := hex.DecodeString(test)
+		if _, _, err := parseTTLV(encoding); err == nil {
+			t.Errorf("Test %d: parsing should fail but it succeeded", i)
+		}
+	}
+}
+
+var verifyKMIPConfigTests = []struct {
+	Config     KMIPConfig
+	ShouldFail bool
+}{
+	{
+		ShouldFail: false, // 0
+		Config:     KMIPConfig{},
+	},
+	{
+		ShouldFail: true, // 1
+		Config:     KMIPConfig{Endpoint: "127.0.0.1:5696"},
+	},
+	{
+		ShouldFail: true, // 2
+		Config:     KMIPConfig{Endpoint: "127.0.0.1:5696", KeyName: "my-key"},
+	},
+	{
+		ShouldFail: true, // 3
+		Config:     KMIPConfig{Endpoint: "127.0.0.1:5696", KeyName: "my-key", ClientCert: "public.crt"},
+	},
+	{
+		ShouldFail: true, // 4
+		Config: KMIPConfig{
+			Endpoint:   "127.0.0.1",
+			KeyName:    "my-key",
+			ClientCert: "public.crt",
+			ClientKey:  "private.key",
+		},
+	},
+	{
+		ShouldFail: false, // 5
+		Config: KMIPConfig{
+			Endpoint:   "127.0.0.1:5696",
+			KeyName:    "my-key",
+			ClientCert: "public.crt",
+			ClientKey:  "private.key",
+		},
+	},
+}
+
+func TestVerifyKMIPConfig(t *testing.T) {
+	for i, test := range verifyKMIPConfigTests {
+		test := test
+		t.Run(fmt.Sprintf("Test-%d", i), func(t *testing.T) {
+			err := test.Config.Verify()
+			if test.ShouldFail && err == nil {
+				t.Errorf("Verify should fail but returned 'err == nil'")
+			}
+			if !test.ShouldFail && err != nil {
+				t.Errorf("Verify should succeed but returned err: %s", err)
+			}
+		})
+	}
+}
+
+// kmipTestServer is a minimal in-process KMIP server supporting
+// the Locate, Encrypt and Decrypt operations with AES-GCM keys.
+type kmipTestServer struct {
+	listener net.Listener
+	dir      string
+
+	lock  sync.Mutex
+	names map[string]string // key name -> unique identifier
+	keys  map[string][]byte // unique identifier -> AES key
+}
+
+// newKMIPTestServer starts a KMIP test server and returns it with
+// a client configuration for the key name. The server and client
+// authenticate each other with a self-signed certificate. The server
+// must be closed by the caller.
+func newKMIPTestServer(t *testing.T, keyName string) (*kmipTestServer, KMIPConfig) {
+	dir, err := ioutil.TempDir("", "kmip-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, "public.crt"), filepath.Join(dir, "private.key")
+	certificate := generateTestCertificate(t, certFile, keyFile)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate.Leaf)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	server := &kmipTestServer{
+		listener: listener,
+		dir:      dir,
+		names:    map[string]string{},
+		keys:     map[string][]byte{},
+	}
+	server.Rekey(keyName)
+	go server.serve()
+	return server, KMIPConfig{
+		Endpoint:   listener.Addr().String(),
+		KeyName:    keyName,
+		ClientCert: certFile,
+		ClientKey:  keyFile,
+		CAPath:     certFile,
+	}
+}
+
+func generateTestCertificate(t *testing.T, certFile, keyFile string) tls.Certificate {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kmip-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err = ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate.Leaf, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+// Close stops the server and removes its certificate files.
+func (s *kmipTestServer) Close() {
+	s.listener.Close()
+	os.RemoveAll(s.dir)
+}
+
+// Rekey creates a new key and moves the name to it, like the
+// KMIP Re-key operation does.
+func (s *kmipTestServer) Rekey(name string) {
+	key := make([]byte, 32)
+	rand.Read(key)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	uid := fmt.Sprintf("%d", len(s.keys)+1)
+	s.keys[uid] = key
+	s.names[name] = uid
+}
+
+func (s *kmipTestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			for {
+				request, err := readTTLV(conn)
+				if err != nil {
+					return
+				}
+				if _, err = conn.Write(s.handle(request).Marshal()); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func (s *kmipTestServer) handle(request ttlv) ttlv {
+	item, _ := request.Child(kmipTagBatchItem)
+	operation, _ := item.Child(kmipTagOperation)
+	payload, _ := item.Child(kmipTagRequestPayload)
+
+	var (
+		response []ttlv
+		err      error
+	)
+	switch operation.Value {
+	case uint32(kmipOperationLocate):
+		response, err = s.locate(payload)
+	case uint32(kmipOperationEncrypt):
+		response, err = s.encrypt(payload)
+	case uint32(kmipOperationDecrypt):
+		response, err = s.decrypt(payload)
+	default:
+		err = fmt.Errorf("operation %v not supported", operation.Value)
+	}
+
+	result := []ttlv{operation}
+	if err != nil {
+		result = append(result,
+			kmipEnum(kmipTagResultStatus, 0x01),
+			kmipEnum(kmipTagResultReason, 0x0100),
+			kmipText(kmipTagResultMessage, err.Error()),
+		)
+	} else {
+		result = append(result,
+			kmipEnum(kmipTagResultStatus, kmipResultStatusSuccess),
+			kmipStruct(kmipTagResponsePayload, response...),
+		)
+	}
+	return kmipStruct(kmipTagResponseMessage,
+		kmipStruct(kmipTagResponseHeader,
+			kmipStruct(kmipTagProtocolVersion,
+				kmipInt(kmipTagProtocolVersionMajor, 1),
+				kmipInt(kmipTagProtocolVersionMinor, 4),
+			),
+			ttlv{Tag: kmipTagTimeStamp, Type: kmipTypeDateTime, Value: time.Now()},
+			kmipInt(kmipTagBatchCount, 1),
+		),
+		kmipStruct(kmipTagBatchItem, result...),
+	)
+}
+
+func (s *kmipTestServer) locate(payload ttlv) ([]ttlv, error) {
+	attribute, _ := payload.Child(kmipTagAttribute)
+	value, _ := attribute.Child(kmipTagAttributeValue)
+	name, _ := value.Child(kmipTagNameValue)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if uid, ok := s.names[name.Value.(string)]; ok {
+		return []ttlv{kmipText(kmipTagUniqueIdentifier, uid)}, nil
+	}
+	return nil, nil
+}
+
+func (s *kmipTestServer) cipher(payload ttlv) (cipher.AEAD, error) {
+	uid, _ := payload.Child(kmipTagUniqueIdentifier)
+
+	s.lock.Lock()
+	key, ok := s.keys[uid.Value.(string)]
+	s.lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("object %v not found", uid.Value)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *kmipTestServer) encrypt(payload ttlv) ([]ttlv, error) {
+	aead, err := s.cipher(payload)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := payload.Bytes(kmipTagData)
+	aad, _ := payload.Bytes(kmipTagAuthenticatedEncryptionAD)
+	iv := make([]byte, aead.NonceSize())
+	rand.Read(iv)
+	ciphertext := aead.Seal(nil, iv, data, aad)
+	tagOffset := len(ciphertext) - aead.Overhead()
+	return []ttlv{
+		kmipBytes(kmipTagData, ciphertext[:tagOffset]),
+		kmipBytes(kmipTagIVCounterNonce, iv),
+		kmipBytes(kmipTagAuthenticatedEncryptionTag, ciphertext[tagOffset:]),
+	}, nil
+}
+
+func (s *kmipTestServer) decrypt(payload ttlv) ([]ttlv, error) {
+	aead, err := s.cipher(payload)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := payload.Bytes(kmipTagData)
+	iv, _ := payload.Bytes(kmipTagIVCounterNonce)
+	aad, _ := payload.Bytes(kmipTagAuthenticatedEncryptionAD)
+	tag, _ := payload.Bytes(kmipTagAuthenticatedEncryptionTag)
+	if len(iv) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid IV")
+	}
+	plaintext, err := aead.Open(nil, iv, append(append([]byte{}, data...), tag...), aad)
+	if err != nil {
+		return nil, err
+	}
+	return []ttlv{kmipBytes(kmipTagData, plaintext)}, nil
+}
+
+func TestKMIP(t *testing.T) {
+	server, config := newKMIPTestServer(t, "my-key")
+	defer server.Close()
+	server.Rekey("other-key")
+
+	kms, err := NewKMIP(config)
+	if err != nil {
+		t.Fatalf("Failed to connect to KMIP server: %v", err)
+	}
+
+	ctx := Context{"bucket": "object"}
+	key, sealedKey, err := kms.GenerateKey("my-key", ctx)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	unsealedKey, err := kms.UnsealKey("my-key", sealedKey, ctx)
+	if err != nil {
+		t.Fatalf("Failed to unseal key: %v", err)
+	}
+	if unsealedKey != key {
+		t.Fatalf("Unsealed key does not match the generated key")
+	}
+	if _, err = kms.UnsealKey("my-key", sealedKey, Context{"bucket": "other-object"}); err == nil {
+		t.Errorf("Unsealing with a different context should fail")
+	}
+	if _, err = kms.UnsealKey("other-key", sealedKey, ctx); err == nil {
+		t.Errorf("Unsealing with a different key ID should fail")
+	}
+	if _, _, err = kms.GenerateKey("unknown-key", ctx); err == nil {
+		t.Errorf("Generating a key with an unknown key ID should fail")
+	}
+
+	rotatedKey, err := kms.UpdateKey("my-key", sealedKey, ctx)
+	if err != nil {
+		t.Fatalf("Failed to update key: %v", err)
+	}
+	if !bytes.Equal(rotatedKey, sealedKey) {
+		t.Errorf("Key should not change if the KMIP key has not been re-keyed")
+	}
+
+	server.Rekey("my-key")
+	if rotatedKey, err = kms.UpdateKey("my-key", sealedKey, ctx); err != nil {
+		t.Fatalf("Failed to update key: %v", err)
+	}
+	if bytes.Equal(rotatedKey, sealedKey) {
+		t.Errorf("Key should change if the KMIP key has been re-keyed")
+	}
+	for _, sealed := range [][]byte{sealedKey, rotatedKey} {
+		if unsealedKey, err = kms.UnsealKey("my-key", sealed, ctx); err != nil {
+			t.Fatalf("Failed to unseal key: %v", err)
+		}
+		if unsealedKey != key {
+			t.Fatalf("Unsealed key does not match the generated key")
+		}
+	}
+
+	config.KeyName = "unknown-key"
+	if _, err = NewKMIP(config); err == nil {
+		t.Errorf("Connecting to KMIP server with an unknown default key should fail")
+	}
+}
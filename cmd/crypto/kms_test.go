@@ -59,6 +59,24 @@ func TestMasterKeyKMS(t *testing.T) {
 	}
 }
 
+func TestMasterKeyKMSUpdateKey(t *testing.T) {
+	kms := NewKMS([32]byte{})
+	_, sealedKey, err := kms.GenerateKey("key", Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("KMS failed to generate key: %v", err)
+	}
+	rotatedKey, err := kms.UpdateKey("key", sealedKey, Context{"bucket": "object"})
+	if err != nil {
+		t.Fatalf("KMS failed to update key: %v", err)
+	}
+	if !bytes.Equal(sealedKey, rotatedKey) {
+		t.Fatal("The sealed key should not change without a rotation of the master key")
+	}
+	if _, err = kms.UpdateKey("key", sealedKey, Context{"bucket": "other-object"}); err == nil {
+		t.Fatal("KMS updated the key successfully but should have failed")
+	}
+}
+
 var contextWriteToTests = []struct {
 	Context      Context
 	ExpectedJSON string
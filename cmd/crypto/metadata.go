@@ -17,6 +17,7 @@ package crypto
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/minio/minio/cmd/logger"
@@ -58,6 +59,7 @@ func RemoveInternalEntries(metadata map[string]string) {
 	delete(metadata, S3SealedKey)
 	delete(metadata, S3KMSKeyID)
 	delete(metadata, S3KMSSealedKey)
+	delete(metadata, S3KMSContext)
 }
 
 // IsEncrypted returns true if the object metadata indicates
@@ -99,6 +101,15 @@ func (s3) IsEncrypted(metadata map[string]string) bool {
 	return false
 }
 
+// IsEncrypted returns true if the object metadata indicates
+// that the object was uploaded using SSE-KMS. SSE-KMS objects
+// are SSE-S3 objects with a client provided KMS key-ID and
+// encryption context, hence S3.IsEncrypted is also true.
+func (s3KMS) IsEncrypted(metadata map[string]string) bool {
+	_, ok := metadata[S3KMSContext]
+	return ok
+}
+
 // IsEncrypted returns true if the object metadata indicates
 // that the object was uploaded using SSE-C.
 func (ssec) IsEncrypted(metadata map[string]string) bool {
@@ -187,6 +198,43 @@ func (s3) ParseMetadata(metadata map[string]string) (keyID string, kmsKey []byte
 	return keyID, kmsKey, sealedKey, nil
 }
 
+// CreateMetadata encodes the keyID, the sealed kms data key, the sealed key and
+// the encryption context into the metadata and returns the modified metadata.
+// It allocates a new metadata map if metadata is nil.
+func (s3KMS) CreateMetadata(metadata map[string]string, keyID string, kmsKey []byte, sealedKey SealedKey, kmsContext Context) map[string]string {
+	if kmsContext == nil {
+		kmsContext = Context{}
+	}
+	b, err := json.Marshal(kmsContext)
+	if err != nil {
+		logger.CriticalIf(context.Background(), err)
+	}
+
+	metadata = S3.CreateMetadata(metadata, keyID, kmsKey, sealedKey)
+	metadata[S3KMSContext] = base64.StdEncoding.EncodeToString(b)
+	return metadata
+}
+
+// ParseMetadata extracts the encryption context provided by the client for
+// SSE-KMS from the object metadata. It returns an empty context for SSE-S3
+// objects. The KMS key-ID, the sealed KMS key and the sealed object key of
+// SSE-KMS objects are extracted by S3.ParseMetadata.
+func (s3KMS) ParseMetadata(metadata map[string]string) (kmsContext Context, err error) {
+	kmsContext = Context{}
+	b64Context, ok := metadata[S3KMSContext]
+	if !ok {
+		return kmsContext, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(b64Context)
+	if err != nil {
+		return kmsContext, Error{"The internal encryption context for SSE-KMS is invalid"}
+	}
+	if err = json.Unmarshal(b, &kmsContext); err != nil {
+		return kmsContext, Error{"The internal encryption context for SSE-KMS is invalid"}
+	}
+	return kmsContext, nil
+}
+
 // CreateMetadata encodes the sealed key into the metadata and returns the modified metadata.
 // It allocates a new metadata map if metadata is nil.
 func (ssec) CreateMetadata(metadata map[string]string, sealedKey SealedKey) map[string]string {
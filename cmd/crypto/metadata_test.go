@@ -327,6 +327,45 @@ func TestS3CreateMetadata(t *testing.T) {
 	_ = S3.CreateMetadata(nil, "", []byte{}, SealedKey{Algorithm: InsecureSealAlgorithm})
 }
 
+func TestS3KMSCreateMetadata(t *testing.T) {
+	sealedKey := SealedKey{IV: [32]byte{0xf7}, Key: [64]byte{0xea}, Algorithm: SealAlgorithm}
+	testCases := []Context{
+		nil,
+		{},
+		{"department": "finance", "project": "minio"},
+	}
+	for i, kmsContext := range testCases {
+		metadata := S3KMS.CreateMetadata(nil, "my-key", make([]byte, 48), sealedKey, kmsContext)
+		if !S3KMS.IsEncrypted(metadata) || !S3.IsEncrypted(metadata) {
+			t.Errorf("Test %d: expected SSE-KMS metadata to be encrypted with SSE-KMS and SSE-S3", i)
+		}
+		if keyID, _, _, err := S3.ParseMetadata(metadata); err != nil || keyID != "my-key" {
+			t.Errorf("Test %d: failed to parse key-ID '%s': %v", i, keyID, err)
+		}
+		parsedContext, err := S3KMS.ParseMetadata(metadata)
+		if err != nil {
+			t.Errorf("Test %d: failed to parse metadata: %v", i, err)
+			continue
+		}
+		if len(parsedContext) != len(kmsContext) {
+			t.Errorf("Test %d: context mismatch: got '%v' - want '%v'", i, parsedContext, kmsContext)
+		}
+		for k, v := range kmsContext {
+			if parsedContext[k] != v {
+				t.Errorf("Test %d: context mismatch: got '%v' - want '%v'", i, parsedContext, kmsContext)
+			}
+		}
+	}
+
+	metadata := S3.CreateMetadata(nil, "my-key", make([]byte, 48), sealedKey)
+	if S3KMS.IsEncrypted(metadata) {
+		t.Error("SSE-S3 metadata must not be encrypted with SSE-KMS")
+	}
+	if _, err := S3KMS.ParseMetadata(map[string]string{S3KMSContext: "e30"}); err == nil {
+		t.Error("Invalid SSE-KMS context should fail to parse")
+	}
+}
+
 var ssecCreateMetadataTests = []struct {
 	KeyID         string
 	SealedDataKey []byte
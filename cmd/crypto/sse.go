@@ -47,12 +47,18 @@ const (
 	S3SealedKey = "X-Minio-Internal-Server-Side-Encryption-S3-Sealed-Key"
 
 	// S3KMSKeyID is the metadata key referencing the KMS key-id used to
-	// generate/decrypt the S3-KMS-Sealed-Key. It is only used for SSE-S3 + KMS.
+	// generate/decrypt the S3-KMS-Sealed-Key. It is only used for SSE-S3
+	// and SSE-KMS.
 	S3KMSKeyID = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Key-Id"
 
 	// S3KMSSealedKey is the metadata key referencing the encrypted key generated
-	// by KMS. It is only used for SSE-S3 + KMS.
+	// by KMS. It is only used for SSE-S3 and SSE-KMS.
 	S3KMSSealedKey = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Sealed-Key"
+
+	// S3KMSContext is the metadata key referencing the base64-encoded
+	// encryption context provided by the client for SSE-KMS. It is only
+	// used for SSE-KMS and marks an object as SSE-KMS encrypted.
+	S3KMSContext = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Context"
 )
 
 const (
@@ -73,13 +79,20 @@ func (s3) String() string { return "SSE-S3" }
 
 // UnsealObjectKey extracts and decrypts the sealed object key
 // from the metadata using KMS and returns the decrypted object
-// key.
+// key. It also unseals the object key of SSE-KMS objects, which
+// only differ from SSE-S3 objects by the KMS key-ID and the
+// encryption context chosen by the client.
 func (sse s3) UnsealObjectKey(kms KMS, metadata map[string]string, bucket, object string) (key ObjectKey, err error) {
 	keyID, kmsKey, sealedKey, err := sse.ParseMetadata(metadata)
 	if err != nil {
 		return
 	}
-	unsealKey, err := kms.UnsealKey(keyID, kmsKey, Context{bucket: path.Join(bucket, object)})
+	kmsContext, err := S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		return
+	}
+	kmsContext[bucket] = path.Join(bucket, object)
+	unsealKey, err := kms.UnsealKey(keyID, kmsKey, kmsContext)
 	if err != nil {
 		return
 	}
@@ -87,6 +100,10 @@ func (sse s3) UnsealObjectKey(kms KMS, metadata map[string]string, bucket, objec
 	return
 }
 
+// String returns the SSE domain as string. For SSE-KMS the
+// domain is "SSE-KMS".
+func (s3KMS) String() string { return "SSE-KMS" }
+
 // String returns the SSE domain as string. For SSE-C the
 // domain is "SSE-C".
 func (ssec) String() string { return "SSE-C" }
@@ -15,7 +15,9 @@
 package crypto
 
 import (
+	"crypto/rand"
 	"net/http"
+	"path"
 	"testing"
 )
 
@@ -33,6 +35,41 @@ func TestSSECString(t *testing.T) {
 	}
 }
 
+func TestS3KMSString(t *testing.T) {
+	const Domain = "SSE-KMS"
+	if domain := S3KMS.String(); domain != Domain {
+		t.Errorf("S3KMS's string method returns wrong domain: got '%s' - want '%s'", domain, Domain)
+	}
+}
+
+func TestS3UnsealObjectKeyWithContext(t *testing.T) {
+	kms := NewKMS([32]byte{})
+	bucket, object := "bucket", "object"
+	kmsContext := Context{"project": "minio", bucket: path.Join(bucket, object)}
+
+	key, sealedDataKey, err := kms.GenerateKey("my-key", kmsContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectKey := GenerateKey(key, rand.Reader)
+	sealedKey := objectKey.Seal(key, GenerateIV(rand.Reader), S3.String(), bucket, object)
+	metadata := S3KMS.CreateMetadata(nil, "my-key", sealedDataKey, sealedKey, Context{"project": "minio"})
+
+	unsealedKey, err := S3.UnsealObjectKey(kms, metadata, bucket, object)
+	if err != nil {
+		t.Fatalf("Failed to unseal SSE-KMS object key: %v", err)
+	}
+	if unsealedKey != objectKey {
+		t.Fatal("Unsealed SSE-KMS object key does not match the object key")
+	}
+
+	// The object key can only be unsealed with the encryption context it was sealed with.
+	metadata = S3KMS.CreateMetadata(metadata, "my-key", sealedDataKey, sealedKey, Context{"project": "other"})
+	if _, err = S3.UnsealObjectKey(kms, metadata, bucket, object); err == nil {
+		t.Fatal("Unsealing SSE-KMS object key with a different encryption context should fail")
+	}
+}
+
 var ssecUnsealObjectKeyTests = []struct {
 	Headers        http.Header
 	Bucket, Object string
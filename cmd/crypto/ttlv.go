@@ -0,0 +1,201 @@
+// MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// maxTTLVSize is the max. size of a KMIP message accepted by readTTLV.
+const maxTTLVSize = 1 << 20
+
+var errInvalidTTLV = errors.New("crypto: invalid KMIP TTLV encoding")
+
+// ttlv is a KMIP tag-type-length-value item. The type of
+// the value depends on the item type:
+//   - Structure:                 []ttlv
+//   - Integer / Interval:        int32 / uint32
+//   - Long Integer:              int64
+//   - Enumeration:               uint32
+//   - Boolean:                   bool
+//   - Text String:               string
+//   - Byte String / Big Integer: []byte
+//   - Date-Time:                 time.Time
+type ttlv struct {
+	Tag   uint32
+	Type  byte
+	Value interface{}
+}
+
+func kmipStruct(tag uint32, items ...ttlv) ttlv {
+	return ttlv{Tag: tag, Type: kmipTypeStructure, Value: items}
+}
+func kmipInt(tag uint32, v int32) ttlv { return ttlv{Tag: tag, Type: kmipTypeInteger, Value: v} }
+func kmipEnum(tag uint32, v uint32) ttlv {
+	return ttlv{Tag: tag, Type: kmipTypeEnumeration, Value: v}
+}
+func kmipText(tag uint32, s string) ttlv { return ttlv{Tag: tag, Type: kmipTypeTextString, Value: s} }
+func kmipBytes(tag uint32, b []byte) ttlv {
+	return ttlv{Tag: tag, Type: kmipTypeByteString, Value: b}
+}
+
+// Child returns the first item of the structure t with the given tag.
+func (t ttlv) Child(tag uint32) (ttlv, bool) {
+	items, _ := t.Value.([]ttlv)
+	for _, item := range items {
+		if item.Tag == tag {
+			return item, true
+		}
+	}
+	return ttlv{}, false
+}
+
+// Bytes returns the value of the byte string with the given tag
+// of the structure t.
+func (t ttlv) Bytes(tag uint32) ([]byte, bool) {
+	item, ok := t.Child(tag)
+	if !ok {
+		return nil, false
+	}
+	b, ok := item.Value.([]byte)
+	return b, ok
+}
+
+// Marshal returns the TTLV encoding of t.
+func (t ttlv) Marshal() []byte { return t.appendTo(nil) }
+
+func (t ttlv) appendTo(b []byte) []byte {
+	var value []byte
+	switch v := t.Value.(type) {
+	case []ttlv:
+		for _, item := range v {
+			value = item.appendTo(value)
+		}
+	case int32:
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(v))
+	case uint32:
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, v)
+	case int64:
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v))
+	case bool:
+		value = make([]byte, 8)
+		if v {
+			value[7] = 1
+		}
+	case string:
+		value = []byte(v)
+	case []byte:
+		value = v
+	case time.Time:
+		value = make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v.Unix()))
+	}
+
+	var header [8]byte
+	header[0], header[1], header[2], header[3] = byte(t.Tag>>16), byte(t.Tag>>8), byte(t.Tag), t.Type
+	binary.BigEndian.PutUint32(header[4:], uint32(len(value)))
+	b = append(b, header[:]...)
+	b = append(b, value...)
+	if n := len(value) % 8; n != 0 {
+		b = append(b, make([]byte, 8-n)...)
+	}
+	return b
+}
+
+// readTTLV reads one TTLV item, usually a KMIP message, from r.
+func readTTLV(r io.Reader) (ttlv, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return ttlv{}, err
+	}
+	length := binary.BigEndian.Uint32(header[4:])
+	if length > maxTTLVSize {
+		return ttlv{}, errInvalidTTLV
+	}
+	message := make([]byte, 8+paddedTTLVLength(length))
+	copy(message, header[:])
+	if _, err := io.ReadFull(r, message[8:]); err != nil {
+		return ttlv{}, err
+	}
+	t, _, err := parseTTLV(message)
+	return t, err
+}
+
+func paddedTTLVLength(length uint32) int { return int((length + 7) &^ 7) }
+
+// parseTTLV decodes the first TTLV item of b and returns
+// the item and the number of bytes it occupies in b.
+func parseTTLV(b []byte) (t ttlv, n int, err error) {
+	if len(b) < 8 {
+		return t, 0, errInvalidTTLV
+	}
+	t.Tag = uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	t.Type = b[3]
+	length := binary.BigEndian.Uint32(b[4:8])
+	if length > maxTTLVSize || 8+paddedTTLVLength(length) > len(b) {
+		return t, 0, errInvalidTTLV
+	}
+	n = 8 + paddedTTLVLength(length)
+	value := b[8 : 8+length]
+
+	switch t.Type {
+	case kmipTypeStructure:
+		var items []ttlv
+		for len(value) > 0 {
+			item, m, err := parseTTLV(value)
+			if err != nil {
+				return t, 0, err
+			}
+			items = append(items, item)
+			value = value[m:]
+		}
+		t.Value = items
+	case kmipTypeInteger, kmipTypeEnumeration, kmipTypeInterval:
+		if length != 4 {
+			return t, 0, errInvalidTTLV
+		}
+		if t.Type == kmipTypeInteger {
+			t.Value = int32(binary.BigEndian.Uint32(value))
+		} else {
+			t.Value = binary.BigEndian.Uint32(value)
+		}
+	case kmipTypeLongInteger, kmipTypeBoolean, kmipTypeDateTime:
+		if length != 8 {
+			return t, 0, errInvalidTTLV
+		}
+		v := binary.BigEndian.Uint64(value)
+		switch t.Type {
+		case kmipTypeLongInteger:
+			t.Value = int64(v)
+		case kmipTypeBoolean:
+			t.Value = v != 0
+		default:
+			t.Value = time.Unix(int64(v), 0).UTC()
+		}
+	case kmipTypeTextString:
+		t.Value = string(value)
+	case kmipTypeByteString, kmipTypeBigInteger:
+		t.Value = append([]byte{}, value...)
+	default:
+		return t, 0, errInvalidTTLV
+	}
+	return t, n, nil
+}
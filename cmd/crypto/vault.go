@@ -28,6 +28,8 @@ import (
 var (
 	//ErrKMSAuthLogin is raised when there is a failure authenticating to KMS
 	ErrKMSAuthLogin = errors.New("Vault service did not return auth info")
+
+	errMissingUpdatedKey = errors.New("crypto: Vault service did not return the re-wrapped key")
 )
 
 // VaultKey represents vault encryption key-ring.
@@ -250,3 +252,29 @@ func (v *vaultService) UnsealKey(keyID string, sealedKey []byte, ctx Context) (k
 	copy(key[:], []byte(plainKey))
 	return key, nil
 }
+
+// UpdateKey re-wraps the sealedKey with the latest version of the
+// named key referenced by keyID. Therefore it sends the sealedKey
+// to the KMS which decrypts and encrypts it again without revealing
+// the plaintext key.
+//
+// The context must be same context as the one provided while
+// generating the plaintext key / sealedKey.
+func (v *vaultService) UpdateKey(keyID string, sealedKey []byte, ctx Context) (rotatedKey []byte, err error) {
+	var contextStream bytes.Buffer
+	ctx.WriteTo(&contextStream)
+
+	payload := map[string]interface{}{
+		"ciphertext": string(sealedKey),
+		"context":    base64.StdEncoding.EncodeToString(contextStream.Bytes()),
+	}
+	s, err := v.client.Logical().Write(fmt.Sprintf("/transit/rewrap/%s", keyID), payload)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, ok := s.Data["ciphertext"].(string)
+	if !ok {
+		return nil, errMissingUpdatedKey
+	}
+	return []byte(ciphertext), nil
+}
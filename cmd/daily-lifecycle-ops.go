@@ -0,0 +1,214 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+const (
+	// Interval between two lifecycle rounds.
+	lifecycleInterval = 24 * time.Hour
+
+	// User agent of events sent for lifecycle actions.
+	lifecycleUserAgent = "Internal: [ILM-EXPIRY]"
+)
+
+func initDailyLifecycle() {
+	go startDailyLifecycle()
+}
+
+// Apply the lifecycle rules of all buckets on a daily basis
+func startDailyLifecycle() {
+	var lastLifecycleTime time.Time
+	var objAPI ObjectLayer
+
+	reqInfo := &logger.ReqInfo{API: "DailyLifecycle"}
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	// Wait until the object API is ready
+	for {
+		objAPI = newObjectLayerFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	// Perform a lifecycle round each day
+	for {
+		if time.Since(lastLifecycleTime) < lifecycleInterval {
+			time.Sleep(time.Hour)
+			continue
+		}
+
+		err := lifecycleRound(ctx, objAPI)
+		if err != nil {
+			switch err.(type) {
+			// Unable to hold a lock means there is another
+			// instance doing the lifecycle round
+			case OperationTimedOut:
+				lastLifecycleTime = time.Now()
+			default:
+				logger.LogIf(ctx, err)
+				time.Sleep(time.Minute)
+				continue
+			}
+		} else {
+			lastLifecycleTime = time.Now()
+		}
+	}
+}
+
+// lifecycleRound - applies the lifecycle rules of all buckets to the
+// objects and incomplete multipart uploads selected by their prefixes.
+func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
+	zeroDuration := time.Millisecond
+	zeroDynamicTimeout := newDynamicTimeout(zeroDuration, zeroDuration)
+
+	// General lock so we avoid parallel lifecycle rounds by different instances.
+	lifecycleLock := globalNSMutex.NewNSLock(ctx, "system", "daily-lifecycle-ops")
+	if err := lifecycleLock.GetLock(zeroDynamicTimeout); err != nil {
+		return err
+	}
+	defer lifecycleLock.Unlock()
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		lc, ok := globalLifecycleSys.Get(bucket.Name)
+		if !ok {
+			continue
+		}
+		now := UTCNow()
+
+		// Prefixes of the rules of a bucket do not overlap.
+		for _, rule := range lc.Rules {
+			if rule.Status != "Enabled" {
+				continue
+			}
+			if !rule.AbortIncompleteMultipartUpload.IsEmpty() {
+				lifecycleUploads(ctx, objAPI, lc, bucket.Name, rule.Filter.GetPrefix(), now)
+			}
+			if rule.Expiration.IsEmpty() && rule.Transition.IsEmpty() {
+				continue
+			}
+
+			marker := ""
+			for {
+				res, err := objAPI.ListObjects(ctx, bucket.Name, rule.Filter.GetPrefix(), marker, "", 1000)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					break
+				}
+				for _, obj := range res.Objects {
+					lifecycleObject(ctx, objAPI, lc, bucket.Name, obj.Name, now)
+				}
+				if !res.IsTruncated {
+					break
+				}
+				marker = res.NextMarker
+			}
+		}
+	}
+
+	return nil
+}
+
+// lifecycleObject - applies the lifecycle rules of the bucket to an
+// object, deleting it when expired or transitioning its data to a
+// remote tier when due at the given time.
+func lifecycleObject(ctx context.Context, objAPI ObjectLayer, lc lifecycle.Lifecycle, bucket, object string, now time.Time) {
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return
+	}
+
+	action, storageClass := lc.ComputeAction(object, getObjectTags(objInfo.UserDefined).ToMap(), objInfo.ModTime, now)
+	switch action {
+	case lifecycle.DeleteAction:
+		// Objects are never deleted in WORM mode.
+		if globalWORMEnabled {
+			return
+		}
+		opts := ObjectOptions{}
+		setVersioningOpts(bucket, &opts)
+		deleted, err := objAPI.DeleteObject(ctx, bucket, object, opts)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		lifecycleActionsTotal.WithLabelValues("expire").Inc()
+
+		// Notify object expired event.
+		if deleted.Name == "" {
+			deleted = objInfo
+		}
+		sendEvent(eventArgs{
+			EventName:  event.ObjectRemovedServerDelete,
+			BucketName: bucket,
+			Object:     deleted,
+			Host:       globalMinioHost,
+			UserAgent:  lifecycleUserAgent,
+		})
+	case lifecycle.TransitionAction:
+		if objInfo.TransitionTier != "" {
+			return
+		}
+		opts := ObjectOptions{VersionID: objInfo.VersionID}
+		if _, err = objAPI.TransitionObject(ctx, bucket, object, storageClass, opts); err != nil {
+			if _, ok := err.(PreConditionFailed); !ok {
+				logger.LogIf(ctx, err)
+			}
+			return
+		}
+		lifecycleActionsTotal.WithLabelValues("transition").Inc()
+	}
+}
+
+// lifecycleUploads - aborts the incomplete multipart uploads of all
+// objects with the given prefix, whether the objects exist or not,
+// which are due to be aborted at the given time by the lifecycle rules
+// of the bucket.
+func lifecycleUploads(ctx context.Context, objAPI ObjectLayer, lc lifecycle.Lifecycle, bucket, prefix string, now time.Time) {
+	uploads, err := objAPI.ListAllMultipartUploads(ctx, bucket, prefix)
+	if err != nil {
+		return
+	}
+
+	for _, upload := range uploads {
+		if upload.Initiated.IsZero() || !lc.IsMultipartUploadExpired(upload.Object, upload.Initiated, now) {
+			continue
+		}
+		if err = objAPI.AbortMultipartUpload(ctx, bucket, upload.Object, upload.UploadID); err != nil {
+			if _, ok := err.(InvalidUploadID); !ok {
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+		lifecycleActionsTotal.WithLabelValues("abort_upload").Inc()
+	}
+}
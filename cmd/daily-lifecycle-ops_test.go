@@ -0,0 +1,100 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+// Wrapper for calling lifecycle tests for both XL multiple disks and single node setup.
+func TestLifecycleObject(t *testing.T) {
+	ExecObjectLayerTest(t, testLifecycleObject)
+}
+
+// Unit test for expiring objects and aborting incomplete multipart
+// uploads by lifecycle rules.
+func testLifecycleObject(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "bucket"
+
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration>
+	<Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>30</Days></Expiration></Rule>
+	<Rule><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule>
+	</LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	for _, object := range []string{"logs/object", "data/object"} {
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+			4, "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "uploads/object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// Uploads are aborted by the prefix of their rule, the object
+	// of the upload does not exist.
+	lifecycleRules := func(now time.Time) {
+		lifecycleUploads(ctx, obj, *lc, bucket, "uploads/", now)
+		for _, object := range []string{"logs/object", "data/object"} {
+			lifecycleObject(ctx, obj, *lc, bucket, object, now)
+		}
+	}
+
+	// Nothing is due yet.
+	now := UTCNow()
+	lifecycleRules(now)
+	if _, err = obj.GetObjectInfo(ctx, bucket, "logs/object", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: expected object not to be expired yet, %s", instanceType, err)
+	}
+	result, err := obj.ListMultipartUploads(ctx, bucket, "uploads/object", "", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Uploads) != 1 || result.Uploads[0].UploadID != uploadID {
+		t.Fatalf("%s: expected upload not to be aborted yet, got %v", instanceType, result.Uploads)
+	}
+
+	// Objects past expiration are deleted and uploads are aborted.
+	now = now.Add(31 * 24 * time.Hour)
+	lifecycleRules(now)
+	if _, err = obj.GetObjectInfo(ctx, bucket, "logs/object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected object to be expired, got %v", instanceType, err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "data/object", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: expected object without lifecycle rule to remain, %s", instanceType, err)
+	}
+	if result, err = obj.ListMultipartUploads(ctx, bucket, "uploads/object", "", "", "", 10); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Uploads) != 0 {
+		t.Fatalf("%s: expected upload to be aborted, got %v", instanceType, result.Uploads)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Snapshot of the data usage computed by the crawler.
+	dataUsageObjName = "data-usage.json"
+
+	// Interval at which the crawler checks if the data usage is due.
+	dataUsageCheckInterval = time.Hour
+)
+
+// objectHistogramInterval is an interval of object sizes, start and
+// end are inclusive, an end of -1 means no upper bound.
+type objectHistogramInterval struct {
+	name       string
+	start, end int64
+}
+
+// objectsHistogramIntervals are the intervals of the object sizes
+// histogram reported by the data usage crawler.
+var objectsHistogramIntervals = []objectHistogramInterval{
+	{"LESS_THAN_1024_B", 0, humanize.KiByte - 1},
+	{"BETWEEN_1024_B_AND_1_MB", humanize.KiByte, humanize.MiByte - 1},
+	{"BETWEEN_1_MB_AND_10_MB", humanize.MiByte, humanize.MiByte*10 - 1},
+	{"BETWEEN_10_MB_AND_64_MB", humanize.MiByte * 10, humanize.MiByte*64 - 1},
+	{"BETWEEN_64_MB_AND_128_MB", humanize.MiByte * 64, humanize.MiByte*128 - 1},
+	{"BETWEEN_128_MB_AND_512_MB", humanize.MiByte * 128, humanize.MiByte*512 - 1},
+	{"GREATER_THAN_512_MB", humanize.MiByte * 512, -1},
+}
+
+// objectSizeInterval - returns the name of the histogram interval the
+// given object size falls in.
+func objectSizeInterval(size int64) string {
+	for _, interval := range objectsHistogramIntervals {
+		if size >= interval.start && (interval.end == -1 || size <= interval.end) {
+			return interval.name
+		}
+	}
+	return objectsHistogramIntervals[0].name
+}
+
+// newDataUsageInfo - returns an empty data usage with all the
+// histogram intervals set to zero.
+func newDataUsageInfo() madmin.DataUsageInfo {
+	return madmin.DataUsageInfo{
+		ObjectsSizesHistogram: newObjectsSizesHistogram(),
+		BucketsUsage:          make(map[string]madmin.BucketUsageInfo),
+	}
+}
+
+func newObjectsSizesHistogram() map[string]uint64 {
+	histogram := make(map[string]uint64, len(objectsHistogramIntervals))
+	for _, interval := range objectsHistogramIntervals {
+		histogram[interval.name] = 0
+	}
+	return histogram
+}
+
+// addBucketToDataUsage - accounts for a bucket without any objects.
+func addBucketToDataUsage(info *madmin.DataUsageInfo, bucket string) {
+	if _, ok := info.BucketsUsage[bucket]; ok {
+		return
+	}
+	info.BucketsCount++
+	info.BucketsUsage[bucket] = madmin.BucketUsageInfo{
+		ObjectsSizesHistogram: newObjectsSizesHistogram(),
+	}
+}
+
+// addObjectToDataUsage - accounts for an object of the given size.
+func addObjectToDataUsage(info *madmin.DataUsageInfo, bucket string, size int64) {
+	addBucketToDataUsage(info, bucket)
+
+	interval := objectSizeInterval(size)
+	info.ObjectsCount++
+	info.ObjectsTotalSize += uint64(size)
+	info.ObjectsSizesHistogram[interval]++
+
+	bucketUsage := info.BucketsUsage[bucket]
+	bucketUsage.ObjectsCount++
+	bucketUsage.Size += uint64(size)
+	bucketUsage.ObjectsSizesHistogram[interval]++
+	info.BucketsUsage[bucket] = bucketUsage
+}
+
+// mergeDataUsageInfo - adds the data usage of src to dst, used to
+// combine the data usage of the erasure sets, which all have the
+// same buckets but distinct objects.
+func mergeDataUsageInfo(dst *madmin.DataUsageInfo, src madmin.DataUsageInfo) {
+	dst.ObjectsCount += src.ObjectsCount
+	dst.ObjectsTotalSize += src.ObjectsTotalSize
+	for interval, count := range src.ObjectsSizesHistogram {
+		dst.ObjectsSizesHistogram[interval] += count
+	}
+	for bucket, usage := range src.BucketsUsage {
+		addBucketToDataUsage(dst, bucket)
+		bucketUsage := dst.BucketsUsage[bucket]
+		bucketUsage.ObjectsCount += usage.ObjectsCount
+		bucketUsage.Size += usage.Size
+		for interval, count := range usage.ObjectsSizesHistogram {
+			bucketUsage.ObjectsSizesHistogram[interval] += count
+		}
+		dst.BucketsUsage[bucket] = bucketUsage
+	}
+}
+
+// waitForLowHTTPReq - the crawler runs at a low priority, it waits
+// for in-progress S3 requests to complete for at most a second before
+// looking at the next object.
+func waitForLowHTTPReq() {
+	if globalHTTPServer == nil {
+		return
+	}
+	waitCount := 10
+	for globalHTTPServer.GetRequestCount() > 2 && waitCount > 0 {
+		waitCount--
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func storeDataUsageInBackend(ctx context.Context, objAPI ObjectLayer, dataUsageInfo madmin.DataUsageInfo) error {
+	data, err := json.Marshal(dataUsageInfo)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, dataUsageObjName), data)
+}
+
+// loadDataUsageFromBackend - loads the last data usage computed by the
+// crawler, the data usage is empty if it was never computed.
+func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, dataUsageObjName))
+	if err != nil {
+		if err == errConfigNotFound {
+			return newDataUsageInfo(), nil
+		}
+		return madmin.DataUsageInfo{}, err
+	}
+
+	dataUsageInfo := newDataUsageInfo()
+	if err = json.Unmarshal(data, &dataUsageInfo); err != nil {
+		return madmin.DataUsageInfo{}, err
+	}
+	return dataUsageInfo, nil
+}
+
+// initDataUsageStats creates a go-routine which periodically
+// computes the data usage of all buckets.
+func initDataUsageStats() {
+	go runDataUsageInfoUpdateRoutine()
+}
+
+// Compute the data usage once every globalDataUsageCrawlInterval,
+// the time of the last computation is shared by all instances.
+func runDataUsageInfoUpdateRoutine() {
+	var objAPI ObjectLayer
+
+	reqInfo := &logger.ReqInfo{API: "DataUsageCrawler"}
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	// Wait until the object layer is ready
+	for {
+		objAPI = newObjectLayerFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	ticker := time.NewTicker(dataUsageCheckInterval)
+	defer ticker.Stop()
+	for {
+		if err := runDataUsageInfo(ctx, objAPI, GlobalServiceDoneCh); err != nil {
+			// Unable to hold the lock means that another
+			// instance is computing the data usage.
+			if _, ok := err.(OperationTimedOut); !ok {
+				logger.LogIf(ctx, err)
+			}
+		}
+
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDataUsageInfo - computes and stores the data usage if the last
+// computation is older than globalDataUsageCrawlInterval.
+func runDataUsageInfo(ctx context.Context, objAPI ObjectLayer, endCh <-chan struct{}) error {
+	zeroDuration := time.Millisecond
+	zeroDynamicTimeout := newDynamicTimeout(zeroDuration, zeroDuration)
+
+	// General lock so we avoid parallel crawling by different instances.
+	crawlLock := globalNSMutex.NewNSLock(ctx, "system", "data-usage-crawler")
+	if err := crawlLock.GetLock(zeroDynamicTimeout); err != nil {
+		return err
+	}
+	defer crawlLock.Unlock()
+
+	lastDataUsage, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if time.Since(lastDataUsage.LastUpdate) < globalDataUsageCrawlInterval {
+		return nil
+	}
+
+	dataUsageInfo := objAPI.CrawlAndGetDataUsage(ctx, endCh)
+	select {
+	case <-endCh:
+		// The crawl was interrupted, its result is incomplete.
+		return nil
+	default:
+	}
+
+	dataUsageInfo.LastUpdate = UTCNow()
+	return storeDataUsageInBackend(ctx, objAPI, dataUsageInfo)
+}
@@ -0,0 +1,129 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestObjectSizeInterval(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		expected string
+	}{
+		{0, "LESS_THAN_1024_B"},
+		{humanize.KiByte - 1, "LESS_THAN_1024_B"},
+		{humanize.KiByte, "BETWEEN_1024_B_AND_1_MB"},
+		{humanize.MiByte, "BETWEEN_1_MB_AND_10_MB"},
+		{humanize.MiByte * 64, "BETWEEN_64_MB_AND_128_MB"},
+		{humanize.MiByte*512 - 1, "BETWEEN_128_MB_AND_512_MB"},
+		{humanize.GiByte * 5, "GREATER_THAN_512_MB"},
+	}
+
+	for i, testCase := range testCases {
+		if interval := objectSizeInterval(testCase.size); interval != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, interval)
+		}
+	}
+}
+
+// Wrapper for calling data usage tests for both XL multiple disks and single node setup.
+func TestDataUsage(t *testing.T) {
+	ExecObjectLayerTest(t, testDataUsage)
+}
+
+// Unit test for crawling the data usage of all buckets and storing it.
+func testDataUsage(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	objects := map[string][]int64{
+		"bucket1": {4, 4, humanize.KiByte * 2},
+		"bucket2": {},
+	}
+	for bucket, sizes := range objects {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		for i, size := range sizes {
+			object := "dir/object" + strconv.Itoa(i)
+			data := bytes.Repeat([]byte("a"), int(size))
+			if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data),
+				size, "", ""), ObjectOptions{}); err != nil {
+				t.Fatalf("%s: %s", instanceType, err)
+			}
+		}
+	}
+
+	dataUsageInfo := obj.CrawlAndGetDataUsage(ctx, nil)
+	if dataUsageInfo.BucketsCount != 2 {
+		t.Fatalf("%s: expected 2 buckets, got %d", instanceType, dataUsageInfo.BucketsCount)
+	}
+	if dataUsageInfo.ObjectsCount != 3 || dataUsageInfo.ObjectsTotalSize != 2056 {
+		t.Fatalf("%s: expected 3 objects of 2056 bytes, got %d objects of %d bytes", instanceType,
+			dataUsageInfo.ObjectsCount, dataUsageInfo.ObjectsTotalSize)
+	}
+	if dataUsageInfo.ObjectsSizesHistogram["LESS_THAN_1024_B"] != 2 ||
+		dataUsageInfo.ObjectsSizesHistogram["BETWEEN_1024_B_AND_1_MB"] != 1 {
+		t.Fatalf("%s: unexpected objects sizes histogram %v", instanceType, dataUsageInfo.ObjectsSizesHistogram)
+	}
+	bucketUsage := dataUsageInfo.BucketsUsage["bucket1"]
+	if bucketUsage.ObjectsCount != 3 || bucketUsage.Size != 2056 {
+		t.Fatalf("%s: unexpected bucket usage %v", instanceType, bucketUsage)
+	}
+	if bucketUsage = dataUsageInfo.BucketsUsage["bucket2"]; bucketUsage.ObjectsCount != 0 || bucketUsage.Size != 0 {
+		t.Fatalf("%s: unexpected empty bucket usage %v", instanceType, bucketUsage)
+	}
+
+	// Nothing is stored before the crawler runs.
+	stored, err := loadDataUsageFromBackend(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !stored.LastUpdate.IsZero() {
+		t.Fatalf("%s: expected no data usage to be stored, got %v", instanceType, stored)
+	}
+
+	if err = runDataUsageInfo(ctx, obj, nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if stored, err = loadDataUsageFromBackend(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if stored.LastUpdate.IsZero() || stored.ObjectsCount != 3 || stored.BucketsUsage["bucket1"].Size != 2056 {
+		t.Fatalf("%s: unexpected stored data usage %v", instanceType, stored)
+	}
+
+	// The data usage is not computed again until it is due.
+	if _, err = obj.PutObject(ctx, "bucket2", "object", mustGetPutObjReader(t, bytes.NewBufferString("data"),
+		4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err = runDataUsageInfo(ctx, obj, nil); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if stored, err = loadDataUsageFromBackend(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if stored.ObjectsCount != 3 {
+		t.Fatalf("%s: expected data usage not to be computed again, got %d objects", instanceType, stored.ObjectsCount)
+	}
+}
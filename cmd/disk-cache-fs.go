@@ -224,7 +224,7 @@ func (cfs *cacheFSObjects) purge() {
 							object.AccTime.After(expiry) {
 							continue
 						}
-						if err = cfs.DeleteObject(ctx, bucket.Name, object.Name); err != nil {
+						if _, err = cfs.DeleteObject(ctx, bucket.Name, object.Name, ObjectOptions{}); err != nil {
 							logger.LogIf(ctx, err)
 							continue
 						}
@@ -288,7 +288,8 @@ func (cfs *cacheFSObjects) Get(ctx context.Context, bucket, object string, start
 
 // Deletes the cached object
 func (cfs *cacheFSObjects) Delete(ctx context.Context, bucket, object string) (err error) {
-	return cfs.DeleteObject(ctx, bucket, object)
+	_, err = cfs.DeleteObject(ctx, bucket, object, ObjectOptions{})
+	return err
 }
 
 // convenience function to check if object is cached on this cacheFSObjects
@@ -61,8 +61,8 @@ type cacheObjects struct {
 	GetObjectFn               func(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error)
 	GetObjectInfoFn           func(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	PutObjectFn               func(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
-	DeleteObjectFn            func(ctx context.Context, bucket, object string) error
-	DeleteObjectsFn           func(ctx context.Context, bucket string, objects []string) ([]error, error)
+	DeleteObjectFn            func(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	DeleteObjectsFn           func(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error)
 	ListObjectsFn             func(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result ListObjectsInfo, err error)
 	ListObjectsV2Fn           func(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error)
 	ListBucketsFn             func(ctx context.Context) (buckets []BucketInfo, err error)
@@ -94,8 +94,8 @@ type CacheObjectLayer interface {
 	GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error)
 	GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
-	DeleteObject(ctx context.Context, bucket, object string) error
-	DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error)
+	DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error)
 
 	// Multipart operations.
 	NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error)
@@ -185,7 +185,8 @@ func (c cacheObjects) getMetadata(objInfo ObjectInfo) map[string]string {
 }
 
 func (c cacheObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	if c.isCacheExclude(bucket, object) {
+	// Only the latest version of an object is ever cached.
+	if c.isCacheExclude(bucket, object) || opts.VersionID != "" {
 		return c.GetObjectNInfoFn(ctx, bucket, object, rs, h, lockType, opts)
 	}
 
@@ -269,7 +270,7 @@ func (c cacheObjects) GetObject(ctx context.Context, bucket, object string, star
 	GetObjectFn := c.GetObjectFn
 	GetObjectInfoFn := c.GetObjectInfoFn
 
-	if c.isCacheExclude(bucket, object) {
+	if c.isCacheExclude(bucket, object) || opts.VersionID != "" {
 		return GetObjectFn(ctx, bucket, object, startOffset, length, writer, etag, opts)
 	}
 	// fetch cacheFSObjects if object is currently cached or nearest available cache drive
@@ -337,7 +338,7 @@ func (c cacheObjects) GetObject(ctx context.Context, bucket, object string, star
 // Returns ObjectInfo from cache if available.
 func (c cacheObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
 	getObjectInfoFn := c.GetObjectInfoFn
-	if c.isCacheExclude(bucket, object) {
+	if c.isCacheExclude(bucket, object) || opts.VersionID != "" {
 		return getObjectInfoFn(ctx, bucket, object, opts)
 	}
 	// fetch cacheFSObjects if object is currently cached or nearest available cache drive
@@ -617,8 +618,8 @@ func (c cacheObjects) GetBucketInfo(ctx context.Context, bucket string) (bucketI
 }
 
 // Delete Object deletes from cache as well if backend operation succeeds
-func (c cacheObjects) DeleteObject(ctx context.Context, bucket, object string) (err error) {
-	if err = c.DeleteObjectFn(ctx, bucket, object); err != nil {
+func (c cacheObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if objInfo, err = c.DeleteObjectFn(ctx, bucket, object, opts); err != nil {
 		return
 	}
 	if c.isCacheExclude(bucket, object) {
@@ -626,15 +627,15 @@ func (c cacheObjects) DeleteObject(ctx context.Context, bucket, object string) (
 	}
 	dcache, cerr := c.cache.getCachedFSLoc(ctx, bucket, object)
 	if cerr == nil {
-		_ = dcache.DeleteObject(ctx, bucket, object)
+		_, _ = dcache.DeleteObject(ctx, bucket, object, ObjectOptions{})
 	}
 	return
 }
 
-func (c cacheObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (c cacheObjects) DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error) {
 	errs := make([]error, len(objects))
 	for idx, object := range objects {
-		errs[idx] = c.DeleteObject(ctx, bucket, object)
+		_, errs[idx] = c.DeleteObject(ctx, bucket, object, opts)
 	}
 	return errs, nil
 }
@@ -984,13 +985,13 @@ func newServerCacheObjects(config CacheConfig) (CacheObjectLayer, error) {
 		PutObjectFn: func(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 			return newObjectLayerFn().PutObject(ctx, bucket, object, data, opts)
 		},
-		DeleteObjectFn: func(ctx context.Context, bucket, object string) error {
-			return newObjectLayerFn().DeleteObject(ctx, bucket, object)
+		DeleteObjectFn: func(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+			return newObjectLayerFn().DeleteObject(ctx, bucket, object, opts)
 		},
-		DeleteObjectsFn: func(ctx context.Context, bucket string, objects []string) ([]error, error) {
+		DeleteObjectsFn: func(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error) {
 			errs := make([]error, len(objects))
 			for idx, object := range objects {
-				errs[idx] = newObjectLayerFn().DeleteObject(ctx, bucket, object)
+				_, errs[idx] = newObjectLayerFn().DeleteObject(ctx, bucket, object, opts)
 			}
 			return errs, nil
 		},
@@ -77,8 +77,10 @@ const (
 
 // hasServerSideEncryptionHeader returns true if the given HTTP header
 // contains server-side-encryption.
+// SSE-KMS requests are passed through to the backend by the S3 gateway.
 func hasServerSideEncryptionHeader(header http.Header) bool {
-	return crypto.S3.IsRequested(header) || crypto.SSEC.IsRequested(header)
+	return crypto.S3.IsRequested(header) || crypto.SSEC.IsRequested(header) ||
+		(!globalIsGateway && crypto.S3KMS.IsRequested(header))
 }
 
 // isEncryptedMultipart returns true if the current object is
@@ -130,8 +132,10 @@ func ParseSSECustomerHeader(header http.Header) (key []byte, err error) {
 	return k[:], err
 }
 
-// This function rotates old to new key.
-func rotateKey(oldKey []byte, newKey []byte, bucket, object string, metadata map[string]string) error {
+// This function rotates old to new key. SSE-S3 and SSE-KMS objects are
+// sealed again with the KMS key-ID and encryption context of the SSE-KMS
+// headers, if present, or with the master key of the server otherwise.
+func rotateKey(h http.Header, oldKey []byte, newKey []byte, bucket, object string, metadata map[string]string) error {
 	switch {
 	default:
 		return errObjectTampered
@@ -166,7 +170,11 @@ func rotateKey(oldKey []byte, newKey []byte, bucket, object string, metadata map
 		if err != nil {
 			return err
 		}
-		oldKey, err := GlobalKMS.UnsealKey(keyID, kmsKey, crypto.Context{bucket: path.Join(bucket, object)})
+		oldContext, err := objectKMSContext(bucket, object, metadata)
+		if err != nil {
+			return err
+		}
+		oldKey, err := GlobalKMS.UnsealKey(keyID, kmsKey, oldContext)
 		if err != nil {
 			return err
 		}
@@ -175,30 +183,94 @@ func rotateKey(oldKey []byte, newKey []byte, bucket, object string, metadata map
 			return err
 		}
 
-		newKey, encKey, err := GlobalKMS.GenerateKey(globalKMSKeyID, crypto.Context{bucket: path.Join(bucket, object)})
+		newKeyID, newContext, err := parseSSEKMSRequest(h)
+		if err != nil {
+			return err
+		}
+		newKey, encKey, err := GlobalKMS.GenerateKey(newKeyID, newKMSContext(bucket, object, newContext))
 		if err != nil {
 			return err
 		}
 		sealedKey = objectKey.Seal(newKey, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
-		crypto.S3.CreateMetadata(metadata, globalKMSKeyID, encKey, sealedKey)
+		if crypto.S3KMS.IsRequested(h) {
+			crypto.S3KMS.CreateMetadata(metadata, newKeyID, encKey, sealedKey, newContext)
+		} else {
+			delete(metadata, crypto.S3KMSContext)
+			crypto.S3.CreateMetadata(metadata, newKeyID, encKey, sealedKey)
+		}
 		return nil
 	}
 }
 
-func newEncryptMetadata(key []byte, bucket, object string, metadata map[string]string, sseS3 bool) ([]byte, error) {
+// objectKMSContext - returns the KMS encryption context an SSE-S3 or SSE-KMS
+// object was sealed with.
+func objectKMSContext(bucket, object string, metadata map[string]string) (crypto.Context, error) {
+	kmsCtx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return newKMSContext(bucket, object, kmsCtx), nil
+}
+
+// newKMSContext - returns the KMS encryption context of an object,
+// the client provided encryption context of SSE-KMS bound to the object.
+func newKMSContext(bucket, object string, clientCtx crypto.Context) crypto.Context {
+	kmsCtx := crypto.Context{}
+	for k, v := range clientCtx {
+		kmsCtx[k] = v
+	}
+	kmsCtx[bucket] = path.Join(bucket, object)
+	return kmsCtx
+}
+
+// parseSSEKMSRequest - returns the KMS key-ID and encryption context of
+// the SSE-KMS headers, SSE-S3 and SSE-KMS without key-ID use the
+// master key of the server.
+func parseSSEKMSRequest(h http.Header) (keyID string, clientCtx crypto.Context, err error) {
+	if crypto.S3KMS.IsRequested(h) {
+		if keyID, clientCtx, err = crypto.S3KMS.ParseHTTP(h); err != nil {
+			return "", nil, err
+		}
+	}
+	if keyID == "" {
+		keyID = globalKMSKeyID
+	}
+	return keyID, clientCtx, nil
+}
+
+// setSSEKMSResponseHeaders - sets the SSE-KMS response headers of an
+// SSE-KMS object: the KMS key-ID and the client provided encryption
+// context, if any.
+func setSSEKMSResponseHeaders(h http.Header, metadata map[string]string) {
+	h.Set(crypto.SSEHeader, crypto.SSEAlgorithmKMS)
+	h.Set(crypto.SSEKmsID, metadata[crypto.S3KMSKeyID])
+	if kmsCtx, err := crypto.S3KMS.ParseMetadata(metadata); err == nil && len(kmsCtx) > 0 {
+		h.Set(crypto.SSEKmsContext, metadata[crypto.S3KMSContext])
+	}
+}
+
+func newEncryptMetadata(h http.Header, key []byte, bucket, object string, metadata map[string]string) ([]byte, error) {
 	var sealedKey crypto.SealedKey
-	if sseS3 {
+	if crypto.S3.IsRequested(h) || crypto.S3KMS.IsRequested(h) {
 		if GlobalKMS == nil {
 			return nil, errKMSNotConfigured
 		}
-		key, encKey, err := GlobalKMS.GenerateKey(globalKMSKeyID, crypto.Context{bucket: path.Join(bucket, object)})
+		keyID, clientCtx, err := parseSSEKMSRequest(h)
+		if err != nil {
+			return nil, err
+		}
+		key, encKey, err := GlobalKMS.GenerateKey(keyID, newKMSContext(bucket, object, clientCtx))
 		if err != nil {
 			return nil, err
 		}
 
 		objectKey := crypto.GenerateKey(key, rand.Reader)
 		sealedKey = objectKey.Seal(key, crypto.GenerateIV(rand.Reader), crypto.S3.String(), bucket, object)
-		crypto.S3.CreateMetadata(metadata, globalKMSKeyID, encKey, sealedKey)
+		if crypto.S3KMS.IsRequested(h) {
+			crypto.S3KMS.CreateMetadata(metadata, keyID, encKey, sealedKey, clientCtx)
+		} else {
+			crypto.S3.CreateMetadata(metadata, keyID, encKey, sealedKey)
+		}
 		return objectKey[:], nil
 	}
 	var extKey [32]byte
@@ -209,8 +281,8 @@ func newEncryptMetadata(key []byte, bucket, object string, metadata map[string]s
 	return objectKey[:], nil
 }
 
-func newEncryptReader(content io.Reader, key []byte, bucket, object string, metadata map[string]string, sseS3 bool) (r io.Reader, encKey []byte, err error) {
-	objectEncryptionKey, err := newEncryptMetadata(key, bucket, object, metadata, sseS3)
+func newEncryptReader(h http.Header, content io.Reader, key []byte, bucket, object string, metadata map[string]string) (r io.Reader, encKey []byte, err error) {
+	objectEncryptionKey, err := newEncryptMetadata(h, key, bucket, object, metadata)
 	if err != nil {
 		return nil, encKey, err
 	}
@@ -235,7 +307,7 @@ func setEncryptionMetadata(r *http.Request, bucket, object string, metadata map[
 			return
 		}
 	}
-	_, err = newEncryptMetadata(key, bucket, object, metadata, crypto.S3.IsRequested(r.Header))
+	_, err = newEncryptMetadata(r.Header, key, bucket, object, metadata)
 	return
 }
 
@@ -247,7 +319,7 @@ func EncryptRequest(content io.Reader, r *http.Request, bucket, object string, m
 	var (
 		key []byte
 	)
-	if crypto.S3.IsRequested(r.Header) && crypto.SSEC.IsRequested(r.Header) {
+	if (crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header)) && crypto.SSEC.IsRequested(r.Header) {
 		return nil, objEncKey, crypto.ErrIncompatibleEncryptionMethod
 	}
 	if crypto.SSEC.IsRequested(r.Header) {
@@ -256,7 +328,7 @@ func EncryptRequest(content io.Reader, r *http.Request, bucket, object string, m
 			return nil, objEncKey, err
 		}
 	}
-	return newEncryptReader(content, key, bucket, object, metadata, crypto.S3.IsRequested(r.Header))
+	return newEncryptReader(r.Header, content, key, bucket, object, metadata)
 }
 
 // DecryptCopyRequest decrypts the object with the client provided key. It also removes
@@ -288,7 +360,11 @@ func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]st
 		if err != nil {
 			return nil, err
 		}
-		extKey, err := GlobalKMS.UnsealKey(keyID, kmsKey, crypto.Context{bucket: path.Join(bucket, object)})
+		kmsCtx, err := objectKMSContext(bucket, object, metadata)
+		if err != nil {
+			return nil, err
+		}
+		extKey, err := GlobalKMS.UnsealKey(keyID, kmsKey, kmsCtx)
 		if err != nil {
 			return nil, err
 		}
@@ -798,6 +874,7 @@ func DecryptBlocksRequest(client io.Writer, r *http.Request, bucket, object stri
 		delete(objInfo.UserDefined, crypto.S3SealedKey)
 		delete(objInfo.UserDefined, crypto.S3KMSKeyID)
 		delete(objInfo.UserDefined, crypto.S3KMSSealedKey)
+		delete(objInfo.UserDefined, crypto.S3KMSContext)
 	}
 	if w.copySource {
 		w.customerKeyHeader = r.Header.Get(crypto.SSECopyKey)
@@ -1149,7 +1226,11 @@ func DecryptObjectInfo(info *ObjectInfo, headers http.Header) (encrypted bool, e
 			err = errEncryptedObject
 			return
 		}
-		_, err = info.DecryptedSize()
+		// The size of a compressed object is not the size of
+		// the encrypted data once the object is read.
+		if !info.IsCompressed() {
+			_, err = info.DecryptedSize()
+		}
 
 		if crypto.IsEncrypted(info.UserDefined) && !crypto.IsMultiPart(info.UserDefined) {
 			info.ETag = getDecryptedETag(headers, *info, false)
@@ -1239,10 +1320,16 @@ func putOpts(ctx context.Context, r *http.Request, bucket, object string, metada
 		return
 	}
 	if crypto.S3KMS.IsRequested(r.Header) {
-		keyID, context, err := crypto.S3KMS.ParseHTTP(r.Header)
+		keyID, kmsCtx, err := crypto.S3KMS.ParseHTTP(r.Header)
 		if err != nil {
 			return ObjectOptions{}, err
 		}
+		// A nil context must not be passed as a typed interface,
+		// it would be sent as JSON null context.
+		var context interface{}
+		if kmsCtx != nil {
+			context = kmsCtx
+		}
 		sseKms, err := encrypt.NewSSEKMS(keyID, context)
 		if err != nil {
 			return ObjectOptions{}, err
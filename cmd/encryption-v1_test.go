@@ -40,6 +40,7 @@ var hasServerSideEncryptionHeaderTests = []struct {
 	{headers: map[string]string{crypto.SSECopyAlgorithm + " ": "AES256", " " + crypto.SSECopyKey: "key", crypto.SSECopyKeyMD5 + " ": "md5"}, sseRequest: false}, // 5
 	{headers: map[string]string{crypto.SSECopyAlgorithm: "", crypto.SSECopyKey: "", crypto.SSECopyKeyMD5: ""}, sseRequest: false},                               // 6
 	{headers: map[string]string{crypto.SSEHeader: ""}, sseRequest: true},                                                                                        // 7
+	{headers: map[string]string{crypto.SSEHeader: crypto.SSEAlgorithmKMS}, sseRequest: true},                                                                    // 8
 }
 
 func TestHasServerSideEncryptionHeader(t *testing.T) {
@@ -158,6 +159,70 @@ func TestEncryptRequest(t *testing.T) {
 	}
 }
 
+func TestEncryptRequestSSEKMS(t *testing.T) {
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmKMS)
+	req.Header.Set(crypto.SSEKmsID, "team-key")
+	req.Header.Set(crypto.SSEKmsContext, base64.StdEncoding.EncodeToString([]byte(`{"team":"data"}`)))
+
+	metadata := map[string]string{}
+	_, objectKey, err := EncryptRequest(bytes.NewReader(make([]byte, 64)), req, "bucket", "object", metadata)
+	if err != nil {
+		t.Fatalf("Failed to encrypt request: %v", err)
+	}
+	if !crypto.S3KMS.IsEncrypted(metadata) || !crypto.S3.IsEncrypted(metadata) {
+		t.Fatalf("Object must be SSE-KMS encrypted: %v", metadata)
+	}
+	if keyID := metadata[crypto.S3KMSKeyID]; keyID != "team-key" {
+		t.Fatalf("Expected KMS key ID %q, got %q", "team-key", keyID)
+	}
+	kmsCtx, err := crypto.S3KMS.ParseMetadata(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kmsCtx) != 1 || kmsCtx["team"] != "data" {
+		t.Fatalf("Unexpected encryption context: %v", kmsCtx)
+	}
+
+	key, err := decryptObjectInfo(nil, "bucket", "object", metadata)
+	if err != nil {
+		t.Fatalf("Failed to decrypt object key: %v", err)
+	}
+	if !bytes.Equal(key, objectKey) {
+		t.Fatal("Decrypted object key does not match the encryption key")
+	}
+	// The object key is bound to the bucket and object name.
+	if _, err = decryptObjectInfo(nil, "bucket", "other-object", metadata); err == nil {
+		t.Fatal("Object key must not be decrypted for another object")
+	}
+
+	header := http.Header{}
+	setSSEKMSResponseHeaders(header, metadata)
+	if header.Get(crypto.SSEHeader) != crypto.SSEAlgorithmKMS || header.Get(crypto.SSEKmsID) != "team-key" ||
+		header.Get(crypto.SSEKmsContext) != req.Header.Get(crypto.SSEKmsContext) {
+		t.Fatalf("Unexpected SSE-KMS response headers: %v", header)
+	}
+
+	// SSE-KMS without key ID uses the master key of the server.
+	req.Header.Del(crypto.SSEKmsID)
+	req.Header.Del(crypto.SSEKmsContext)
+	metadata = map[string]string{}
+	if _, _, err = EncryptRequest(bytes.NewReader(make([]byte, 64)), req, "bucket", "object", metadata); err != nil {
+		t.Fatalf("Failed to encrypt request: %v", err)
+	}
+	if keyID := metadata[crypto.S3KMSKeyID]; keyID != "my-key" {
+		t.Fatalf("Expected KMS key ID %q, got %q", "my-key", keyID)
+	}
+	header = http.Header{}
+	setSSEKMSResponseHeaders(header, metadata)
+	if _, ok := header[crypto.SSEKmsContext]; ok {
+		t.Fatalf("Unexpected SSE-KMS encryption context header: %v", header)
+	}
+}
+
 var decryptRequestTests = []struct {
 	bucket, object string
 	header         map[string]string
@@ -76,6 +76,76 @@ const (
 	EnvVaultNamespace = "MINIO_SSE_VAULT_NAMESPACE"
 )
 
+const (
+	// EnvKMIPEndpoint is the environment variable used to specify
+	// the host:port of the KMIP server.
+	EnvKMIPEndpoint = "MINIO_SSE_KMIP_ENDPOINT"
+
+	// EnvKMIPKeyName is the environment variable used to specify
+	// the name of the KMIP key used for SSE-S3. In the S3 context
+	// it's referred as customer master key ID (CMK-ID).
+	EnvKMIPKeyName = "MINIO_SSE_KMIP_KEY_NAME"
+
+	// EnvKMIPClientCert is the environment variable used to specify
+	// the PEM-encoded client certificate MinIO uses to authenticate
+	// to the KMIP server.
+	EnvKMIPClientCert = "MINIO_SSE_KMIP_CLIENT_CERT"
+
+	// EnvKMIPClientKey is the environment variable used to specify
+	// the PEM-encoded private key of the KMIP client certificate.
+	EnvKMIPClientKey = "MINIO_SSE_KMIP_CLIENT_KEY"
+
+	// EnvKMIPCAPath is the environment variable used to specify the
+	// path to a PEM-encoded CA cert file or a directory of them used
+	// to verify the KMIP server certificate.
+	EnvKMIPCAPath = "MINIO_SSE_KMIP_CAPATH"
+)
+
+const (
+	// EnvKeyStorePath is the environment variable used to specify
+	// the path of the key store file.
+	EnvKeyStorePath = "MINIO_SSE_KEYSTORE_PATH"
+
+	// EnvKeyStoreKeys is the environment variable used to specify
+	// a comma-separated list of key names. The first key is used
+	// for SSE-S3, missing keys are created.
+	EnvKeyStoreKeys = "MINIO_SSE_KEYSTORE_KEYS"
+
+	// EnvKeyStorePassphrase is the environment variable used to
+	// specify the passphrase protecting the key store. The
+	// passphrase is only available through ENV.
+	EnvKeyStorePassphrase = "MINIO_SSE_KEYSTORE_PASSPHRASE"
+)
+
+const (
+	// EnvLDAPServerAddr is the environment variable used to specify
+	// the host:port of the LDAP server. The other LDAP environment
+	// variables are only honored if it is set.
+	EnvLDAPServerAddr = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
+
+	// EnvLDAPServerInsecure is the environment variable used to
+	// connect to the LDAP server without TLS. If present it must be
+	// either "on" or "off".
+	EnvLDAPServerInsecure = "MINIO_IDENTITY_LDAP_SERVER_INSECURE"
+
+	// EnvLDAPTLSSkipVerify is the environment variable used to skip
+	// verification of the LDAP server certificate. If present it must
+	// be either "on" or "off".
+	EnvLDAPTLSSkipVerify = "MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY"
+
+	// EnvLDAPUsernameFormat is the environment variable used to specify
+	// the format of the DN users bind with.
+	EnvLDAPUsernameFormat = "MINIO_IDENTITY_LDAP_USERNAME_FORMAT"
+
+	// EnvLDAPGroupSearchBaseDN is the environment variable used to
+	// specify the base DN of the search for the groups of a user.
+	EnvLDAPGroupSearchBaseDN = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
+
+	// EnvLDAPGroupSearchFilter is the environment variable used to
+	// specify the filter of the search for the groups of a user.
+	EnvLDAPGroupSearchFilter = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER"
+)
+
 // Environment provides functions for accessing environment
 // variables.
 var Environment = environment{}
@@ -131,22 +201,68 @@ func (env environment) LookupKMSConfig(config crypto.KMSConfig) (err error) {
 		return err
 	}
 
+	// Lookup KMIP configuration & overwrite config entry if ENV var is present
+	config.KMIP.Endpoint = env.Get(EnvKMIPEndpoint, config.KMIP.Endpoint)
+	config.KMIP.KeyName = env.Get(EnvKMIPKeyName, config.KMIP.KeyName)
+	config.KMIP.ClientCert = env.Get(EnvKMIPClientCert, config.KMIP.ClientCert)
+	config.KMIP.ClientKey = env.Get(EnvKMIPClientKey, config.KMIP.ClientKey)
+	config.KMIP.CAPath = env.Get(EnvKMIPCAPath, config.KMIP.CAPath)
+	if err = config.KMIP.Verify(); err != nil {
+		return err
+	}
+
+	// Lookup key store configuration & overwrite config entry if ENV var is present
+	config.KeyStore.Path = env.Get(EnvKeyStorePath, config.KeyStore.Path)
+	if keys, ok := env.Lookup(EnvKeyStoreKeys); ok {
+		config.KeyStore.Keys = nil
+		if keys != "" {
+			config.KeyStore.Keys = strings.Split(keys, ",")
+		}
+	}
+	config.KeyStore.Passphrase = env.Get(EnvKeyStorePassphrase, config.KeyStore.Passphrase)
+	if err = config.KeyStore.Verify(); err != nil {
+		return err
+	}
+
 	// Lookup KMS master keys - only available through ENV.
-	if masterKey, ok := env.Lookup(EnvKMSMasterKey); ok {
-		if !config.Vault.IsEmpty() { // Vault and KMS master key provided
-			return errors.New("Ambiguous KMS configuration: vault configuration and a master key are provided at the same time")
+	masterKey, isMasterKeySet := env.Lookup(EnvKMSMasterKey)
+	if isMasterKeySet && !config.Vault.IsEmpty() { // Vault and KMS master key provided
+		return errors.New("Ambiguous KMS configuration: vault configuration and a master key are provided at the same time")
+	}
+	numKMS := 0
+	for _, isSet := range []bool{isMasterKeySet, !config.Vault.IsEmpty(), !config.KMIP.IsEmpty(), !config.KeyStore.IsEmpty()} {
+		if isSet {
+			numKMS++
 		}
+	}
+	if numKMS > 1 {
+		return errors.New("Ambiguous KMS configuration: more than one of vault, KMIP, key store and master key are provided at the same time")
+	}
+
+	switch {
+	case isMasterKeySet:
 		globalKMSKeyID, GlobalKMS, err = parseKMSMasterKey(masterKey)
 		if err != nil {
 			return err
 		}
-	}
-	if !config.Vault.IsEmpty() {
+	case !config.Vault.IsEmpty():
 		GlobalKMS, err = crypto.NewVault(config.Vault)
 		if err != nil {
 			return err
 		}
 		globalKMSKeyID = config.Vault.Key.Name
+	case !config.KMIP.IsEmpty():
+		GlobalKMS, err = crypto.NewKMIP(config.KMIP)
+		if err != nil {
+			return err
+		}
+		globalKMSKeyID = config.KMIP.KeyName
+	case !config.KeyStore.IsEmpty():
+		GlobalKMS, err = crypto.NewKeyStore(config.KeyStore)
+		if err != nil {
+			return err
+		}
+		globalKMSKeyID = config.KeyStore.DefaultKey()
 	}
 
 	autoEncryption, err := ParseBoolFlag(env.Get(EnvAutoEncryption, "off"))
@@ -180,3 +296,35 @@ func parseKMSMasterKey(envArg string) (string, crypto.KMS, error) {
 	}
 	return keyID, crypto.NewKMS(masterKey), nil
 }
+
+// LookupLDAPConfig extracts the LDAP server configuration provided by
+// environment variables. If EnvLDAPServerAddr is set the environment
+// replaces the server settings of the provided configuration, the
+// group to policy mappings are kept. As with the KMS configuration
+// the environment never changes the configuration file.
+func (env environment) LookupLDAPConfig(config ldapServerConfig) (ldapServerConfig, error) {
+	serverAddr, ok := env.Lookup(EnvLDAPServerAddr)
+	if !ok {
+		return config, nil
+	}
+
+	serverInsecure, err := ParseBoolFlag(env.Get(EnvLDAPServerInsecure, "off"))
+	if err != nil {
+		return config, err
+	}
+	tlsSkipVerify, err := ParseBoolFlag(env.Get(EnvLDAPTLSSkipVerify, "off"))
+	if err != nil {
+		return config, err
+	}
+
+	config = ldapServerConfig{
+		ServerAddr:        serverAddr,
+		ServerInsecure:    bool(serverInsecure),
+		TLSSkipVerify:     bool(tlsSkipVerify),
+		UsernameFormat:    env.Get(EnvLDAPUsernameFormat, ""),
+		GroupSearchBaseDN: env.Get(EnvLDAPGroupSearchBaseDN, ""),
+		GroupSearchFilter: env.Get(EnvLDAPGroupSearchFilter, ""),
+		GroupPolicies:     config.GroupPolicies,
+	}
+	return config, config.Validate()
+}
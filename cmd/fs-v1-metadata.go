@@ -116,6 +116,10 @@ type fsMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// parts info for current object - used in encryption.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// version ID of current object, empty for the null version.
+	VersionID string `json:"versionId,omitempty"`
+	// set if current object represents a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
 }
 
 // IsValid - tells if the format is sane by validating the version
@@ -147,8 +151,10 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 	}
 
 	objInfo := ObjectInfo{
-		Bucket: bucket,
-		Name:   object,
+		Bucket:       bucket,
+		Name:         object,
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
 	}
 
 	// We set file info only if its valid.
@@ -277,6 +283,10 @@ func (m *fsMetaV1) ReadFrom(ctx context.Context, lk *lock.LockedFile) (n int64,
 	// obtain metadata.
 	m.Meta = parseFSMetaMap(fsMetaBuf)
 
+	// obtain version information.
+	m.VersionID = gjson.GetBytes(fsMetaBuf, "versionId").String()
+	m.DeleteMarker = gjson.GetBytes(fsMetaBuf, "deleteMarker").Bool()
+
 	// Success.
 	return int64(len(fsMetaBuf)), nil
 }
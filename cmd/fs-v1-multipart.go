@@ -204,6 +204,56 @@ func (fs *FSObjects) ListMultipartUploads(ctx context.Context, bucket, object, k
 	return result, nil
 }
 
+// ListAllMultipartUploads - lists the multipart uploads of all objects of
+// the bucket with the given prefix. Uploads started before the name of
+// the object was saved with them are not listed.
+func (fs *FSObjects) ListAllMultipartUploads(ctx context.Context, bucket, prefix string) (uploads []MultipartInfo, err error) {
+	shaDirs, err := readDir(pathJoin(fs.fsPath, minioMetaMultipartBucket))
+	if err != nil {
+		if err == errFileNotFound {
+			return nil, nil
+		}
+		logger.LogIf(ctx, err)
+		return nil, toObjectErr(err)
+	}
+	for _, shaDir := range shaDirs {
+		uploadIDs, err := readDir(pathJoin(fs.fsPath, minioMetaMultipartBucket, shaDir))
+		if err != nil {
+			continue
+		}
+		for _, uploadID := range uploadIDs {
+			// Uploads being removed concurrently are skipped.
+			metaFilePath := pathJoin(fs.fsPath, minioMetaMultipartBucket, shaDir, uploadID, fs.metaJSONFile)
+			fi, err := fsStatFile(ctx, metaFilePath)
+			if err != nil {
+				continue
+			}
+			fsMetaBuf, err := ioutil.ReadFile(metaFilePath)
+			if err != nil {
+				continue
+			}
+			var fsMeta fsMetaV1
+			if err = json.Unmarshal(fsMetaBuf, &fsMeta); err != nil {
+				continue
+			}
+			uploadBucket, object := getMultipartObject(fsMeta.Meta)
+			if uploadBucket == "" {
+				continue
+			}
+			if uploadBucket != bucket || !hasPrefix(object, prefix) {
+				// All uploads of a directory belong to the same object.
+				break
+			}
+			uploads = append(uploads, MultipartInfo{
+				Object:    object,
+				UploadID:  strings.TrimSuffix(uploadID, slashSeparator),
+				Initiated: fi.ModTime(),
+			})
+		}
+	}
+	return uploads, nil
+}
+
 // NewMultipartUpload - initialize a new multipart upload, returns a
 // unique id. The unique id returned here is of UUID form, for each
 // subsequent request each UUID is unique.
@@ -230,6 +280,10 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = opts.UserDefined
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	fsMeta.Meta[multipartObjectKey] = pathJoin(bucket, object)
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -667,20 +721,29 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+	delete(fsMeta.Meta, multipartObjectKey)
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+
+	// Deny overwriting a version protected by WORM or object lock.
+	err = enforceRetentionForOverwrite(bucket, object, opts, func() (ObjectInfo, error) {
+		return fs.latestVersionInfo(ctx, bucket, object, metaFile)
+	})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// Keep the existing object as a noncurrent version if versioning is configured.
+	if _, err = fs.archiveLatestVersion(ctx, bucket, object, metaFile, opts); err != nil {
+		return oi, err
+	}
+	fsMeta.VersionID = newObjectVersionID(opts)
+
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
 	}
 
-	// Deny if WORM is enabled
-	if globalWORMEnabled {
-		if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == nil {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
-		}
-	}
-
 	err = fsRenameFile(ctx, appendFilePath, pathJoin(fs.fsPath, bucket, object))
 	if err != nil {
 		logger.LogIf(ctx, err)
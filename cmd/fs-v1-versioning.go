@@ -0,0 +1,363 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/lock"
+)
+
+// Noncurrent object versions in the version store hold
+// the object data along with its `fs.json`.
+const fsVersionDataFile = "part.1"
+
+// getVersionDir - returns the directory of a noncurrent object version.
+func (fs *FSObjects) getVersionDir(bucket, object, versionID string) string {
+	return pathJoin(fs.fsPath, minioMetaBucket, versionStorePath(bucket, object, versionID))
+}
+
+// getVersionInfo - reads a noncurrent object version from the version store.
+func (fs *FSObjects) getVersionInfo(ctx context.Context, bucket, object, versionID string) (oi ObjectInfo, err error) {
+	versionDir := fs.getVersionDir(bucket, object, versionID)
+	fsMetaPath := pathJoin(versionDir, fs.metaJSONFile)
+
+	rlk, err := fs.rwPool.Open(fsMetaPath)
+	if err != nil {
+		return oi, err
+	}
+	fsMeta := fsMetaV1{}
+	_, err = fsMeta.ReadFrom(ctx, rlk.LockedFile)
+	fs.rwPool.Close(fsMetaPath)
+	if err != nil {
+		return oi, err
+	}
+
+	fi, err := fsStatFile(ctx, pathJoin(versionDir, fsVersionDataFile))
+	if err != nil {
+		return oi, err
+	}
+
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
+// getObjectVersionInfo - resolves the version of an object requested in
+// opts, returns the path of its data along with the object info. Delete
+// markers are reported as not found unless explicitly requested.
+func (fs *FSObjects) getObjectVersionInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (string, ObjectInfo, error) {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return "", objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if opts.VersionID == "" {
+		if err != nil {
+			return "", objInfo, toObjectErr(err, bucket, object)
+		}
+		if objInfo.DeleteMarker {
+			return "", objInfo, ObjectNotFound{Bucket: bucket, Object: object}
+		}
+		objInfo.IsLatest = true
+		return fsObjPath, objInfo, nil
+	}
+
+	versionID := versionIDFromOpts(opts)
+	if err == nil && objInfo.VersionID == versionID {
+		objInfo.IsLatest = true
+	} else {
+		objInfo, err = fs.getVersionInfo(ctx, bucket, object, versionID)
+		if err != nil {
+			if err == errFileNotFound {
+				return "", objInfo, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+			}
+			return "", objInfo, toObjectErr(err, bucket, object)
+		}
+		fsObjPath = pathJoin(fs.getVersionDir(bucket, object, versionID), fsVersionDataFile)
+	}
+
+	if objInfo.DeleteMarker {
+		return "", objInfo, MethodNotAllowed{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	}
+	return fsObjPath, objInfo, nil
+}
+
+// listObjectVersionsOf - returns all noncurrent versions of an object
+// from the version store, newest version first.
+func (fs *FSObjects) listObjectVersionsOf(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	entries, err := readDir(pathJoin(fs.fsPath, minioMetaBucket, versionsMetaPrefix, bucket, object))
+	if err != nil {
+		if err == errFileNotFound {
+			return nil, nil
+		}
+		return nil, toObjectErr(err, bucket, object)
+	}
+
+	var versions []ObjectInfo
+	for _, entry := range entries {
+		if !hasSuffix(entry, slashSeparator) {
+			continue
+		}
+		// Directories without `fs.json` belong to other objects.
+		objInfo, err := fs.getVersionInfo(ctx, bucket, object, path.Clean(entry))
+		if err != nil {
+			if err == errFileNotFound {
+				continue
+			}
+			return nil, toObjectErr(err, bucket, object)
+		}
+		versions = append(versions, objInfo)
+	}
+	sortObjectVersions(versions)
+	return versions, nil
+}
+
+// deleteStoredVersion - removes a noncurrent version from the version store.
+func (fs *FSObjects) deleteStoredVersion(ctx context.Context, bucket, object, versionID string) error {
+	versionsDir := pathJoin(fs.fsPath, minioMetaBucket, versionsMetaPrefix)
+	versionDir := fs.getVersionDir(bucket, object, versionID)
+	for _, file := range []string{fsVersionDataFile, fs.metaJSONFile} {
+		if err := fsDeleteFile(ctx, versionsDir, pathJoin(versionDir, file)); err != nil && err != errFileNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveLatestVersion - moves the latest version of an object into the
+// version store before it gets replaced by a new version, fsMetaLk holds
+// the `fs.json` of the latest version. Returns false if the latest version
+// is a null version which is to be overwritten instead, which is the case
+// for buckets with versioning suspended.
+func (fs *FSObjects) archiveLatestVersion(ctx context.Context, bucket, object string, fsMetaLk *lock.LockedFile, opts ObjectOptions) (bool, error) {
+	if !opts.isVersioningConfigured() {
+		return false, nil
+	}
+
+	// There is only ever one null version of an object, a new
+	// null version replaces the one in the version store.
+	if !opts.Versioned {
+		if err := fs.deleteStoredVersion(ctx, bucket, object, ""); err != nil {
+			return false, toObjectErr(err, bucket, object)
+		}
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if _, err := fsStatFile(ctx, fsObjPath); err != nil {
+		if err == errFileNotFound {
+			return false, nil
+		}
+		return false, toObjectErr(err, bucket, object)
+	}
+
+	// Objects without `fs.json` are pre-existing null versions.
+	fsMeta := fs.defaultFsJSON(object)
+	if fi, err := fsMetaLk.Stat(); err == nil && fi.Size() > 0 {
+		if _, err = fsMeta.ReadFrom(ctx, fsMetaLk); err != nil {
+			fsMeta = fs.defaultFsJSON(object)
+		}
+	}
+	if fsMeta.VersionID == "" && !opts.Versioned {
+		return false, nil
+	}
+
+	versionDir := fs.getVersionDir(bucket, object, fsMeta.VersionID)
+	if err := fs.writeVersionMeta(ctx, pathJoin(versionDir, fs.metaJSONFile), fsMeta); err != nil {
+		return false, toObjectErr(err, bucket, object)
+	}
+	if err := fsRenameFile(ctx, fsObjPath, pathJoin(versionDir, fsVersionDataFile)); err != nil {
+		return false, toObjectErr(err, bucket, object)
+	}
+	return true, nil
+}
+
+// latestVersionInfo - returns the object info of the latest version of an
+// object, fsMetaLk holds its `fs.json` which may be empty for new objects.
+func (fs *FSObjects) latestVersionInfo(ctx context.Context, bucket, object string, fsMetaLk *lock.LockedFile) (ObjectInfo, error) {
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	fsMeta := fs.defaultFsJSON(object)
+	if fsMetaLk != nil {
+		if lfi, err := fsMetaLk.Stat(); err == nil && lfi.Size() > 0 {
+			if _, err = fsMeta.ReadFrom(ctx, fsMetaLk); err != nil {
+				fsMeta = fs.defaultFsJSON(object)
+			}
+		}
+	}
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
+// writeVersionMeta - saves `fs.json` of an object version at fsMetaPath.
+func (fs *FSObjects) writeVersionMeta(ctx context.Context, fsMetaPath string, fsMeta fsMetaV1) error {
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer wlk.Close()
+
+	_, err = fsMeta.WriteTo(wlk)
+	return err
+}
+
+// promoteLatestVersion - makes the newest noncurrent version of an object
+// its latest version, called after the latest version has been deleted.
+func (fs *FSObjects) promoteLatestVersion(ctx context.Context, bucket, object string) error {
+	versions, err := fs.listObjectVersionsOf(ctx, bucket, object)
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+
+	versionID := versions[0].VersionID
+	versionDir := fs.getVersionDir(bucket, object, versionID)
+	versionMetaPath := pathJoin(versionDir, fs.metaJSONFile)
+
+	rlk, err := fs.rwPool.Open(versionMetaPath)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	fsMeta := fsMetaV1{}
+	_, err = fsMeta.ReadFrom(ctx, rlk.LockedFile)
+	fs.rwPool.Close(versionMetaPath)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	if err = fs.writeVersionMeta(ctx, fsMetaPath, fsMeta); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	if err = fsRenameFile(ctx, pathJoin(versionDir, fsVersionDataFile), pathJoin(fs.fsPath, bucket, object)); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	return toObjectErr(fs.deleteStoredVersion(ctx, bucket, object, versionID), bucket, object)
+}
+
+// putDeleteMarker - replaces the latest version of an object with a
+// delete marker, the replaced version is kept in the version store.
+func (fs *FSObjects) putDeleteMarker(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	// A delete marker can not be created beneath another object.
+	if fs.parentDirIsObject(ctx, bucket, path.Dir(object)) {
+		return ObjectInfo{}, toObjectErr(errFileParentIsFile, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	if _, err = fs.archiveLatestVersion(ctx, bucket, object, wlk, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// Delete markers are empty objects, an unarchived
+	// null version is simply overwritten.
+	fsTmpObjPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, mustGetUUID())
+	if _, err = fsCreateFile(ctx, fsTmpObjPath, bytes.NewReader(nil), nil, 0); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	defer fsRemoveFile(ctx, fsTmpObjPath)
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if err = fsRenameFile(ctx, fsTmpObjPath, fsObjPath); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	fsMeta := newFSMetaV1()
+	fsMeta.Meta = map[string]string{}
+	fsMeta.VersionID = newObjectVersionID(opts)
+	fsMeta.DeleteMarker = true
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	objInfo := fsMeta.ToObjectInfo(bucket, object, fi)
+	objInfo.IsLatest = true
+	return objInfo, nil
+}
+
+// deleteObjectVersion - permanently deletes the version of an object
+// requested in opts, deleting the latest version promotes the newest
+// noncurrent version.
+func (fs *FSObjects) deleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	versionID := versionIDFromOpts(opts)
+
+	objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if err != nil || objInfo.VersionID != versionID {
+		objInfo, err = fs.getVersionInfo(ctx, bucket, object, versionID)
+		if err != nil {
+			if err == errFileNotFound {
+				return objInfo, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+			}
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		if err = enforceRetentionForDeletion(objInfo, opts); err != nil {
+			return objInfo, err
+		}
+		return objInfo, toObjectErr(fs.deleteStoredVersion(ctx, bucket, object, versionID), bucket, object)
+	}
+
+	if err = enforceRetentionForDeletion(objInfo, opts); err != nil {
+		return objInfo, err
+	}
+
+	if err = fs.deleteObject(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	objInfo.IsLatest = true
+	return objInfo, fs.promoteLatestVersion(ctx, bucket, object)
+}
+
+// ListObjectVersions - lists all versions of the objects in a bucket.
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, delimiter, fs); err != nil {
+		return ListObjectVersionsInfo{}, err
+	}
+
+	listFn := func(marker string, maxKeys int) (ListObjectsInfo, error) {
+		return listObjects(ctx, fs, bucket, prefix, marker, delimiter, maxKeys, fs.listPool,
+			fs.listDirFactory(), fs.getObjectInfo, fs.getObjectInfo)
+	}
+	latestFn := func(object string) (ObjectInfo, error) {
+		objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	versionsFn := func(object string) ([]ObjectInfo, error) {
+		return fs.listObjectVersionsOf(ctx, bucket, object)
+	}
+	return listObjectVersions(marker, versionIDMarker, maxKeys, listFn, latestFn, versionsFn)
+}
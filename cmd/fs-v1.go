@@ -37,7 +37,11 @@ import (
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/mimedb"
 	"github.com/minio/minio/pkg/mountinfo"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/sse"
+	"github.com/minio/minio/pkg/versioning"
 )
 
 // Default etag is used for pre-existing objects.
@@ -259,6 +263,46 @@ func (fs *FSObjects) StorageInfo(ctx context.Context) StorageInfo {
 	return storageInfo
 }
 
+// CrawlAndGetDataUsage - returns the data usage of all buckets by
+// listing their objects, stops early when endCh is closed.
+func (fs *FSObjects) CrawlAndGetDataUsage(ctx context.Context, endCh <-chan struct{}) madmin.DataUsageInfo {
+	dataUsageInfo := newDataUsageInfo()
+
+	buckets, err := fs.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return dataUsageInfo
+	}
+
+	for _, bucket := range buckets {
+		addBucketToDataUsage(&dataUsageInfo, bucket.Name)
+
+		marker := ""
+		for {
+			res, err := fs.ListObjects(ctx, bucket.Name, "", marker, "", maxObjectList)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				break
+			}
+			for _, obj := range res.Objects {
+				select {
+				case <-endCh:
+					return dataUsageInfo
+				default:
+				}
+				waitForLowHTTPReq()
+				addObjectToDataUsage(&dataUsageInfo, bucket.Name, obj.Size)
+			}
+			if !res.IsTruncated {
+				break
+			}
+			marker = res.NextMarker
+		}
+	}
+
+	return dataUsageInfo
+}
+
 /// Bucket operations
 
 // getBucketDir - will convert incoming bucket names to
@@ -395,6 +439,11 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string) error {
 		return toObjectErr(err, bucket)
 	}
 
+	// Cleanup the version store of the bucket.
+	if err = fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaBucket, versionsMetaPrefix, bucket)); err != nil {
+		return toObjectErr(err, bucket)
+	}
+
 	// Delete all bucket metadata.
 	deleteBucketMetadata(ctx, bucket, fs)
 
@@ -408,13 +457,13 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string) error {
 // update metadata.
 func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, e error) {
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
-	if !cpSrcDstSame {
-		objectDWLock := fs.nsMutex.NewNSLock(ctx, dstBucket, dstObject)
-		if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
-			return oi, err
-		}
-		defer objectDWLock.Unlock()
+	// The source is read without a lock when it is the destination,
+	// the write lock is held for the copy in both cases.
+	objectDWLock := fs.nsMutex.NewNSLock(ctx, dstBucket, dstObject)
+	if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
+		return oi, err
 	}
+	defer objectDWLock.Unlock()
 
 	if _, err := fs.statBucketDir(ctx, srcBucket); err != nil {
 		return oi, toObjectErr(err, srcBucket)
@@ -437,18 +486,23 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 			fsMeta = fs.defaultFsJSON(srcObject)
 		}
 
-		fsMeta.Meta = srcInfo.UserDefined
-		fsMeta.Meta["etag"] = srcInfo.ETag
-		if _, err = fsMeta.WriteTo(wlk); err != nil {
-			return oi, toObjectErr(err, srcBucket, srcObject)
-		}
-
 		// Stat the file to get file size.
 		fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, srcBucket, srcObject))
 		if err != nil {
 			return oi, toObjectErr(err, srcBucket, srcObject)
 		}
 
+		// Deny changing the retention or legal hold of a protected object.
+		if err = enforceRetentionForUpdate(fsMeta.ToObjectInfo(srcBucket, srcObject, fi), srcInfo.UserDefined, dstOpts); err != nil {
+			return oi, err
+		}
+
+		fsMeta.Meta = srcInfo.UserDefined
+		fsMeta.Meta["etag"] = srcInfo.ETag
+		if _, err = fsMeta.WriteTo(wlk); err != nil {
+			return oi, toObjectErr(err, srcBucket, srcObject)
+		}
+
 		// Return the new object info.
 		return fsMeta.ToObjectInfo(srcBucket, srcObject, fi), nil
 	}
@@ -457,7 +511,15 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return ObjectInfo{}, err
 	}
 
-	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
+	putOpts := ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+		BypassGovernance:     dstOpts.BypassGovernance,
+		IndexCB:              dstOpts.IndexCB,
+	}
+	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -500,7 +562,13 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 
 	// Otherwise we get the object info
 	var objInfo ObjectInfo
-	if objInfo, err = fs.getObjectInfo(ctx, bucket, object); err != nil {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if hasSuffix(object, slashSeparator) {
+		objInfo, err = fs.getObjectInfo(ctx, bucket, object)
+	} else {
+		fsObjPath, objInfo, err = fs.getObjectVersionInfo(ctx, bucket, object, opts)
+	}
+	if err != nil {
 		nsUnlocker()
 		return nil, toObjectErr(err, bucket, object)
 	}
@@ -531,7 +599,6 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	readCloser, size, err := fsOpenFile(ctx, fsObjPath, off)
 	if err != nil {
 		rwPoolUnlocker()
@@ -574,11 +641,11 @@ func (fs *FSObjects) GetObject(ctx context.Context, bucket, object string, offse
 		return err
 	}
 	defer objectLock.RUnlock()
-	return fs.getObject(ctx, bucket, object, offset, length, writer, etag, true)
+	return fs.getObject(ctx, bucket, object, offset, length, writer, etag, true, opts)
 }
 
 // getObject - wrapper for GetObject
-func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, lock bool) (err error) {
+func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, lock bool, opts ObjectOptions) (err error) {
 	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
 		return toObjectErr(err, bucket)
 	}
@@ -625,8 +692,13 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 		}
 	}
 
+	// Resolve the requested version, delete markers have no content.
+	fsObjPath, _, err := fs.getObjectVersionInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return err
+	}
+
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	reader, size, err := fsOpenFile(ctx, fsObjPath, offset)
 	if err != nil {
 		return toObjectErr(err, bucket, object)
@@ -734,7 +806,7 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 }
 
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
-func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string) (oi ObjectInfo, e error) {
+func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string, opts ObjectOptions) (oi ObjectInfo, e error) {
 	// Lock the object before reading.
 	objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
 	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
@@ -754,12 +826,21 @@ func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object s
 		return oi, errFileNotFound
 	}
 
-	return fs.getObjectInfo(ctx, bucket, object)
+	if opts.VersionID != "" {
+		_, oi, err := fs.getObjectVersionInfo(ctx, bucket, object, opts)
+		return oi, err
+	}
+
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err == nil && oi.DeleteMarker {
+		return oi, errFileNotFound
+	}
+	return oi, err
 }
 
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (oi ObjectInfo, e error) {
-	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object)
+	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object, opts)
 	if err == errCorruptedFormat || err == io.EOF {
 		objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
 		if err = objectLock.GetLock(globalObjectTimeout); err != nil {
@@ -773,7 +854,7 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 			return oi, toObjectErr(err, bucket, object)
 		}
 
-		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object)
+		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object, opts)
 	}
 	return oi, toObjectErr(err, bucket, object)
 }
@@ -906,6 +987,7 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	setCompressionIndex(fsMeta.Meta, opts.IndexCB)
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -921,11 +1003,20 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 
 	// Entire object was written to the temp location, now it's safe to rename it to the actual location.
 	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
-	// Deny if WORM is enabled
-	if globalWORMEnabled {
-		if _, err = fsStatFile(ctx, fsNSObjPath); err == nil {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	// Deny overwriting a version protected by WORM or object lock.
+	err = enforceRetentionForOverwrite(bucket, object, opts, func() (ObjectInfo, error) {
+		return fs.latestVersionInfo(ctx, bucket, object, wlk)
+	})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if bucket != minioMetaBucket {
+		// Keep the existing object as a noncurrent version if versioning is configured.
+		if _, err = fs.archiveLatestVersion(ctx, bucket, object, wlk, opts); err != nil {
+			return ObjectInfo{}, err
 		}
+		fsMeta.VersionID = newObjectVersionID(opts)
 	}
 	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
@@ -948,34 +1039,121 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	return fsMeta.ToObjectInfo(bucket, object, fi), nil
 }
 
+// UpdateObjectMetadata - merges metadata into the metadata of the object
+// version requested in opts, keys with empty values are removed.
+func (fs *FSObjects) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	// Acquire a write lock before updating the object.
+	objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	fsObjPath, objInfo, err := fs.getObjectVersionInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return objInfo, err
+	}
+
+	// The object may have changed since the caller read it.
+	if opts.CheckUpdatePrecondFn != nil && opts.CheckUpdatePrecondFn(objInfo) {
+		return objInfo, PreConditionFailed{}
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	if !objInfo.IsLatest {
+		fsMetaPath = pathJoin(fs.getVersionDir(bucket, object, versionIDFromOpts(opts)), fs.metaJSONFile)
+	}
+
+	// Objects without `fs.json` get one created.
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	fsMeta := fs.defaultFsJSON(object)
+	if fi, err := wlk.Stat(); err == nil && fi.Size() > 0 {
+		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
+			// For any error to read fsMeta, set default ETag and proceed.
+			fsMeta = fs.defaultFsJSON(object)
+		}
+	}
+
+	fsMeta.Meta = mergeObjectMetadata(fsMeta.Meta, metadata)
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Stat the file to get file size.
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	isLatest := objInfo.IsLatest
+	objInfo = fsMeta.ToObjectInfo(bucket, object, fi)
+	objInfo.IsLatest = isLatest
+	return objInfo, nil
+}
+
 // DeleteObjects - deletes an object from a bucket, this operation is destructive
 // and there are no rollbacks supported.
-func (fs *FSObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (fs *FSObjects) DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error) {
 	errs := make([]error, len(objects))
 	for idx, object := range objects {
-		errs[idx] = fs.DeleteObject(ctx, bucket, object)
+		_, errs[idx] = fs.DeleteObject(ctx, bucket, object, opts)
 	}
 	return errs, nil
 }
 
 // DeleteObject - deletes an object from a bucket, this operation is destructive
 // and there are no rollbacks supported.
-func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) error {
+func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	// Acquire a write lock before deleting the object.
 	objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
-	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
-		return err
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
 	}
 	defer objectLock.Unlock()
 
-	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
-		return err
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
 	}
 
-	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
-		return toObjectErr(err, bucket)
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	if bucket != minioMetaBucket && !hasSuffix(object, slashSeparator) {
+		// Permanently delete a specific version.
+		if opts.VersionID != "" {
+			return fs.deleteObjectVersion(ctx, bucket, object, opts)
+		}
+		// Hide the object behind a delete marker.
+		if opts.isVersioningConfigured() {
+			return fs.putDeleteMarker(ctx, bucket, object, opts)
+		}
+	}
+
+	if err = fs.deleteObject(ctx, bucket, object); err != nil {
+		return objInfo, err
 	}
+	return ObjectInfo{Bucket: bucket, Name: object}, nil
+}
 
+// deleteObject - deletes an object and its `fs.json`, expects
+// the caller to hold the object lock.
+func (fs *FSObjects) deleteObject(ctx context.Context, bucket, object string) error {
 	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
 	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	if bucket != minioMetaBucket {
@@ -1104,8 +1282,19 @@ func (fs *FSObjects) getObjectETag(ctx context.Context, bucket, entry string, lo
 // ListObjects - list all objects at prefix upto maxKeys., optionally delimited by '/'. Maintains the list pool
 // state for future re-entrant list requests.
 func (fs *FSObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, e error) {
-	return listObjects(ctx, fs, bucket, prefix, marker, delimiter, maxKeys, fs.listPool,
+	loi, err := listObjects(ctx, fs, bucket, prefix, marker, delimiter, maxKeys, fs.listPool,
 		fs.listDirFactory(), fs.getObjectInfo, fs.getObjectInfo)
+	if err != nil {
+		return loi, err
+	}
+	// Delete markers are only visible when listing versions.
+	return filterDeleteMarkers(loi), nil
+}
+
+// TransitionObject - no-op for fs, Valid only for XL.
+func (fs *FSObjects) TransitionObject(ctx context.Context, bucket, object, tier string, opts ObjectOptions) (ObjectInfo, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return ObjectInfo{}, NotImplemented{}
 }
 
 // ReloadFormat - no-op for fs, Valid only for XL.
@@ -1182,6 +1371,56 @@ func (fs *FSObjects) DeleteBucketLifecycle(ctx context.Context, bucket string) e
 	return removeLifecycleConfig(ctx, fs, bucket)
 }
 
+// SetBucketVersioning sets versioning configuration on bucket
+func (fs *FSObjects) SetBucketVersioning(ctx context.Context, bucket string, v *versioning.Versioning) error {
+	return saveVersioningConfig(ctx, fs, bucket, v)
+}
+
+// GetBucketVersioning will get versioning configuration on bucket
+func (fs *FSObjects) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getVersioningConfig(fs, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (fs *FSObjects) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (fs *FSObjects) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(fs, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (fs *FSObjects) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveReplicationConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (fs *FSObjects) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getReplicationConfig(fs, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (fs *FSObjects) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeReplicationConfig(ctx, fs, bucket)
+}
+
+// SetBucketSSEConfig sets encryption configuration on bucket
+func (fs *FSObjects) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.Config) error {
+	return saveBucketSSEConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketSSEConfig will get encryption configuration on bucket
+func (fs *FSObjects) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.Config, error) {
+	return getBucketSSEConfig(fs, bucket)
+}
+
+// DeleteBucketSSEConfig deletes encryption configuration on bucket
+func (fs *FSObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, fs, bucket)
+}
+
 // ListObjectsV2 lists all blobs in bucket filtered by prefix
 func (fs *FSObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
 	marker := continuationToken
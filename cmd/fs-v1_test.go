@@ -139,7 +139,7 @@ func TestFSShutdown(t *testing.T) {
 
 	// Test Shutdown with faulty disk
 	fs, disk = prepareTest()
-	fs.DeleteObject(context.Background(), bucketName, objectName)
+	fs.DeleteObject(context.Background(), bucketName, objectName, ObjectOptions{})
 	os.RemoveAll(disk)
 	if err := fs.Shutdown(context.Background()); err != nil {
 		t.Fatal("Got unexpected fs shutdown error: ", err)
@@ -272,29 +272,29 @@ func TestFSDeleteObject(t *testing.T) {
 	obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{})
 
 	// Test with invalid bucket name
-	if err := fs.DeleteObject(context.Background(), "fo", objectName); !isSameType(err, BucketNameInvalid{}) {
+	if _, err := fs.DeleteObject(context.Background(), "fo", objectName, ObjectOptions{}); !isSameType(err, BucketNameInvalid{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 	// Test with bucket does not exist
-	if err := fs.DeleteObject(context.Background(), "foobucket", "fooobject"); !isSameType(err, BucketNotFound{}) {
+	if _, err := fs.DeleteObject(context.Background(), "foobucket", "fooobject", ObjectOptions{}); !isSameType(err, BucketNotFound{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 	// Test with invalid object name
-	if err := fs.DeleteObject(context.Background(), bucketName, "\\"); !isSameType(err, ObjectNameInvalid{}) {
+	if _, err := fs.DeleteObject(context.Background(), bucketName, "\\", ObjectOptions{}); !isSameType(err, ObjectNameInvalid{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 	// Test with object does not exist.
-	if err := fs.DeleteObject(context.Background(), bucketName, "foooobject"); !isSameType(err, ObjectNotFound{}) {
+	if _, err := fs.DeleteObject(context.Background(), bucketName, "foooobject", ObjectOptions{}); !isSameType(err, ObjectNotFound{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 	// Test with valid condition
-	if err := fs.DeleteObject(context.Background(), bucketName, objectName); err != nil {
+	if _, err := fs.DeleteObject(context.Background(), bucketName, objectName, ObjectOptions{}); err != nil {
 		t.Fatal("Unexpected error: ", err)
 	}
 
 	// Delete object should err disk not found.
 	os.RemoveAll(disk)
-	if err := fs.DeleteObject(context.Background(), bucketName, objectName); err != nil {
+	if _, err := fs.DeleteObject(context.Background(), bucketName, objectName, ObjectOptions{}); err != nil {
 		if !isSameType(err, BucketNotFound{}) {
 			t.Fatal("Unexpected error: ", err)
 		}
@@ -276,6 +276,21 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// Create new lifecycle system
 	globalLifecycleSys = NewLifecycleSys()
 
+	// Create new bucket versioning system
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Create new bucket object lock system
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+
+	// Create new bucket replication system
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// Create new bucket encryption system
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// Create new bucket quota system
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 	if globalEtcdClient != nil && newObject.IsNotificationSupported() {
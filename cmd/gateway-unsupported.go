@@ -22,7 +22,11 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/lifecycle"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/sse"
+	"github.com/minio/minio/pkg/versioning"
 )
 
 // GatewayUnsupported list of unsupported call stubs for gateway.
@@ -98,6 +102,83 @@ func (a GatewayUnsupported) DeleteBucketLifecycle(ctx context.Context, bucket st
 	return NotImplemented{}
 }
 
+// SetBucketVersioning sets versioning configuration on bucket
+func (a GatewayUnsupported) SetBucketVersioning(ctx context.Context, bucket string, v *versioning.Versioning) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketVersioning will get versioning configuration on bucket
+func (a GatewayUnsupported) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return nil, NotImplemented{}
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (a GatewayUnsupported) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (a GatewayUnsupported) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (a GatewayUnsupported) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (a GatewayUnsupported) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (a GatewayUnsupported) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketSSEConfig sets encryption configuration on bucket
+func (a GatewayUnsupported) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.Config) error {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// GetBucketSSEConfig will get encryption configuration on bucket
+func (a GatewayUnsupported) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketSSEConfig deletes encryption configuration on bucket
+func (a GatewayUnsupported) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// ListAllMultipartUploads lists the multipart uploads of all objects with a prefix
+func (a GatewayUnsupported) ListAllMultipartUploads(ctx context.Context, bucket, prefix string) (uploads []MultipartInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return nil, NotImplemented{}
+}
+
+// UpdateObjectMetadata updates the metadata of an object version
+func (a GatewayUnsupported) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// TransitionObject moves object data to a remote tier
+func (a GatewayUnsupported) TransitionObject(ctx context.Context, bucket, object, tier string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
+// ListObjectVersions lists all versions of the objects in a bucket
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	return result, NotImplemented{}
+}
+
 // ReloadFormat - Not implemented stub.
 func (a GatewayUnsupported) ReloadFormat(ctx context.Context, dryRun bool) error {
 	return NotImplemented{}
@@ -149,6 +230,11 @@ func (a GatewayUnsupported) RefreshBucketPolicy(ctx context.Context, bucket stri
 	return NotImplemented{}
 }
 
+// CrawlAndGetDataUsage - data usage is not computed by gateways
+func (a GatewayUnsupported) CrawlAndGetDataUsage(ctx context.Context, endCh <-chan struct{}) madmin.DataUsageInfo {
+	return madmin.DataUsageInfo{}
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (a GatewayUnsupported) IsNotificationSupported() bool {
 	return false
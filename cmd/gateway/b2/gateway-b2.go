@@ -590,27 +590,30 @@ func (l *b2Objects) PutObject(ctx context.Context, bucket string, object string,
 }
 
 // DeleteObject deletes a blob in bucket
-func (l *b2Objects) DeleteObject(ctx context.Context, bucket string, object string) error {
+func (l *b2Objects) DeleteObject(ctx context.Context, bucket string, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
 	bkt, err := l.Bucket(ctx, bucket)
 	if err != nil {
-		return err
+		return minio.ObjectInfo{}, err
 	}
 	reader, err := bkt.DownloadFileByName(l.ctx, object, 0, 1)
 	if err != nil {
 		logger.LogIf(ctx, err)
-		return b2ToObjectError(err, bucket, object)
+		return minio.ObjectInfo{}, b2ToObjectError(err, bucket, object)
 	}
 	io.Copy(ioutil.Discard, reader)
 	reader.Close()
 	err = bkt.File(reader.ID, object).DeleteFileVersion(l.ctx)
-	logger.LogIf(ctx, err)
-	return b2ToObjectError(err, bucket, object)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return minio.ObjectInfo{}, b2ToObjectError(err, bucket, object)
+	}
+	return minio.ObjectInfo{Bucket: bucket, Name: object}, nil
 }
 
-func (l *b2Objects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (l *b2Objects) DeleteObjects(ctx context.Context, bucket string, objects []string, opts minio.ObjectOptions) ([]error, error) {
 	errs := make([]error, len(objects))
 	for idx, object := range objects {
-		errs[idx] = l.DeleteObject(ctx, bucket, object)
+		_, errs[idx] = l.DeleteObject(ctx, bucket, object, opts)
 	}
 	return errs, nil
 }
@@ -695,25 +695,25 @@ func (l *ossObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 }
 
 // DeleteObject deletes a blob in bucket.
-func (l *ossObjects) DeleteObject(ctx context.Context, bucket, object string) error {
+func (l *ossObjects) DeleteObject(ctx context.Context, bucket, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
 	bkt, err := l.Client.Bucket(bucket)
 	if err != nil {
 		logger.LogIf(ctx, err)
-		return ossToObjectError(err, bucket, object)
+		return minio.ObjectInfo{}, ossToObjectError(err, bucket, object)
 	}
 
 	err = bkt.DeleteObject(object)
 	if err != nil {
 		logger.LogIf(ctx, err)
-		return ossToObjectError(err, bucket, object)
+		return minio.ObjectInfo{}, ossToObjectError(err, bucket, object)
 	}
-	return nil
+	return minio.ObjectInfo{Bucket: bucket, Name: object}, nil
 }
 
-func (l *ossObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (l *ossObjects) DeleteObjects(ctx context.Context, bucket string, objects []string, opts minio.ObjectOptions) ([]error, error) {
 	errs := make([]error, len(objects))
 	for idx, object := range objects {
-		errs[idx] = l.DeleteObject(ctx, bucket, object)
+		_, errs[idx] = l.DeleteObject(ctx, bucket, object, opts)
 	}
 	return errs, nil
 }
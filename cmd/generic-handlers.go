@@ -428,9 +428,9 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 		// Enable GetBucketACL, GetBucketCors, GetBucketWebsite,
 		// GetBucketAcccelerate, GetBucketRequestPayment,
 		// GetBucketLogging, GetBucketLifecycle,
-		// GetBucketReplication, GetBucketTagging,
-		// GetBucketVersioning, DeleteBucketTagging,
-		// and DeleteBucketWebsite dummy calls specifically.
+		// GetBucketTagging, DeleteBucketTagging,
+		// and DeleteBucketWebsite
+		// dummy calls specifically.
 		if ((name == "acl" ||
 			name == "cors" ||
 			name == "website" ||
@@ -438,9 +438,7 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 			name == "requestPayment" ||
 			name == "logging" ||
 			name == "lifecycle" ||
-			name == "replication" ||
-			name == "tagging" ||
-			name == "versioning") && req.Method == http.MethodGet) ||
+			name == "tagging") && req.Method == http.MethodGet) ||
 			((name == "tagging" ||
 				name == "website") && req.Method == http.MethodDelete) {
 			return false
@@ -456,8 +454,8 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 // Checks requests for not implemented Object resources
 func ignoreNotImplementedObjectResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetObjectACL and GetObjectTagging dummy calls specifically.
-		if (name == "acl" || name == "tagging") && req.Method == http.MethodGet {
+		// Enable GetObjectACL dummy call specifically.
+		if name == "acl" && req.Method == http.MethodGet {
 			return false
 		}
 		if notimplementedObjectResourceNames[name] {
@@ -475,11 +473,8 @@ var notimplementedBucketResourceNames = map[string]bool{
 	"inventory":      true,
 	"logging":        true,
 	"metrics":        true,
-	"replication":    true,
 	"requestPayment": true,
 	"tagging":        true,
-	"versioning":     true,
-	"versions":       true,
 	"website":        true,
 }
 
@@ -488,7 +483,6 @@ var notimplementedObjectResourceNames = map[string]bool{
 	"acl":     true,
 	"policy":  true,
 	"restore": true,
-	"tagging": true,
 	"torrent": true,
 }
 
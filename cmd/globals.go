@@ -88,6 +88,20 @@ const (
 
 	// Refresh interval to update in-memory bucket lifecycle cache.
 	globalRefreshBucketLifecycleInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket versioning cache.
+	globalRefreshBucketVersioningInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket object lock cache.
+	globalRefreshBucketObjectLockInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket replication cache.
+	globalRefreshBucketReplicationInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket encryption cache.
+	globalRefreshBucketSSEConfigInterval = 5 * time.Minute
+	// Refresh interval to update in-memory bucket quota cache.
+	globalRefreshBucketQuotaInterval = 5 * time.Minute
+	// Interval at which the usage of the buckets with a quota is computed.
+	globalBucketUsageInterval = 10 * time.Minute
+	// Interval at which the data usage of all buckets is computed.
+	globalDataUsageCrawlInterval = 12 * time.Hour
 	// Refresh interval to update in-memory iam config cache.
 	globalRefreshIAMInterval = 5 * time.Minute
 
@@ -155,6 +169,26 @@ var (
 
 	globalLifecycleSys *LifecycleSys
 
+	// Bucket versioning is consulted by every object write, hence
+	// an empty versioning system until the object layer is up.
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Bucket object lock is consulted by every object write, hence
+	// an empty object lock system until the object layer is up.
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+
+	// Bucket replication is consulted by every object write, hence
+	// an empty replication system until the object layer is up.
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// Bucket default encryption is consulted by every object write,
+	// hence an empty encryption system until the object layer is up.
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// Bucket quota is consulted by every object write, hence
+	// an empty quota system until the object layer is up.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -251,11 +285,14 @@ var (
 	globalCompressExtensions = []string{".txt", ".log", ".csv", ".json"}
 	globalCompressMimeTypes  = []string{"text/csv", "text/plain", "application/json"}
 
+	// Is compression of encrypted objects allowed.
+	globalCompressAllowEncryption = false
+
 	// Some standard object extensions which we strictly dis-allow for compression.
-	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z"}
+	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".zst", ".lz4", ".sz"}
 
 	// Some standard content-types which we strictly dis-allow for compression.
-	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon"}
+	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon", "application/zstd", "application/x-lz4", "application/x-snappy-framed"}
 
 	// Authorization validators list.
 	globalIAMValidators *validator.Validators
@@ -274,6 +311,9 @@ var (
 	globalAllHealState      *allHealState
 	globalSweepHealState    *allHealState
 
+	// The state of the KMS key rotations started through the admin API
+	globalAllKMSKeyRotateState *allKMSKeyRotateState
+
 	// Add new variable global values here.
 )
 
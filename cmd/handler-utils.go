@@ -92,6 +92,23 @@ func isMetadataReplace(h http.Header) bool {
 	return h.Get("X-Amz-Metadata-Directive") == "REPLACE"
 }
 
+// isTaggingDirectiveValid - check if tagging-directive is valid.
+func isTaggingDirectiveValid(h http.Header) bool {
+	_, ok := h[xhttp.AmzTagDirective]
+	if ok {
+		// Check atleast set tagging-directive is valid.
+		return (h.Get(xhttp.AmzTagDirective) == "COPY" || isTaggingReplace(h))
+	}
+	// By default if x-amz-tagging-directive is not set we
+	// treat it as 'COPY' this function returns true.
+	return true
+}
+
+// Check if the tagging REPLACE is requested.
+func isTaggingReplace(h http.Header) bool {
+	return h.Get(xhttp.AmzTagDirective) == "REPLACE"
+}
+
 // Splits an incoming path into bucket and object components.
 func path2BucketAndObject(path string) (bucket, object string) {
 	// Skip the first element if it is '/', split the rest.
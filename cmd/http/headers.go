@@ -58,6 +58,18 @@ const (
 	AmzCopySourceVersionID = "X-Amz-Copy-Source-Version-Id"
 	AmzCopySourceRange     = "X-Amz-Copy-Source-Range"
 
+	// Object versioning related constants.
+	AmzVersionID    = "X-Amz-Version-Id"
+	AmzDeleteMarker = "X-Amz-Delete-Marker"
+
+	// Object tagging related constants.
+	AmzObjectTagging = "X-Amz-Tagging"
+	AmzTagCount      = "X-Amz-Tagging-Count"
+	AmzTagDirective  = "X-Amz-Tagging-Directive"
+
+	// Bucket replication related constants.
+	AmzBucketReplicationStatus = "X-Amz-Replication-Status"
+
 	// Signature V4 related contants.
 	AmzContentSha256        = "X-Amz-Content-Sha256"
 	AmzDate                 = "X-Amz-Date"
@@ -48,14 +48,21 @@ const (
 	// IAM sts directory.
 	iamConfigSTSPrefix = iamConfigPrefix + "/sts/"
 
+	// IAM groups directory.
+	iamConfigGroupsPrefix = iamConfigPrefix + "/groups/"
+
 	// IAM Policy DB prefixes.
 	iamConfigPolicyDBPrefix         = iamConfigPrefix + "/policydb/"
 	iamConfigPolicyDBUsersPrefix    = iamConfigPolicyDBPrefix + "users/"
 	iamConfigPolicyDBSTSUsersPrefix = iamConfigPolicyDBPrefix + "sts-users/"
+	iamConfigPolicyDBGroupsPrefix   = iamConfigPolicyDBPrefix + "groups/"
 
 	// IAM identity file which captures identity credentials.
 	iamIdentityFile = "identity.json"
 
+	// IAM group members file.
+	iamGroupMembersFile = "members.json"
+
 	// IAM policy file which provides policies for each users.
 	iamPolicyFile = "policy.json"
 
@@ -96,6 +103,20 @@ func getMappedPolicyPath(name string, isSTS bool) string {
 	return pathJoin(iamConfigPolicyDBUsersPrefix, name+".json")
 }
 
+func getGroupInfoPath(group string) string {
+	return pathJoin(iamConfigGroupsPrefix, group, iamGroupMembersFile)
+}
+
+// isValidGroupName - returns true if the group name is a single
+// path element, as groups are saved in a directory of their name.
+func isValidGroupName(group string) bool {
+	return group != "" && group != "." && group != ".." && !strings.Contains(group, slashSeparator)
+}
+
+func getGroupMappedPolicyPath(group string) string {
+	return pathJoin(iamConfigPolicyDBGroupsPrefix, group+".json")
+}
+
 // MappedPolicy represents a policy name mapped to a user or group
 type MappedPolicy struct {
 	Version int    `json:"version"`
@@ -116,6 +137,31 @@ func newUserIdentity(creds auth.Credentials) UserIdentity {
 	return UserIdentity{Version: 1, Credentials: creds}
 }
 
+// GroupInfo represents the status and the members of a group
+type GroupInfo struct {
+	Version int      `json:"version"`
+	Status  string   `json:"status"`
+	Members []string `json:"members"`
+}
+
+func newGroupInfo(members []string) GroupInfo {
+	return GroupInfo{Version: 1, Status: string(madmin.GroupEnabled), Members: members}
+}
+
+// getUserGroupMemberships - returns the groups each user is a member of.
+func getUserGroupMemberships(groups map[string]GroupInfo) map[string]set.StringSet {
+	memberships := make(map[string]set.StringSet)
+	for group, gi := range groups {
+		for _, member := range gi.Members {
+			if _, ok := memberships[member]; !ok {
+				memberships[member] = set.NewStringSet()
+			}
+			memberships[member].Add(group)
+		}
+	}
+	return memberships
+}
+
 func loadIAMConfigItem(objectAPI ObjectLayer, item interface{}, path string) error {
 	data, err := readConfig(context.Background(), objectAPI, path)
 	if err != nil {
@@ -209,6 +255,34 @@ func saveIAMConfigItemEtcd(ctx context.Context, item interface{}, path string) e
 	return saveConfigEtcd(context.Background(), globalEtcdClient, path, data)
 }
 
+// saveIAMItem - saves an IAM config item in etcd if configured,
+// in the backend otherwise.
+func saveIAMItem(objectAPI ObjectLayer, item interface{}, path string) error {
+	if globalEtcdClient != nil {
+		return saveIAMConfigItemEtcd(context.Background(), item, path)
+	}
+	return saveIAMConfigItem(objectAPI, item, path)
+}
+
+// deleteIAMItem - deletes an IAM config item from etcd if configured,
+// from the backend otherwise. Items already deleted are ignored.
+func deleteIAMItem(objectAPI ObjectLayer, path string) error {
+	var err error
+	if globalEtcdClient != nil {
+		err = deleteConfigEtcd(context.Background(), globalEtcdClient, path)
+	} else {
+		err = deleteConfig(context.Background(), objectAPI, path)
+	}
+	switch err.(type) {
+	case ObjectNotFound:
+		err = nil
+	}
+	if err == errConfigNotFound {
+		err = nil
+	}
+	return err
+}
+
 // IAMSys - config system.
 type IAMSys struct {
 	sync.RWMutex
@@ -218,6 +292,12 @@ type IAMSys struct {
 	iamPolicyDocsMap map[string]iampolicy.Policy
 	// map of usernames/temporary access keys to policy names
 	iamUserPolicyMap map[string]MappedPolicy
+	// map of group names to group info
+	iamGroupsMap map[string]GroupInfo
+	// map of usernames to the groups they are members of
+	iamUserGroupMemberships map[string]set.StringSet
+	// map of group names to policy names
+	iamGroupPolicyMap map[string]MappedPolicy
 }
 
 func loadPolicyDoc(objectAPI ObjectLayer, policy string, m map[string]iampolicy.Policy) error {
@@ -323,6 +403,60 @@ func loadMappedPolicies(objectAPI ObjectLayer, isSTS bool, m map[string]MappedPo
 	return nil
 }
 
+func loadGroup(objectAPI ObjectLayer, group string, m map[string]GroupInfo) error {
+	var g GroupInfo
+	err := loadIAMConfigItem(objectAPI, &g, getGroupInfoPath(group))
+	if err != nil {
+		return err
+	}
+	m[group] = g
+	return nil
+}
+
+func loadGroups(objectAPI ObjectLayer, m map[string]GroupInfo) error {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	for item := range listIAMConfigItems(objectAPI, iamConfigGroupsPrefix, true, doneCh) {
+		if item.Err != nil {
+			return item.Err
+		}
+
+		group := item.Item
+		err := loadGroup(objectAPI, group, m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadGroupMappedPolicy(objectAPI ObjectLayer, group string, m map[string]MappedPolicy) error {
+	var p MappedPolicy
+	err := loadIAMConfigItem(objectAPI, &p, getGroupMappedPolicyPath(group))
+	if err != nil {
+		return err
+	}
+	m[group] = p
+	return nil
+}
+
+func loadGroupMappedPolicies(objectAPI ObjectLayer, m map[string]MappedPolicy) error {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	for item := range listIAMConfigItems(objectAPI, iamConfigPolicyDBGroupsPrefix, false, doneCh) {
+		if item.Err != nil {
+			return item.Err
+		}
+
+		group := strings.TrimSuffix(item.Item, ".json")
+		err := loadGroupMappedPolicy(objectAPI, group, m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // LoadPolicy - reloads a specific canned policy from backend disks or etcd.
 func (sys *IAMSys) LoadPolicy(objAPI ObjectLayer, policyName string) error {
 	if objAPI == nil {
@@ -363,6 +497,39 @@ func (sys *IAMSys) LoadUser(objAPI ObjectLayer, accessKey string, isSTS bool) er
 	return nil
 }
 
+// LoadGroup - reloads a specific group from backend disks or etcd.
+func (sys *IAMSys) LoadGroup(objAPI ObjectLayer, group string) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	// When etcd is set, we use watch APIs so this code is not needed.
+	if globalEtcdClient != nil {
+		return nil
+	}
+
+	err := loadGroup(objAPI, group, sys.iamGroupsMap)
+	if err == errConfigNotFound {
+		// Group was deleted.
+		delete(sys.iamGroupsMap, group)
+		delete(sys.iamGroupPolicyMap, group)
+	} else if err != nil {
+		return err
+	} else {
+		err = loadGroupMappedPolicy(objAPI, group, sys.iamGroupPolicyMap)
+		if err == errConfigNotFound {
+			delete(sys.iamGroupPolicyMap, group)
+		} else if err != nil {
+			return err
+		}
+	}
+	sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+	return nil
+}
+
 // Load - loads iam subsystem
 func (sys *IAMSys) Load(objAPI ObjectLayer) error {
 	if globalEtcdClient != nil {
@@ -378,6 +545,8 @@ func (sys *IAMSys) reloadFromEvent(event *etcd.Event) {
 	usersPrefix := strings.HasPrefix(string(event.Kv.Key), iamConfigUsersPrefix)
 	stsPrefix := strings.HasPrefix(string(event.Kv.Key), iamConfigSTSPrefix)
 	policyPrefix := strings.HasPrefix(string(event.Kv.Key), iamConfigPoliciesPrefix)
+	groupsPrefix := strings.HasPrefix(string(event.Kv.Key), iamConfigGroupsPrefix)
+	policyDBGroupsPrefix := strings.HasPrefix(string(event.Kv.Key), iamConfigPolicyDBGroupsPrefix)
 
 	ctx, cancel := context.WithTimeout(context.Background(),
 		defaultContextTimeout)
@@ -398,6 +567,15 @@ func (sys *IAMSys) reloadFromEvent(event *etcd.Event) {
 			policyName := path.Dir(strings.TrimPrefix(string(event.Kv.Key),
 				iamConfigPoliciesPrefix))
 			loadEtcdPolicy(ctx, policyName, sys.iamPolicyDocsMap)
+		case groupsPrefix:
+			group := path.Dir(strings.TrimPrefix(string(event.Kv.Key),
+				iamConfigGroupsPrefix))
+			loadEtcdGroup(ctx, group, sys.iamGroupsMap)
+			sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+		case policyDBGroupsPrefix:
+			group := strings.TrimSuffix(strings.TrimPrefix(string(event.Kv.Key),
+				iamConfigPolicyDBGroupsPrefix), ".json")
+			loadEtcdGroupMappedPolicy(ctx, group, sys.iamGroupPolicyMap)
 		}
 	case eventDelete:
 		switch {
@@ -413,6 +591,15 @@ func (sys *IAMSys) reloadFromEvent(event *etcd.Event) {
 			policyName := path.Dir(strings.TrimPrefix(string(event.Kv.Key),
 				iamConfigPoliciesPrefix))
 			delete(sys.iamPolicyDocsMap, policyName)
+		case groupsPrefix:
+			group := path.Dir(strings.TrimPrefix(string(event.Kv.Key),
+				iamConfigGroupsPrefix))
+			delete(sys.iamGroupsMap, group)
+			sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+		case policyDBGroupsPrefix:
+			group := strings.TrimSuffix(strings.TrimPrefix(string(event.Kv.Key),
+				iamConfigPolicyDBGroupsPrefix), ".json")
+			delete(sys.iamGroupPolicyMap, group)
 		}
 	}
 }
@@ -892,6 +1079,20 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 	sys.Lock()
 	defer sys.Unlock()
 
+	// Remove the user from the groups it is a member of.
+	for _, group := range sys.iamUserGroupMemberships[accessKey].ToSlice() {
+		gi := sys.iamGroupsMap[group]
+		gi.Members = set.CreateStringSet(gi.Members...).Difference(set.CreateStringSet(accessKey)).ToSlice()
+		if gerr := saveIAMItem(objectAPI, gi, getGroupInfoPath(group)); gerr != nil {
+			if err == nil {
+				err = gerr
+			}
+			continue
+		}
+		sys.iamGroupsMap[group] = gi
+	}
+	sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+
 	delete(sys.iamUsersMap, accessKey)
 	delete(sys.iamUserPolicyMap, accessKey)
 
@@ -899,6 +1100,8 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 }
 
 // SetTempUser - set temporary user credentials, these credentials have an expiry.
+// The policy name may be a comma separated list of canned policies which
+// then apply together.
 func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyName string) error {
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil {
@@ -912,7 +1115,7 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 	// temporary user which match with pre-configured canned
 	// policies for this server.
 	if globalPolicyOPA == nil && policyName != "" {
-		p, ok := sys.iamPolicyDocsMap[policyName]
+		p, ok := sys.combinePolicies(strings.Split(policyName, ","))
 		if !ok {
 			return errInvalidArgument
 		}
@@ -970,6 +1173,7 @@ func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 		users[k] = madmin.UserInfo{
 			PolicyName: sys.iamUserPolicyMap[k].Policy,
 			Status:     madmin.AccountStatus(v.Status),
+			MemberOf:   sys.iamUserGroupMemberships[k].ToSlice(),
 		}
 	}
 
@@ -1095,7 +1299,7 @@ func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 // PolicyDBSet - sets a policy for a user or group in the
 // PolicyDB. This function applies only long-term users. For STS
 // users, policy is set directly by called sys.policyDBSet().
-func (sys *IAMSys) PolicyDBSet(name, policy string) error {
+func (sys *IAMSys) PolicyDBSet(name, policy string, isGroup bool) error {
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil {
 		return errServerNotInitialized
@@ -1104,9 +1308,35 @@ func (sys *IAMSys) PolicyDBSet(name, policy string) error {
 	sys.Lock()
 	defer sys.Unlock()
 
+	if isGroup {
+		return sys.groupPolicyDBSet(objectAPI, name, policy)
+	}
 	return sys.policyDBSet(objectAPI, name, policy, false)
 }
 
+// groupPolicyDBSet - sets a policy for a group in the policy db.
+// Assumes that caller has sys.Lock().
+func (sys *IAMSys) groupPolicyDBSet(objectAPI ObjectLayer, group, policy string) error {
+	if group == "" || policy == "" {
+		return errInvalidArgument
+	}
+
+	if _, ok := sys.iamGroupsMap[group]; !ok {
+		return errNoSuchGroup
+	}
+
+	if _, ok := sys.iamPolicyDocsMap[policy]; !ok {
+		return errNoSuchPolicy
+	}
+
+	mp := newMappedPolicy(policy)
+	if err := saveIAMItem(objectAPI, mp, getGroupMappedPolicyPath(group)); err != nil {
+		return err
+	}
+	sys.iamGroupPolicyMap[group] = mp
+	return nil
+}
+
 // policyDBSet - sets a policy for user in the policy db. Assumes that
 // caller has sys.Lock().
 func (sys *IAMSys) policyDBSet(objectAPI ObjectLayer, name, policy string, isSTS bool) error {
@@ -1142,8 +1372,8 @@ func (sys *IAMSys) policyDBSet(objectAPI ObjectLayer, name, policy string, isSTS
 	return nil
 }
 
-// PolicyDBGet - gets policy set on a user
-func (sys *IAMSys) PolicyDBGet(name string) (string, error) {
+// PolicyDBGet - gets policy set on a user or group
+func (sys *IAMSys) PolicyDBGet(name string, isGroup bool) (string, error) {
 	if name == "" {
 		return "", errInvalidArgument
 	}
@@ -1156,6 +1386,14 @@ func (sys *IAMSys) PolicyDBGet(name string) (string, error) {
 	sys.RLock()
 	defer sys.RUnlock()
 
+	if isGroup {
+		if _, ok := sys.iamGroupsMap[name]; !ok {
+			return "", errNoSuchGroup
+		}
+		// returned policy could be empty
+		return sys.iamGroupPolicyMap[name].Policy, nil
+	}
+
 	if _, ok := sys.iamUsersMap[name]; !ok {
 		return "", errNoSuchUser
 	}
@@ -1165,6 +1403,160 @@ func (sys *IAMSys) PolicyDBGet(name string) (string, error) {
 	return policy.Policy, nil
 }
 
+// AddUsersToGroup - adds users to a group, creating the group if it
+// does not exist. Only long-term users can be members of a group.
+func (sys *IAMSys) AddUsersToGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if !isValidGroupName(group) {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	for _, member := range members {
+		cred, ok := sys.iamUsersMap[member]
+		if !ok || cred.SessionToken != "" {
+			return errNoSuchUser
+		}
+	}
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		gi = newGroupInfo(set.CreateStringSet(members...).ToSlice())
+	} else {
+		gi.Members = set.CreateStringSet(gi.Members...).Union(set.CreateStringSet(members...)).ToSlice()
+	}
+
+	if err := saveIAMItem(objectAPI, gi, getGroupInfoPath(group)); err != nil {
+		return err
+	}
+
+	sys.iamGroupsMap[group] = gi
+	sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+	return nil
+}
+
+// RemoveUsersFromGroup - removes users from a group. Without members
+// the group is deleted along with its policy, which is only allowed
+// once the group has no members left.
+func (sys *IAMSys) RemoveUsersFromGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if group == "" {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+
+	if len(members) == 0 {
+		if len(gi.Members) > 0 {
+			return errGroupNotEmpty
+		}
+
+		// It is okay to ignore errors when deleting the policy of the group.
+		_ = deleteIAMItem(objectAPI, getGroupMappedPolicyPath(group))
+		if err := deleteIAMItem(objectAPI, getGroupInfoPath(group)); err != nil {
+			return err
+		}
+
+		delete(sys.iamGroupsMap, group)
+		delete(sys.iamGroupPolicyMap, group)
+		return nil
+	}
+
+	gi.Members = set.CreateStringSet(gi.Members...).Difference(set.CreateStringSet(members...)).ToSlice()
+	if err := saveIAMItem(objectAPI, gi, getGroupInfoPath(group)); err != nil {
+		return err
+	}
+
+	sys.iamGroupsMap[group] = gi
+	sys.iamUserGroupMemberships = getUserGroupMemberships(sys.iamGroupsMap)
+	return nil
+}
+
+// SetGroupStatus - enables or disables a group, the policy of a
+// disabled group does not apply to its members.
+func (sys *IAMSys) SetGroupStatus(group string, status madmin.GroupStatus) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if status != madmin.GroupEnabled && status != madmin.GroupDisabled {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+
+	gi.Status = string(status)
+	if err := saveIAMItem(objectAPI, gi, getGroupInfoPath(group)); err != nil {
+		return err
+	}
+
+	sys.iamGroupsMap[group] = gi
+	return nil
+}
+
+// GetGroupDescription - returns the members, policy and status of a group.
+func (sys *IAMSys) GetGroupDescription(group string) (gd madmin.GroupDesc, err error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return gd, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return gd, errNoSuchGroup
+	}
+
+	return madmin.GroupDesc{
+		Name:    group,
+		Status:  madmin.GroupStatus(gi.Status),
+		Members: gi.Members,
+		Policy:  sys.iamGroupPolicyMap[group].Policy,
+	}, nil
+}
+
+// ListGroups - lists all groups.
+func (sys *IAMSys) ListGroups() ([]string, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	groups := make([]string, 0, len(sys.iamGroupsMap))
+	for group := range sys.iamGroupsMap {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
 // IsAllowedSTS is meant for STS based temporary credentials,
 // which implements claims validation and verification other than
 // applying policies.
@@ -1203,7 +1595,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 	if !ok {
 		// Sub policy not set, this is most common since subPolicy
 		// is optional, use the top level policy only.
-		p, ok := sys.iamPolicyDocsMap[pnameStr]
+		p, ok := sys.combinePolicies(strings.Split(pnameStr, ","))
 		return ok && p.IsAllowed(args)
 	}
 
@@ -1228,7 +1620,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 	}
 
 	// Sub policy is set and valid.
-	p, ok := sys.iamPolicyDocsMap[pnameStr]
+	p, ok := sys.combinePolicies(strings.Split(pnameStr, ","))
 	return ok && p.IsAllowed(args) && subPolicy.IsAllowed(args)
 }
 
@@ -1247,15 +1639,44 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	sys.RLock()
 	defer sys.RUnlock()
 
-	// If policy is available for given user, check the policy.
+	// Policies of the user and of the enabled groups the user is
+	// a member of apply together, as statements of one policy.
+	var policyNames []string
 	if mp, found := sys.iamUserPolicyMap[args.AccountName]; found {
-		p, ok := sys.iamPolicyDocsMap[mp.Policy]
-		return ok && p.IsAllowed(args)
+		policyNames = append(policyNames, mp.Policy)
+	}
+	for _, group := range sys.iamUserGroupMemberships[args.AccountName].ToSlice() {
+		if sys.iamGroupsMap[group].Status == string(madmin.GroupDisabled) {
+			continue
+		}
+		if mp, found := sys.iamGroupPolicyMap[group]; found {
+			policyNames = append(policyNames, mp.Policy)
+		}
 	}
 
 	// As policy is not available and OPA is not configured,
 	// return the owner value.
-	return args.IsOwner
+	if len(policyNames) == 0 {
+		return args.IsOwner
+	}
+
+	combinedPolicy, _ := sys.combinePolicies(policyNames)
+	return combinedPolicy.IsAllowed(args)
+}
+
+// combinePolicies - returns the statements of the named canned
+// policies as one policy, ok is false if any of them does not exist.
+func (sys *IAMSys) combinePolicies(policyNames []string) (combinedPolicy iampolicy.Policy, ok bool) {
+	ok = true
+	for _, name := range policyNames {
+		p, found := sys.iamPolicyDocsMap[name]
+		if !found {
+			ok = false
+			continue
+		}
+		combinedPolicy.Statements = append(combinedPolicy.Statements, p.Statements...)
+	}
+	return combinedPolicy, ok
 }
 
 var defaultContextTimeout = 30 * time.Second
@@ -1403,6 +1824,64 @@ func loadEtcdPolicies(policyDocsMap map[string]iampolicy.Policy) error {
 	return nil
 }
 
+func loadEtcdGroup(ctx context.Context, group string, m map[string]GroupInfo) error {
+	var g GroupInfo
+	err := loadIAMConfigItemEtcd(ctx, &g, getGroupInfoPath(group))
+	if err != nil {
+		return err
+	}
+	m[group] = g
+	return nil
+}
+
+func loadEtcdGroups(m map[string]GroupInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	r, err := globalEtcdClient.Get(ctx, iamConfigGroupsPrefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+
+	groups := etcdKvsToSet(iamConfigGroupsPrefix, r.Kvs)
+
+	// Reload members of all groups.
+	for _, group := range groups.ToSlice() {
+		if err = loadEtcdGroup(ctx, group, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadEtcdGroupMappedPolicy(ctx context.Context, group string, m map[string]MappedPolicy) error {
+	var p MappedPolicy
+	err := loadIAMConfigItemEtcd(ctx, &p, getGroupMappedPolicyPath(group))
+	if err != nil {
+		return err
+	}
+	m[group] = p
+	return nil
+}
+
+func loadEtcdGroupMappedPolicies(m map[string]MappedPolicy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	r, err := globalEtcdClient.Get(ctx, iamConfigPolicyDBGroupsPrefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+
+	groups := etcdKvsToSetPolicyDB(iamConfigPolicyDBGroupsPrefix, r.Kvs)
+
+	// Reload policies of all groups.
+	for _, group := range groups.ToSlice() {
+		if err = loadEtcdGroupMappedPolicy(ctx, group, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set default canned policies only if not already overridden by users.
 func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	_, ok := policies["writeonly"]
@@ -1423,6 +1902,8 @@ func (sys *IAMSys) refreshEtcd() error {
 	iamUsersMap := make(map[string]auth.Credentials)
 	iamPolicyDocsMap := make(map[string]iampolicy.Policy)
 	iamUserPolicyMap := make(map[string]MappedPolicy)
+	iamGroupsMap := make(map[string]GroupInfo)
+	iamGroupPolicyMap := make(map[string]MappedPolicy)
 
 	if err := loadEtcdPolicies(iamPolicyDocsMap); err != nil {
 		return err
@@ -1441,6 +1922,12 @@ func (sys *IAMSys) refreshEtcd() error {
 	if err := loadEtcdMappedPolicies(true, iamUserPolicyMap); err != nil {
 		return err
 	}
+	if err := loadEtcdGroups(iamGroupsMap); err != nil {
+		return err
+	}
+	if err := loadEtcdGroupMappedPolicies(iamGroupPolicyMap); err != nil {
+		return err
+	}
 
 	// Sets default canned policies, if none are set.
 	setDefaultCannedPolicies(iamPolicyDocsMap)
@@ -1451,6 +1938,9 @@ func (sys *IAMSys) refreshEtcd() error {
 	sys.iamUsersMap = iamUsersMap
 	sys.iamUserPolicyMap = iamUserPolicyMap
 	sys.iamPolicyDocsMap = iamPolicyDocsMap
+	sys.iamGroupsMap = iamGroupsMap
+	sys.iamUserGroupMemberships = getUserGroupMemberships(iamGroupsMap)
+	sys.iamGroupPolicyMap = iamGroupPolicyMap
 
 	return nil
 }
@@ -1460,6 +1950,8 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 	iamUsersMap := make(map[string]auth.Credentials)
 	iamPolicyDocsMap := make(map[string]iampolicy.Policy)
 	iamUserPolicyMap := make(map[string]MappedPolicy)
+	iamGroupsMap := make(map[string]GroupInfo)
+	iamGroupPolicyMap := make(map[string]MappedPolicy)
 
 	if err := loadPolicyDocs(objAPI, iamPolicyDocsMap); err != nil {
 		return err
@@ -1480,6 +1972,13 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 		return err
 	}
 
+	if err := loadGroups(objAPI, iamGroupsMap); err != nil {
+		return err
+	}
+	if err := loadGroupMappedPolicies(objAPI, iamGroupPolicyMap); err != nil {
+		return err
+	}
+
 	// Sets default canned policies, if none are set.
 	setDefaultCannedPolicies(iamPolicyDocsMap)
 
@@ -1489,6 +1988,9 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 	sys.iamUsersMap = iamUsersMap
 	sys.iamPolicyDocsMap = iamPolicyDocsMap
 	sys.iamUserPolicyMap = iamUserPolicyMap
+	sys.iamGroupsMap = iamGroupsMap
+	sys.iamUserGroupMemberships = getUserGroupMemberships(iamGroupsMap)
+	sys.iamGroupPolicyMap = iamGroupPolicyMap
 
 	return nil
 }
@@ -1496,8 +1998,11 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 // NewIAMSys - creates new config system object.
 func NewIAMSys() *IAMSys {
 	return &IAMSys{
-		iamUsersMap:      make(map[string]auth.Credentials),
-		iamPolicyDocsMap: make(map[string]iampolicy.Policy),
-		iamUserPolicyMap: make(map[string]MappedPolicy),
+		iamUsersMap:             make(map[string]auth.Credentials),
+		iamPolicyDocsMap:        make(map[string]iampolicy.Policy),
+		iamUserPolicyMap:        make(map[string]MappedPolicy),
+		iamGroupsMap:            make(map[string]GroupInfo),
+		iamUserGroupMemberships: make(map[string]set.StringSet),
+		iamGroupPolicyMap:       make(map[string]MappedPolicy),
 	}
 }
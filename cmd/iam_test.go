@@ -0,0 +1,142 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests group membership, group policies and their persistence.
+func TestIAMSysGroups(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	initNSLock(false)
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = objLayer
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+
+	sys := NewIAMSys()
+	if err = sys.Init(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range []string{"user1", "user2"} {
+		if err = sys.SetUser(user, madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = sys.AddUsersToGroup("devs", []string{"user1", "nouser"}); err != errNoSuchUser {
+		t.Fatalf("expected errNoSuchUser, got %v", err)
+	}
+	// Groups are saved in a directory of their name.
+	for _, group := range []string{"", ".", "..", "devs/ops", "/devs", "devs/"} {
+		if err = sys.AddUsersToGroup(group, []string{"user1"}); err != errInvalidArgument {
+			t.Fatalf("expected errInvalidArgument for group %q, got %v", group, err)
+		}
+	}
+	if err = sys.AddUsersToGroup("devs", []string{"user1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = sys.PolicyDBSet("devs", "nopolicy", true); err != errNoSuchPolicy {
+		t.Fatalf("expected errNoSuchPolicy, got %v", err)
+	}
+	if err = sys.PolicyDBSet("devs", "readonly", true); err != nil {
+		t.Fatal(err)
+	}
+
+	isAllowed := func(user string, action iampolicy.Action) bool {
+		return sys.IsAllowed(iampolicy.Args{
+			AccountName: user,
+			Action:      action,
+			BucketName:  "bucket",
+			ObjectName:  "object",
+		})
+	}
+
+	if !isAllowed("user1", iampolicy.GetObjectAction) || isAllowed("user1", iampolicy.PutObjectAction) {
+		t.Fatal("expected group policy to apply to its member")
+	}
+	if isAllowed("user2", iampolicy.GetObjectAction) {
+		t.Fatal("expected group policy to not apply to other users")
+	}
+
+	// Policies of the user and its groups apply together.
+	if err = sys.PolicyDBSet("user1", "writeonly", false); err != nil {
+		t.Fatal(err)
+	}
+	if !isAllowed("user1", iampolicy.GetObjectAction) || !isAllowed("user1", iampolicy.PutObjectAction) {
+		t.Fatal("expected user and group policies to apply")
+	}
+
+	// Policies of disabled groups do not apply.
+	if err = sys.SetGroupStatus("devs", madmin.GroupDisabled); err != nil {
+		t.Fatal(err)
+	}
+	if isAllowed("user1", iampolicy.GetObjectAction) || !isAllowed("user1", iampolicy.PutObjectAction) {
+		t.Fatal("expected policy of disabled group to not apply")
+	}
+
+	// Groups are loaded from the backend.
+	sys = NewIAMSys()
+	if err = sys.Init(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	gd, err := sys.GetGroupDescription("devs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gd.Status != madmin.GroupDisabled || gd.Policy != "readonly" || len(gd.Members) != 1 || gd.Members[0] != "user1" {
+		t.Fatalf("unexpected group description %+v", gd)
+	}
+	users, err := sys.ListUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memberOf := users["user1"].MemberOf; len(memberOf) != 1 || memberOf[0] != "devs" {
+		t.Fatalf("unexpected group memberships %v", memberOf)
+	}
+
+	// Only empty groups can be removed.
+	if err = sys.RemoveUsersFromGroup("devs", nil); err != errGroupNotEmpty {
+		t.Fatalf("expected errGroupNotEmpty, got %v", err)
+	}
+	if err = sys.DeleteUser("user1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sys.RemoveUsersFromGroup("devs", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sys.GetGroupDescription("devs"); err != errNoSuchGroup {
+		t.Fatalf("expected errNoSuchGroup, got %v", err)
+	}
+	if groups, err := sys.ListGroups(); err != nil || len(groups) != 0 {
+		t.Fatalf("expected no groups, got %v, %v", groups, err)
+	}
+}
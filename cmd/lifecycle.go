@@ -50,6 +50,29 @@ func (sys *LifecycleSys) Set(bucketName string, lifecycle lifecycle.Lifecycle) {
 	sys.bucketLifecycleMap[bucketName] = lifecycle
 }
 
+// Get - gets lifecycle config associated to a given bucket name.
+func (sys *LifecycleSys) Get(bucketName string) (lifecycle.Lifecycle, bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	lc, ok := sys.bucketLifecycleMap[bucketName]
+	return lc, ok
+}
+
+// validateLifecycleTiers - verifies that the storage classes of all
+// transitions name configured remote tiers.
+func validateLifecycleTiers(lc *lifecycle.Lifecycle) error {
+	for _, rule := range lc.Rules {
+		if rule.Transition.IsEmpty() {
+			continue
+		}
+		if _, ok := globalServerConfig.GetTier(rule.Transition.StorageClass); !ok {
+			return RemoteTierNotFound{Tier: rule.Transition.StorageClass}
+		}
+	}
+	return nil
+}
+
 func saveLifecycleConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, bucketLifecycle *lifecycle.Lifecycle) error {
 	data, err := xml.Marshal(bucketLifecycle)
 	if err != nil {
@@ -80,7 +103,7 @@ func removeLifecycleConfig(ctx context.Context, objAPI ObjectLayer, bucketName s
 	// Construct path to lifecycle.xml for the given bucket.
 	configFile := path.Join(bucketConfigPrefix, bucketName, bucketLifecycleConfig)
 
-	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
 		if _, ok := err.(ObjectNotFound); ok {
 			return BucketLifecycleNotFound{Bucket: bucketName}
 		}
@@ -20,26 +20,63 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	gohttp "net/http"
 
 	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/event/target"
 )
 
+// File extension of queued log entries.
+const logEntryExt = ".log"
+
 // Target implements logger.Target and sends the json
 // format of a log entry to the configured http endpoint.
 // An internal buffer of logs is maintained but when the
 // buffer is full, new logs are just ignored and an error
 // is returned to the caller.
+//
+// With a queue directory, log entries are persisted in the
+// directory instead, and only removed once the endpoint
+// accepted them. Entries are retried while the endpoint is
+// down, and entries left over by a restart are sent as well.
 type Target struct {
 	// Channel of log entries
 	logCh chan interface{}
 
+	// Persistent queue of log entries, nil if log entries
+	// are only buffered in memory.
+	store target.Store
+
 	// HTTP(s) endpoint
 	endpoint string
 	client   gohttp.Client
 }
 
+// send posts a json log entry to the endpoint.
+func (h *Target) send(logJSON []byte) error {
+	req, err := gohttp.NewRequest(http.MethodPost, h.endpoint, bytes.NewReader(logJSON))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	// Any response other than success keeps the log entry queued.
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s returned '%s'", h.endpoint, resp.Status)
+	}
+	return nil
+}
+
 func (h *Target) startHTTPLogger() {
 	// Create a routine which sends json logs received
 	// from an internal channel.
@@ -50,40 +87,64 @@ func (h *Target) startHTTPLogger() {
 				continue
 			}
 
-			req, err := gohttp.NewRequest(http.MethodPost, h.endpoint, bytes.NewBuffer(logJSON))
-			if err != nil {
-				continue
-			}
-			req.Header.Set(xhttp.ContentType, "application/json")
-
-			resp, err := h.client.Do(req)
-			if err != nil {
-				continue
-			}
-
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
+			h.send(logJSON)
 		}
 	}()
 }
 
+// sendQueued sends the queued log entry with the given key, the
+// entry is removed once the endpoint accepted it.
+func (h *Target) sendQueued(key string) error {
+	var logJSON json.RawMessage
+	if err := h.store.Get(key, &logJSON); err != nil {
+		// Entries which can not be read are removed by the
+		// store, entries already sent do not exist anymore.
+		return nil
+	}
+
+	if err := h.send(logJSON); err != nil {
+		// The entry is retried until the endpoint accepts it.
+		return target.ErrNotConnected
+	}
+	return h.store.Del(key)
+}
+
 // New initializes a new logger target which
-// sends log over http to the specified endpoint
-func New(endpoint string, transport *gohttp.Transport) *Target {
+// sends log over http to the specified endpoint.
+// Log entries are queued in queueDir if it is set,
+// holding at most queueLimit entries.
+func New(endpoint string, transport *gohttp.Transport, queueDir string, queueLimit uint64) (*Target, error) {
 	h := Target{
 		endpoint: endpoint,
 		client: gohttp.Client{
 			Transport: transport,
 		},
-		logCh: make(chan interface{}, 10000),
 	}
 
-	h.startHTTPLogger()
-	return &h
+	if queueDir == "" {
+		h.logCh = make(chan interface{}, 10000)
+		h.startHTTPLogger()
+		return &h, nil
+	}
+
+	h.store = target.NewQueueStore(queueDir, queueLimit, logEntryExt)
+	if err := h.store.Open(); err != nil {
+		return nil, err
+	}
+
+	// Replays the queued log entries for the lifetime of the
+	// process, like the queued events of notification targets.
+	go target.SendItems(h.sendQueued, target.ReplayItems(h.store, nil), nil)
+	return &h, nil
 }
 
 // Send log message 'e' to http target.
 func (h *Target) Send(entry interface{}) error {
+	if h.store != nil {
+		_, err := h.store.Put(entry)
+		return err
+	}
+
 	select {
 	case h.logCh <- entry:
 	default:
@@ -0,0 +1,105 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Interval between retries of queued log entries.
+const retryInterval = 3 * time.Second
+
+func TestQueuedLogEntries(t *testing.T) {
+	queueDir, err := ioutil.TempDir("", "minio-logger-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(queueDir)
+
+	// Entries which can not be read are removed.
+	if err = ioutil.WriteFile(filepath.Join(queueDir, "broken"+logEntryExt), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var received []string
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		received = append(received, string(data))
+	}))
+	defer server.Close()
+
+	// Entries are kept while the endpoint is down.
+	target, err := New(server.URL, &http.Transport{}, queueDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"first", "second"} {
+		if err = target.Send(map[string]string{"entry": entry}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if keys := target.store.List(); len(keys) != 2 {
+		t.Fatalf("expected 2 queued entries, got %d", len(keys))
+	}
+
+	// Entries are kept while the endpoint rejects them.
+	mu.Lock()
+	status = http.StatusForbidden
+	mu.Unlock()
+	time.Sleep(retryInterval + 100*time.Millisecond)
+	if keys := target.store.List(); len(keys) != 2 {
+		t.Fatalf("expected 2 queued entries, got %d", len(keys))
+	}
+
+	// Entries are replayed once the endpoint accepts them again.
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	deadline := time.Now().Add(2 * retryInterval)
+	for len(target.store.List()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("queued entries were not sent")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(received)
+	expected := []string{`{"entry":"first"}`, `{"entry":"second"}`}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected %v, got %v", expected, received)
+	}
+}
@@ -34,6 +34,15 @@ var (
 		},
 		[]string{"request_type"},
 	)
+	lifecycleActionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "minio",
+			Subsystem: "lifecycle",
+			Name:      "actions_total",
+			Help:      "Total number of lifecycle actions applied by current MinIO server instance",
+		},
+		[]string{"action"},
+	)
 	minioVersionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "minio",
@@ -53,6 +62,7 @@ func init() {
 	prometheus.MustRegister(httpRequestsDuration)
 	prometheus.MustRegister(newMinioCollector())
 	prometheus.MustRegister(minioVersionInfo)
+	prometheus.MustRegister(lifecycleActionsTotal)
 }
 
 // newMinioCollector describes the collector
@@ -196,6 +206,57 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(offlineDisks),
 	)
+
+	// Expose the data usage computed by the data usage crawler
+	dataUsageMetricsPrometheus(ch, objLayer)
+}
+
+// dataUsageMetricsPrometheus - exposes the number of objects, their
+// total size and the histogram of their sizes per bucket.
+func dataUsageMetricsPrometheus(ch chan<- prometheus.Metric, objLayer ObjectLayer) {
+	dataUsageInfo, err := loadDataUsageFromBackend(context.Background(), objLayer)
+	if err != nil {
+		return
+	}
+
+	// Data usage was never computed yet.
+	if dataUsageInfo.LastUpdate.IsZero() {
+		return
+	}
+
+	for bucket, usageInfo := range dataUsageInfo.BucketsUsage {
+		// Total space used by bucket
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "usage_size"),
+				"Total bucket size",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(usageInfo.Size),
+			bucket,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "objects_count"),
+				"Total number of objects in a bucket",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(usageInfo.ObjectsCount),
+			bucket,
+		)
+		for k, v := range usageInfo.ObjectsSizesHistogram {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bucket", "objects_histogram"),
+					"Total number of objects of different sizes in a bucket",
+					[]string{"bucket", "object_size"}, nil),
+				prometheus.GaugeValue,
+				float64(v),
+				bucket,
+				k,
+			)
+		}
+	}
 }
 
 func metricsHandler() http.Handler {
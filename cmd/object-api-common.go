@@ -39,6 +39,10 @@ const (
 
 	// ETag (hex encoded md5sum) of empty string.
 	emptyETag = "d41d8cd98f00b204e9800998ecf8427e"
+
+	// Metadata key of the bucket and object name of a multipart upload,
+	// only saved with the upload and removed when it is completed.
+	multipartObjectKey = ReservedMetadataPrefix + "multipart-object"
 )
 
 // Global object layer mutex, used for safely updating object layer.
@@ -55,6 +59,15 @@ func init() {
 	globalObjLayerMutex = &sync.RWMutex{}
 }
 
+// Returns the bucket and object name saved with a multipart upload.
+func getMultipartObject(metadata map[string]string) (bucket, object string) {
+	name := metadata[multipartObjectKey]
+	if i := strings.Index(name, slashSeparator); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", ""
+}
+
 // Checks if the object is a directory, this logic uses
 // if size == 0 and object ends with slashSeparator then
 // returns true.
@@ -94,6 +107,9 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete listener config, if present - ignore any errors.
 	removeListenerConfig(ctx, objAPI, bucket)
+
+	// Delete versioning config, if present - ignore any errors.
+	removeVersioningConfig(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
@@ -226,14 +242,16 @@ func removeNotificationConfig(ctx context.Context, objAPI ObjectLayer, bucket st
 	}
 
 	ncPath := path.Join(bucketConfigPrefix, bucket, bucketNotificationConfig)
-	return objAPI.DeleteObject(ctx, minioMetaBucket, ncPath)
+	_, err := objAPI.DeleteObject(ctx, minioMetaBucket, ncPath, ObjectOptions{})
+	return err
 }
 
 // Remove listener configuration from storage layer. Used when a bucket is deleted.
 func removeListenerConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
 	// make the path
 	lcPath := path.Join(bucketConfigPrefix, bucket, bucketListenerConfig)
-	return objAPI.DeleteObject(ctx, minioMetaBucket, lcPath)
+	_, err := objAPI.DeleteObject(ctx, minioMetaBucket, lcPath, ObjectOptions{})
+	return err
 }
 
 func listObjectsNonSlash(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, delimiter string, maxKeys int, tpool *TreeWalkPool, listDir ListDirFunc, getObjInfo func(context.Context, string, string) (ObjectInfo, error), getObjectInfoDirs ...func(context.Context, string, string) (ObjectInfo, error)) (loi ListObjectsInfo, err error) {
@@ -100,7 +100,7 @@ func testDeleteObject(obj ObjectLayer, instanceType string, t TestErrHandler) {
 		}
 
 		// TODO: check the error in the future
-		_ = obj.DeleteObject(context.Background(), testCase.bucketName, testCase.pathToDelete)
+		_, _ = obj.DeleteObject(context.Background(), testCase.bucketName, testCase.pathToDelete, ObjectOptions{})
 
 		result, err := obj.ListObjects(context.Background(), testCase.bucketName, "", "", "", 1000)
 		if err != nil {
@@ -146,8 +146,9 @@ func (e InsufficientWriteQuorum) Error() string {
 
 // GenericError - generic object layer error.
 type GenericError struct {
-	Bucket string
-	Object string
+	Bucket    string
+	Object    string
+	VersionID string
 }
 
 // BucketNotFound bucket does not exist.
@@ -185,6 +186,46 @@ func (e ObjectNotFound) Error() string {
 	return "Object not found: " + e.Bucket + "#" + e.Object
 }
 
+// VersionNotFound object version does not exist.
+type VersionNotFound GenericError
+
+func (e VersionNotFound) Error() string {
+	return "Version not found: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ")"
+}
+
+// MethodNotAllowed on a delete marker.
+type MethodNotAllowed GenericError
+
+func (e MethodNotAllowed) Error() string {
+	return "Method not allowed: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ") is a delete marker"
+}
+
+// ObjectLocked object version is protected by a retention
+// period or a legal hold.
+type ObjectLocked GenericError
+
+func (e ObjectLocked) Error() string {
+	return "Object is WORM protected and cannot be overwritten or deleted: " + e.Bucket + "#" + e.Object
+}
+
+// RemoteTierNotFound no remote tier is configured with the name.
+type RemoteTierNotFound struct {
+	Tier string
+}
+
+func (e RemoteTierNotFound) Error() string {
+	return "Remote tier not configured: " + e.Tier
+}
+
+// ReplicationTargetNotFound no remote replication target is configured with the name.
+type ReplicationTargetNotFound struct {
+	Target string
+}
+
+func (e ReplicationTargetNotFound) Error() string {
+	return "Remote replication target not configured: " + e.Target
+}
+
 // ObjectAlreadyExists object already exists.
 type ObjectAlreadyExists GenericError
 
@@ -254,6 +295,48 @@ func (e BucketPolicyNotFound) Error() string {
 	return "No bucket policy found for bucket: " + e.Bucket
 }
 
+// BucketVersioningNotFound - no bucket versioning configuration found.
+type BucketVersioningNotFound GenericError
+
+func (e BucketVersioningNotFound) Error() string {
+	return "No bucket versioning configuration found for bucket: " + e.Bucket
+}
+
+// BucketObjectLockConfigNotFound - no bucket object lock configuration found.
+type BucketObjectLockConfigNotFound GenericError
+
+func (e BucketObjectLockConfigNotFound) Error() string {
+	return "No bucket object lock configuration found for bucket: " + e.Bucket
+}
+
+// BucketReplicationConfigNotFound - no bucket replication configuration found.
+type BucketReplicationConfigNotFound GenericError
+
+func (e BucketReplicationConfigNotFound) Error() string {
+	return "No bucket replication configuration found for bucket: " + e.Bucket
+}
+
+// BucketQuotaConfigNotFound - no bucket quota config found.
+type BucketQuotaConfigNotFound GenericError
+
+func (e BucketQuotaConfigNotFound) Error() string {
+	return "No quota config found for bucket : " + e.Bucket
+}
+
+// BucketQuotaExceeded - bucket quota exceeded.
+type BucketQuotaExceeded GenericError
+
+func (e BucketQuotaExceeded) Error() string {
+	return "Bucket quota exceeded for bucket: " + e.Bucket
+}
+
+// BucketSSEConfigNotFound - no bucket encryption configuration found.
+type BucketSSEConfigNotFound GenericError
+
+func (e BucketSSEConfigNotFound) Error() string {
+	return "No bucket encryption configuration found for bucket: " + e.Bucket
+}
+
 // BucketLifecycleNotFound - no bucket lifecycle found.
 type BucketLifecycleNotFound GenericError
 
@@ -433,6 +516,12 @@ func isErrObjectNotFound(err error) bool {
 	return ok
 }
 
+// isErrVersionNotFound - Check if error type is VersionNotFound.
+func isErrVersionNotFound(err error) bool {
+	_, ok := err.(VersionNotFound)
+	return ok
+}
+
 // PreConditionFailed - Check if copy precondition failed
 type PreConditionFailed struct{}
 
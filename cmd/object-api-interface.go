@@ -24,17 +24,32 @@ import (
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 	"github.com/minio/minio/pkg/lifecycle"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/sse"
+	"github.com/minio/minio/pkg/versioning"
 )
 
 // CheckCopyPreconditionFn returns true if copy precondition check failed.
 type CheckCopyPreconditionFn func(o ObjectInfo, encETag string) bool
 
+// CheckUpdatePreconditionFn returns true if the precondition of an update failed.
+type CheckUpdatePreconditionFn func(o ObjectInfo) bool
+
 // ObjectOptions represents object options for ObjectLayer operations
 type ObjectOptions struct {
 	ServerSideEncryption encrypt.ServerSide
 	UserDefined          map[string]string
 	CheckCopyPrecondFn   CheckCopyPreconditionFn
+	CheckUpdatePrecondFn CheckUpdatePreconditionFn // Checked against the object version under lock by UpdateObjectMetadata.
+
+	VersionID        string // Version of the object to operate on, empty means the latest version.
+	Versioned        bool   // Indicates if the bucket has versioning enabled.
+	VersionSuspended bool   // Indicates if the bucket has versioning suspended.
+	BypassGovernance bool   // Indicates if governance retention may be bypassed.
+
+	IndexCB func() []byte // Returns the block index of compressed object data, called once the data is read.
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -51,6 +66,7 @@ type ObjectLayer interface {
 	// Storage operations.
 	Shutdown(context.Context) error
 	StorageInfo(context.Context) StorageInfo
+	CrawlAndGetDataUsage(ctx context.Context, endCh <-chan struct{}) madmin.DataUsageInfo
 
 	// Bucket operations.
 	MakeBucketWithLocation(ctx context.Context, bucket string, location string) error
@@ -73,11 +89,15 @@ type ObjectLayer interface {
 	GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error)
-	DeleteObject(ctx context.Context, bucket, object string) error
-	DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error)
+	DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error)
+	DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error)
+	ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error)
+	UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	TransitionObject(ctx context.Context, bucket, object, tier string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 
 	// Multipart operations.
 	ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error)
+	ListAllMultipartUploads(ctx context.Context, bucket, prefix string) (uploads []MultipartInfo, err error)
 	NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error)
 	CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
 		startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (info PartInfo, err error)
@@ -113,4 +133,22 @@ type ObjectLayer interface {
 	SetBucketLifecycle(context.Context, string, *lifecycle.Lifecycle) error
 	GetBucketLifecycle(context.Context, string) (*lifecycle.Lifecycle, error)
 	DeleteBucketLifecycle(context.Context, string) error
+
+	// Versioning operations
+	SetBucketVersioning(context.Context, string, *versioning.Versioning) error
+	GetBucketVersioning(context.Context, string) (*versioning.Versioning, error)
+
+	// Object lock operations
+	SetBucketObjectLockConfig(context.Context, string, *objectlock.Config) error
+	GetBucketObjectLockConfig(context.Context, string) (*objectlock.Config, error)
+
+	// Replication operations
+	SetBucketReplication(context.Context, string, *replication.Config) error
+	GetBucketReplication(context.Context, string) (*replication.Config, error)
+	DeleteBucketReplication(context.Context, string) error
+
+	// Default encryption operations
+	SetBucketSSEConfig(context.Context, string, *sse.Config) error
+	GetBucketSSEConfig(context.Context, string) (*sse.Config, error)
+	DeleteBucketSSEConfig(context.Context, string) error
 }
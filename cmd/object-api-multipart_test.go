@@ -1985,3 +1985,61 @@ func BenchmarkPutObjectPart50MbFS(b *testing.B) {
 func BenchmarkPutObjectPart50MbXL(b *testing.B) {
 	benchmarkPutObjectPart(b, "XL", 50*humanize.MiByte)
 }
+
+// Wrapper for calling ListAllMultipartUploads tests for both XL multiple disks and single node setup.
+func TestListAllMultipartUploads(t *testing.T) {
+	ExecObjectLayerTest(t, testListAllMultipartUploads)
+}
+
+// Unit test for listing the multipart uploads of all objects with a prefix.
+func testListAllMultipartUploads(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	for _, bucket := range []string{"bucket", "other"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	uploadIDs := make(map[string]string)
+	for _, object := range []string{"uploads/a", "uploads/b", "data/c"} {
+		uploadID, err := obj.NewMultipartUpload(ctx, "bucket", object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		uploadIDs[object] = uploadID
+	}
+	if _, err := obj.NewMultipartUpload(ctx, "other", "uploads/d", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	uploads, err := obj.ListAllMultipartUploads(ctx, "bucket", "uploads/")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(uploads) != 2 {
+		t.Fatalf("%s: expected 2 uploads, got %v", instanceType, uploads)
+	}
+	for _, upload := range uploads {
+		if uploadIDs[upload.Object] != upload.UploadID || upload.Initiated.IsZero() {
+			t.Errorf("%s: unexpected upload %v", instanceType, upload)
+		}
+	}
+
+	// The name saved with an upload is not part of the completed object.
+	part, err := obj.PutObjectPart(ctx, "bucket", "data/c", uploadIDs["data/c"], 1,
+		mustGetPutObjReader(t, bytes.NewBufferString("data"), 4, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	objInfo, err := obj.CompleteMultipartUpload(ctx, "bucket", "data/c", uploadIDs["data/c"],
+		[]CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, ok := objInfo.UserDefined[multipartObjectKey]; ok {
+		t.Errorf("%s: expected the upload name not to be saved with the object", instanceType)
+	}
+	if uploads, err = obj.ListAllMultipartUploads(ctx, "bucket", ""); err != nil || len(uploads) != 2 {
+		t.Errorf("%s: expected 2 uploads, got %v, %v", instanceType, uploads, err)
+	}
+}
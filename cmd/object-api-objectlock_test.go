@@ -0,0 +1,319 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/objectlock"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+// Wrapper for calling object lock tests for both XL multiple disks and single node setup.
+func TestObjectLock(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectLock)
+}
+
+// Unit test for retention and legal hold enforcement of object versions.
+func testObjectLock(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := obj.SetBucketVersioning(ctx, bucket, &versioning.Versioning{Status: versioning.Enabled}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if err := obj.SetBucketObjectLockConfig(ctx, bucket, objectlock.NewConfig()); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err := obj.GetBucketObjectLockConfig(ctx, bucket); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	putObject := func(metadata map[string]string) ObjectInfo {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+			4, "", ""), ObjectOptions{Versioned: true, UserDefined: metadata})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return objInfo
+	}
+
+	retainUntil := objectlock.FormatRetainUntilDate(UTCNow().Add(time.Hour))
+	governed := putObject(map[string]string{
+		objectlock.AmzObjectLockMode:            string(objectlock.Governance),
+		objectlock.AmzObjectLockRetainUntilDate: retainUntil,
+	})
+	held := putObject(nil)
+
+	// Legal hold is set on an existing version.
+	objInfo, err := obj.UpdateObjectMetadata(ctx, bucket, object, map[string]string{
+		objectlock.AmzObjectLockLegalHold: string(objectlock.LegalHoldOn),
+	}, ObjectOptions{Versioned: true, VersionID: held.VersionID})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !objectlock.GetObjectLegalHoldMeta(objInfo.UserDefined).IsOn() {
+		t.Fatalf("%s: expected legal hold to be on", instanceType)
+	}
+
+	testCases := []struct {
+		versionID      string
+		bypass         bool
+		expectedLocked bool
+	}{
+		{governed.VersionID, false, true},
+		{held.VersionID, true, true},
+		{governed.VersionID, true, false},
+	}
+	for i, testCase := range testCases {
+		_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{
+			Versioned:        true,
+			VersionID:        testCase.versionID,
+			BypassGovernance: testCase.bypass,
+		})
+		if _, locked := err.(ObjectLocked); locked != testCase.expectedLocked {
+			t.Errorf("Test %d: %s: expected locked %v, got %v", i+1, instanceType, testCase.expectedLocked, err)
+		}
+	}
+
+	// Delete markers may always be created on top of locked versions.
+	marker, err := obj.DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !marker.DeleteMarker {
+		t.Fatalf("%s: expected a delete marker, got %+v", instanceType, marker)
+	}
+
+	// Removing the legal hold allows the version to be deleted.
+	if _, err = obj.UpdateObjectMetadata(ctx, bucket, object, map[string]string{
+		objectlock.AmzObjectLockLegalHold: "",
+	}, ObjectOptions{Versioned: true, VersionID: held.VersionID}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true, VersionID: held.VersionID}); err != nil {
+		t.Errorf("%s: expected version without legal hold to be deleted, got %v", instanceType, err)
+	}
+}
+
+// Wrapper for calling in-place copy tests of locked objects for both XL multiple disks and single node setup.
+func TestObjectLockCopyInPlace(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectLockCopyInPlace)
+}
+
+// Unit test for metadata updates of an object copied onto itself under retention.
+func testObjectLockCopyInPlace(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	retainUntil := objectlock.FormatRetainUntilDate(UTCNow().Add(time.Hour))
+	_, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+		4, "", ""), ObjectOptions{UserDefined: map[string]string{
+		objectlock.AmzObjectLockMode:            string(objectlock.Compliance),
+		objectlock.AmzObjectLockRetainUntilDate: retainUntil,
+	}})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	copyInPlace := func(metadata map[string]string) (ObjectInfo, error) {
+		srcInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		srcInfo.UserDefined = metadata
+		srcInfo.metadataOnly = true
+		return obj.CopyObject(ctx, bucket, object, bucket, object, srcInfo, ObjectOptions{}, ObjectOptions{BypassGovernance: true})
+	}
+
+	// Removing the retention is denied.
+	if _, err = copyInPlace(map[string]string{"content-type": "text/plain"}); err == nil {
+		t.Fatalf("%s: expected the retention change to be denied", instanceType)
+	}
+
+	// Keeping the retention allows the metadata to be replaced.
+	if _, err = copyInPlace(map[string]string{
+		"content-type":                          "text/plain",
+		objectlock.AmzObjectLockMode:            string(objectlock.Compliance),
+		objectlock.AmzObjectLockRetainUntilDate: retainUntil,
+	}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if objInfo.ContentType != "text/plain" {
+		t.Errorf("%s: expected content type text/plain, got %s", instanceType, objInfo.ContentType)
+	}
+	if mode := objectlock.GetObjectRetentionMeta(objInfo.UserDefined).Mode; mode != objectlock.Compliance {
+		t.Errorf("%s: expected compliance retention, got %q", instanceType, mode)
+	}
+}
+
+// Tests keeping the retention and legal hold of an object updated in place.
+func TestUpdateObjectLockMetadata(t *testing.T) {
+	current := map[string]string{
+		objectlock.AmzObjectLockMode:            string(objectlock.Governance),
+		objectlock.AmzObjectLockRetainUntilDate: "2030-01-01T00:00:00.000Z",
+		objectlock.AmzObjectLockLegalHold:       string(objectlock.LegalHoldOn),
+	}
+	metadata := map[string]string{
+		"content-type":               "text/plain",
+		objectlock.AmzObjectLockMode: string(objectlock.Compliance),
+	}
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3Err := updateObjectLockMetadata(r, "bucket", "object", current, metadata); s3Err != ErrNone {
+		t.Fatalf("unexpected error %v", s3Err)
+	}
+	for k, v := range current {
+		if metadata[k] != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, metadata[k])
+		}
+	}
+	if metadata["content-type"] != "text/plain" {
+		t.Errorf("expected the metadata of the update to be kept")
+	}
+}
+
+// Wrapper for calling legacy WORM overwrite tests for both XL multiple disks and single node setup.
+func TestObjectLockWORM(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectLockWORM)
+}
+
+// Unit test for objects protected by the legacy global WORM mode.
+func testObjectLockWORM(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	putObject := func() error {
+		_, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+			4, "", ""), ObjectOptions{})
+		return err
+	}
+
+	globalWORMEnabled = true
+	defer func() { globalWORMEnabled = false }()
+
+	if err := putObject(); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, ok := putObject().(ObjectAlreadyExists); !ok {
+		t.Errorf("%s: expected ObjectAlreadyExists", instanceType)
+	}
+}
+
+// Tests the overwrite protection of legacy WORM mode and object lock.
+func TestEnforceRetentionForOverwrite(t *testing.T) {
+	defer func() { globalWORMEnabled = false }()
+
+	retainUntil := objectlock.FormatRetainUntilDate(UTCNow().Add(time.Hour))
+	unlocked := ObjectInfo{VersionID: "null"}
+	governed := ObjectInfo{VersionID: "null", UserDefined: map[string]string{
+		objectlock.AmzObjectLockMode:            string(objectlock.Governance),
+		objectlock.AmzObjectLockRetainUntilDate: retainUntil,
+	}}
+
+	testCases := []struct {
+		worm           bool
+		opts           ObjectOptions
+		latest         ObjectInfo
+		expectedLocked bool
+		expectedExists bool
+	}{
+		// Object lock protects only the version which is overwritten.
+		{false, ObjectOptions{}, unlocked, false, false},
+		{false, ObjectOptions{}, governed, true, false},
+		{false, ObjectOptions{BypassGovernance: true}, governed, false, false},
+		{false, ObjectOptions{Versioned: true}, governed, false, false},
+		// WORM denies every overwrite, also in versioned buckets and with bypass.
+		{true, ObjectOptions{}, unlocked, false, true},
+		{true, ObjectOptions{Versioned: true}, unlocked, false, true},
+		{true, ObjectOptions{BypassGovernance: true}, governed, false, true},
+		{true, ObjectOptions{}, ObjectInfo{DeleteMarker: true}, false, false},
+	}
+
+	for i, testCase := range testCases {
+		globalWORMEnabled = testCase.worm
+		err := enforceRetentionForOverwrite("bucket", "object", testCase.opts, func() (ObjectInfo, error) {
+			return testCase.latest, nil
+		})
+		if _, locked := err.(ObjectLocked); locked != testCase.expectedLocked {
+			t.Errorf("Test %d: expected locked %v, got %v", i+1, testCase.expectedLocked, err)
+		}
+		if _, exists := err.(ObjectAlreadyExists); exists != testCase.expectedExists {
+			t.Errorf("Test %d: expected already exists %v, got %v", i+1, testCase.expectedExists, err)
+		}
+	}
+}
+
+// Tests validating changes to the retention of an object.
+func TestIsRetentionChangeAllowed(t *testing.T) {
+	now := UTCNow()
+	retention := func(mode objectlock.Mode, d time.Duration) objectlock.ObjectRetention {
+		return objectlock.ObjectRetention{
+			Mode:            mode,
+			RetainUntilDate: objectlock.RetentionDate{Time: now.Add(d)},
+		}
+	}
+
+	testCases := []struct {
+		current         objectlock.ObjectRetention
+		retention       objectlock.ObjectRetention
+		bypass          bool
+		expectedAllowed bool
+	}{
+		// No retention in effect.
+		{objectlock.ObjectRetention{}, retention(objectlock.Compliance, time.Hour), false, true},
+		{retention(objectlock.Compliance, -time.Hour), objectlock.ObjectRetention{}, false, true},
+		// Extending retention is always allowed.
+		{retention(objectlock.Compliance, time.Hour), retention(objectlock.Compliance, 2*time.Hour), false, true},
+		{retention(objectlock.Governance, time.Hour), retention(objectlock.Governance, 2*time.Hour), false, true},
+		// Shortening, removing or changing the mode of retention in effect.
+		{retention(objectlock.Compliance, 2*time.Hour), retention(objectlock.Compliance, time.Hour), true, false},
+		{retention(objectlock.Compliance, time.Hour), objectlock.ObjectRetention{}, true, false},
+		{retention(objectlock.Governance, 2*time.Hour), retention(objectlock.Governance, time.Hour), false, false},
+		{retention(objectlock.Governance, time.Hour), retention(objectlock.Compliance, 2*time.Hour), false, false},
+		{retention(objectlock.Governance, 2*time.Hour), objectlock.ObjectRetention{}, true, true},
+	}
+
+	for i, testCase := range testCases {
+		allowed := isRetentionChangeAllowed(testCase.current, testCase.retention, testCase.bypass)
+		if allowed != testCase.expectedAllowed {
+			t.Errorf("Test %d: expected allowed %v, got %v", i+1, testCase.expectedAllowed, allowed)
+		}
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/wildcard"
+	"github.com/minio/sio"
 	"github.com/skyrings/skyring-common/tools/uuid"
 )
 
@@ -251,6 +252,22 @@ func cleanMetadataKeys(metadata map[string]string, keyNames ...string) map[strin
 	return newMeta
 }
 
+// mergeObjectMetadata - merges src into the object metadata dst and
+// returns it, keys with empty values in src are removed from dst.
+func mergeObjectMetadata(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string)
+	}
+	for k, v := range src {
+		if v == "" {
+			delete(dst, k)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
 // Extracts etag value from the metadata.
 func extractETag(metadata map[string]string) string {
 	// md5Sum tag is kept for backward compatibility.
@@ -354,7 +371,7 @@ func (o ObjectInfo) GetActualSize() int64 {
 // Using compression and encryption together enables room for side channel attacks.
 // Eliminate non-compressible objects by extensions/content-types.
 func isCompressible(header http.Header, object string) bool {
-	if hasServerSideEncryptionHeader(header) || excludeForCompression(header, object) {
+	if (hasServerSideEncryptionHeader(header) && !globalCompressAllowEncryption) || excludeForCompression(header, object) {
 		return false
 	}
 	return true
@@ -413,16 +430,23 @@ func getPartFile(entries []string, partNumber int, etag string) string {
 	return ""
 }
 
-// Returs the compressed offset which should be skipped.
+// Returs the compressed offset which should be skipped. For encrypted
+// objects the offset refers to the decrypted, compressed data.
 func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (int64, int64) {
 	var compressedOffset int64
 	var skipLength int64
 	var cumulativeActualSize int64
 	if len(objectInfo.Parts) > 0 {
+		isEncrypted := isEncryptedMultipart(objectInfo)
 		for _, part := range objectInfo.Parts {
 			cumulativeActualSize += part.ActualSize
 			if cumulativeActualSize <= offset {
-				compressedOffset += part.Size
+				partSize := part.Size
+				if isEncrypted {
+					decPartSize, _ := sio.DecryptedSize(uint64(part.Size))
+					partSize = int64(decPartSize)
+				}
+				compressedOffset += partSize
 			} else {
 				skipLength = cumulativeActualSize - part.ActualSize
 				break
@@ -432,6 +456,48 @@ func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (int64, int64) {
 	return compressedOffset, offset - skipLength
 }
 
+// getCompressedRange returns the offset of the compressed data to read
+// for the range of a compressed object, and the offset and the length of
+// the range in the decompressed data read from there. seekBlock reports
+// whether the compressed data is read from an indexed block in the
+// middle of the snappy stream.
+func (o ObjectInfo) getCompressedRange(rs *HTTPRangeSpec) (off, decOff, decLength int64, seekBlock bool, err error) {
+	// Read the decompressed size from the meta.json.
+	actualSize := o.GetActualSize()
+	if actualSize < 0 {
+		return 0, 0, 0, false, errInvalidDecompressedSize
+	}
+	if rs == nil {
+		return 0, 0, actualSize, false, nil
+	}
+
+	off, decLength, err = rs.GetOffsetLength(actualSize)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	// Incase of range based queries on multiparts, the offset and length are reduced.
+	off, decOff = getCompressedOffsets(o, off)
+
+	// For negative length we read everything.
+	if decLength < 0 {
+		decLength = actualSize - decOff
+	}
+
+	// Reply back invalid range if the input offset and length fall out of range.
+	if decOff > actualSize || decOff+decLength > actualSize {
+		return 0, 0, 0, false, errInvalidRange
+	}
+
+	// Start decompressing at the nearest indexed block, if any.
+	if index := o.getCompressionIndex(); index != nil {
+		blockOff, blockCompOff := index.find(decOff)
+		off += blockCompOff
+		decOff -= blockOff
+		seekBlock = blockCompOff > 0
+	}
+	return off, decOff, decLength, seekBlock, nil
+}
+
 // byBucketName is a collection satisfying sort.Interface.
 type byBucketName []BucketInfo
 
@@ -499,6 +565,76 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 	// Calculate range to read (different for
 	// e.g. encrypted/compressed objects)
 	switch {
+	case isEncrypted && isCompressed:
+		// The object is compressed before it is encrypted, so the
+		// range of the decompressed object is translated to a range
+		// of the compressed object, which is translated to a range
+		// of the encrypted object.
+		var compOff, decOff, decLength int64
+		var seekBlock bool
+		compOff, decOff, decLength, seekBlock, err = oi.getCompressedRange(rs)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		var compRange *HTTPRangeSpec
+		if rs != nil {
+			compRange = &HTTPRangeSpec{Start: compOff, End: -1}
+		}
+		var seqNumber uint32
+		var partStart int
+		off, length, skipLen, seqNumber, partStart, err = oi.GetDecryptedRange(compRange)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		fn = func(inputReader io.Reader, h http.Header, pcfn CheckCopyPreconditionFn, cFns ...func()) (r *GetObjectReader, err error) {
+			copySource := h.Get(crypto.SSECopyAlgorithm) != ""
+
+			cFns = append(cleanUpFns, cFns...)
+			// Attach decrypter on inputReader
+			var compReader io.Reader
+			compReader, err = DecryptBlocksRequestR(inputReader, h,
+				off, length, seqNumber, partStart, oi, copySource)
+			if err != nil {
+				// Call the cleanup funcs
+				for i := len(cFns) - 1; i >= 0; i-- {
+					cFns[i]()
+				}
+				return nil, err
+			}
+			encETag := oi.ETag
+			oi.ETag = getDecryptedETag(h, oi, copySource) // Decrypt the ETag before top layer consumes this value.
+
+			if pcfn != nil {
+				if ok := pcfn(oi, encETag); ok {
+					// Call the cleanup funcs
+					for i := len(cFns) - 1; i >= 0; i-- {
+						cFns[i]()
+					}
+					return nil, PreConditionFailed{}
+				}
+			}
+
+			// Apply the skipLen on the decrypted stream
+			compReader = ioutil.NewSkipReader(compReader, skipLen)
+			if seekBlock {
+				// The stream is read from the middle.
+				compReader = io.MultiReader(bytes.NewReader(snappyStreamHeader), compReader)
+			}
+			// Apply the decOff and limit on the
+			// decompressed stream
+			decReader := io.LimitReader(ioutil.NewSkipReader(snappy.NewReader(compReader), decOff), decLength)
+			oi.Size = decLength
+
+			// Assemble the GetObjectReader
+			r = &GetObjectReader{
+				ObjInfo:    oi,
+				pReader:    decReader,
+				cleanUpFns: cFns,
+				precondFn:  pcfn,
+			}
+			return r, nil
+		}
 	case isEncrypted:
 		var seqNumber uint32
 		var partStart int
@@ -563,33 +699,13 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 			return r, nil
 		}
 	case isCompressed:
-		// Read the decompressed size from the meta.json.
-		actualSize := oi.GetActualSize()
-		if actualSize < 0 {
-			return nil, 0, 0, errInvalidDecompressedSize
-		}
-		off, length = int64(0), oi.Size
-		decOff, decLength := int64(0), actualSize
-		if rs != nil {
-			off, length, err = rs.GetOffsetLength(actualSize)
-			if err != nil {
-				return nil, 0, 0, err
-			}
-			// Incase of range based queries on multiparts, the offset and length are reduced.
-			off, decOff = getCompressedOffsets(oi, off)
-			decLength = length
-			length = oi.Size - off
-
-			// For negative length we read everything.
-			if decLength < 0 {
-				decLength = actualSize - decOff
-			}
-
-			// Reply back invalid range if the input offset and length fall out of range.
-			if decOff > actualSize || decOff+decLength > actualSize {
-				return nil, 0, 0, errInvalidRange
-			}
+		var decOff, decLength int64
+		var seekBlock bool
+		off, decOff, decLength, seekBlock, err = oi.getCompressedRange(rs)
+		if err != nil {
+			return nil, 0, 0, err
 		}
+		length = oi.Size - off
 		fn = func(inputReader io.Reader, _ http.Header, pcfn CheckCopyPreconditionFn, cFns ...func()) (r *GetObjectReader, err error) {
 			cFns = append(cleanUpFns, cFns...)
 			if pcfn != nil {
@@ -601,6 +717,10 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 					return nil, PreConditionFailed{}
 				}
 			}
+			if seekBlock {
+				// The stream is read from the middle.
+				inputReader = io.MultiReader(bytes.NewReader(snappyStreamHeader), inputReader)
+			}
 			// Decompression reader.
 			snappyReader := snappy.NewReader(inputReader)
 			// Apply the skipLen and limit on the
@@ -767,32 +887,72 @@ type snappyCompressReader struct {
 	w      *snappy.Writer
 	closed bool
 	buf    bytes.Buffer
+
+	index        *compressionIndex
+	decompressed int64 // bytes written to w
+	compressed   int64 // bytes read out of buf
 }
 
 func newSnappyCompressReader(r io.Reader) *snappyCompressReader {
-	cr := &snappyCompressReader{r: r}
+	cr := &snappyCompressReader{r: r, index: newCompressionIndex()}
 	cr.w = snappy.NewBufferedWriter(&cr.buf)
 	return cr
 }
 
+// Index returns the encoded block index of the compressed stream, or
+// nil if the stream has not been read completely.
+func (cr *snappyCompressReader) Index() []byte {
+	if !cr.closed || len(cr.index.entries) == 0 {
+		return nil
+	}
+	data, _ := cr.index.MarshalBinary()
+	return data
+}
+
+// write writes p to the snappy writer, starting a new chunk at every
+// multiple of the index interval and adding it to the index.
+func (cr *snappyCompressReader) write(p []byte) error {
+	for len(p) > 0 {
+		n := cr.index.interval - cr.decompressed%cr.index.interval
+		if n > int64(len(p)) {
+			n = int64(len(p))
+		}
+		nw, err := cr.w.Write(p[:n])
+		if err != nil {
+			return err
+		}
+		if int64(nw) != n {
+			return io.ErrShortWrite
+		}
+		p = p[n:]
+		cr.decompressed += n
+
+		if cr.decompressed%cr.index.interval == 0 {
+			if err = cr.w.Flush(); err != nil {
+				return err
+			}
+			cr.index.add(cr.decompressed, cr.compressed+int64(cr.buf.Len()))
+		}
+	}
+	return nil
+}
+
 func (cr *snappyCompressReader) Read(p []byte) (int, error) {
 	if cr.closed {
 		// if snappy writer is closed r has been completely read,
 		// return any remaining data in buf.
-		return cr.buf.Read(p)
+		n, err := cr.buf.Read(p)
+		cr.compressed += int64(n)
+		return n, err
 	}
 
 	// read from original using p as buffer
 	nr, readErr := cr.r.Read(p)
 
 	// write read bytes to snappy writer
-	nw, err := cr.w.Write(p[:nr])
-	if err != nil {
+	if err := cr.write(p[:nr]); err != nil {
 		return 0, err
 	}
-	if nw != nr {
-		return 0, io.ErrShortWrite
-	}
 
 	// if last of data from reader, close snappy writer to flush
 	if readErr == io.EOF {
@@ -805,6 +965,7 @@ func (cr *snappyCompressReader) Read(p []byte) (int, error) {
 
 	// read compressed bytes out of buf
 	n, err := cr.buf.Read(p)
+	cr.compressed += int64(n)
 	if readErr != io.EOF && (err == nil || err == io.EOF) {
 		err = readErr
 	}
@@ -360,6 +360,13 @@ func TestExcludeForCompression(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			object: "object.json.zst",
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			result: true,
+		},
 		{
 			object: "object.txt",
 			header: http.Header{
@@ -0,0 +1,222 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+)
+
+// Versioned objects are laid out as follows
+//
+//  - the latest version of an object, which may be a delete marker,
+//    lives at its usual location `bucket/object`.
+//  - all noncurrent versions are moved into the version store at
+//    `.minio.sys/versions/bucket/object/<version-id>`, the null
+//    version is stored under the name `null`.
+//
+// Every key which has noncurrent versions therefore always has a
+// latest version, which keeps listing of versions a simple walk
+// over the bucket namespace.
+
+const (
+	// Version store prefix inside minioMetaBucket.
+	versionsMetaPrefix = "versions"
+
+	// Version ID reported for objects which were written
+	// while versioning was not enabled.
+	nullVersionID = "null"
+)
+
+// versionStorePath - returns the location of a noncurrent
+// object version inside minioMetaBucket.
+func versionStorePath(bucket, object, versionID string) string {
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	return pathJoin(versionsMetaPrefix, bucket, object, versionID)
+}
+
+// versionIDFromOpts - returns the version ID requested in the
+// object options, the null version is returned as an empty string.
+func versionIDFromOpts(opts ObjectOptions) string {
+	if opts.VersionID == nullVersionID {
+		return ""
+	}
+	return opts.VersionID
+}
+
+// isVersioningConfigured - returns true if versioning was ever
+// configured on the bucket the options were populated for.
+func (opts ObjectOptions) isVersioningConfigured() bool {
+	return opts.Versioned || opts.VersionSuspended
+}
+
+// newObjectVersionID - returns the version ID for a new object
+// version, buckets without versioning enabled get the null version.
+func newObjectVersionID(opts ObjectOptions) string {
+	if opts.Versioned {
+		return mustGetUUID()
+	}
+	return ""
+}
+
+// sortObjectVersions - sorts object versions newest first.
+func sortObjectVersions(versions []ObjectInfo) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].ModTime.After(versions[j].ModTime)
+	})
+}
+
+// filterDeleteMarkers - removes delete markers from a regular
+// object listing, they are only visible through list versions.
+func filterDeleteMarkers(loi ListObjectsInfo) ListObjectsInfo {
+	objects := loi.Objects[:0]
+	for _, object := range loi.Objects {
+		if object.DeleteMarker {
+			continue
+		}
+		objects = append(objects, object)
+	}
+	loi.Objects = objects
+	return loi
+}
+
+// listObjectVersions - lists all versions of all objects in a bucket,
+// listFn is expected to list the latest versions of all keys including
+// delete markers, latestFn returns the latest version of a single key
+// and versionsFn returns the noncurrent versions of a key sorted newest
+// first.
+func listObjectVersions(marker, versionIDMarker string, maxKeys int,
+	listFn func(marker string, maxKeys int) (ListObjectsInfo, error),
+	latestFn func(object string) (ObjectInfo, error),
+	versionsFn func(object string) ([]ObjectInfo, error)) (result ListObjectVersionsInfo, err error) {
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return result, nil
+	}
+
+	var count int
+	var lastKey, lastVersionID string
+
+	// addVersions - appends versions to the result, returns false
+	// once maxKeys is reached.
+	addVersions := func(versions []ObjectInfo) bool {
+		for _, version := range versions {
+			if count == maxKeys {
+				result.IsTruncated = true
+				result.NextMarker = lastKey
+				result.NextVersionIDMarker = lastVersionID
+				return false
+			}
+			result.Objects = append(result.Objects, version)
+			lastKey = version.Name
+			lastVersionID = version.VersionID
+			if lastVersionID == "" {
+				lastVersionID = nullVersionID
+			}
+			count++
+		}
+		return true
+	}
+
+	// allVersions - returns all versions of a key given its latest version.
+	allVersions := func(latest ObjectInfo) ([]ObjectInfo, error) {
+		latest.IsLatest = true
+		versions := []ObjectInfo{latest}
+		if latest.IsDir {
+			return versions, nil
+		}
+		noncurrent, err := versionsFn(latest.Name)
+		if err != nil {
+			return nil, err
+		}
+		return append(versions, noncurrent...), nil
+	}
+
+	// Continue with the remaining versions of the marker key.
+	if marker != "" && versionIDMarker != "" {
+		latest, err := latestFn(marker)
+		if err != nil {
+			if _, ok := err.(ObjectNotFound); !ok {
+				return result, err
+			}
+		} else {
+			versions, err := allVersions(latest)
+			if err != nil {
+				return result, err
+			}
+			for i, version := range versions {
+				versionID := version.VersionID
+				if versionID == "" {
+					versionID = nullVersionID
+				}
+				if versionID == versionIDMarker {
+					if !addVersions(versions[i+1:]) {
+						return result, nil
+					}
+					break
+				}
+			}
+		}
+	}
+
+	for {
+		loi, err := listFn(marker, maxObjectList)
+		if err != nil {
+			return result, err
+		}
+
+		// Merge objects and prefixes in lexical order.
+		objects, prefixes := loi.Objects, loi.Prefixes
+		for len(objects) > 0 || len(prefixes) > 0 {
+			if len(prefixes) > 0 && (len(objects) == 0 || prefixes[0] < objects[0].Name) {
+				if count == maxKeys {
+					result.IsTruncated = true
+					result.NextMarker = lastKey
+					result.NextVersionIDMarker = lastVersionID
+					return result, nil
+				}
+				result.Prefixes = append(result.Prefixes, prefixes[0])
+				lastKey, lastVersionID = prefixes[0], ""
+				prefixes = prefixes[1:]
+				count++
+				continue
+			}
+			versions, err := allVersions(objects[0])
+			if err != nil {
+				return result, err
+			}
+			if !addVersions(versions) {
+				return result, nil
+			}
+			objects = objects[1:]
+		}
+
+		if !loi.IsTruncated || loi.NextMarker == "" {
+			break
+		}
+		marker = loi.NextMarker
+	}
+
+	return result, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/pkg/versioning"
+)
+
+// Wrapper for calling object versioning tests for both XL multiple disks and single node setup.
+func TestObjectVersioning(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectVersioning)
+}
+
+// Unit test for put, get, delete and list of object versions.
+func testObjectVersioning(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	putObject := func(content string, opts ObjectOptions) ObjectInfo {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString(content),
+			int64(len(content)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return objInfo
+	}
+
+	getObject := func(versionID string) (string, error) {
+		var buf bytes.Buffer
+		err := obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{VersionID: versionID})
+		return buf.String(), err
+	}
+
+	// An object written before versioning is enabled becomes the null version.
+	putObject("null", ObjectOptions{})
+
+	bucketVersioning := &versioning.Versioning{Status: versioning.Enabled}
+	if err := obj.SetBucketVersioning(ctx, bucket, bucketVersioning); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	gotVersioning, err := obj.GetBucketVersioning(ctx, bucket)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !gotVersioning.Enabled() {
+		t.Fatalf("%s: expected versioning to be enabled", instanceType)
+	}
+
+	opts := ObjectOptions{Versioned: true}
+	v1 := putObject("v1", opts)
+	v2 := putObject("v2", opts)
+	if v1.VersionID == "" || v2.VersionID == "" || v1.VersionID == v2.VersionID {
+		t.Fatalf("%s: expected unique version IDs, got %q and %q", instanceType, v1.VersionID, v2.VersionID)
+	}
+
+	testCases := []struct {
+		versionID string
+		content   string
+	}{
+		{"", "v2"},
+		{v2.VersionID, "v2"},
+		{v1.VersionID, "v1"},
+		{nullVersionID, "null"},
+	}
+	for i, testCase := range testCases {
+		content, err := getObject(testCase.versionID)
+		if err != nil {
+			t.Fatalf("Test %d: %s: %s", i+1, instanceType, err)
+		}
+		if content != testCase.content {
+			t.Errorf("Test %d: %s: expected %q, got %q", i+1, instanceType, testCase.content, content)
+		}
+	}
+
+	if _, err = getObject("unknown"); !isErrVersionNotFound(err) {
+		t.Errorf("%s: expected VersionNotFound, got %v", instanceType, err)
+	}
+
+	// Deleting without a version ID creates a delete marker.
+	marker, err := obj.DeleteObject(ctx, bucket, object, opts)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !marker.DeleteMarker || marker.VersionID == "" {
+		t.Fatalf("%s: expected a delete marker, got %+v", instanceType, marker)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Errorf("%s: expected ObjectNotFound, got %v", instanceType, err)
+	}
+	loi, err := obj.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(loi.Objects) != 0 {
+		t.Errorf("%s: expected delete markers to be hidden from listing, got %d objects", instanceType, len(loi.Objects))
+	}
+
+	lvi, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	expectedVersions := []string{marker.VersionID, v2.VersionID, v1.VersionID, ""}
+	if len(lvi.Objects) != len(expectedVersions) {
+		t.Fatalf("%s: expected %d versions, got %d", instanceType, len(expectedVersions), len(lvi.Objects))
+	}
+	for i, versionID := range expectedVersions {
+		if lvi.Objects[i].VersionID != versionID {
+			t.Errorf("Test %d: %s: expected version %q, got %q", i+1, instanceType, versionID, lvi.Objects[i].VersionID)
+		}
+		if lvi.Objects[i].IsLatest != (i == 0) {
+			t.Errorf("Test %d: %s: unexpected IsLatest %v", i+1, instanceType, lvi.Objects[i].IsLatest)
+		}
+	}
+
+	// Paginate with max keys of one using the returned markers.
+	var keyMarker, versionIDMarker string
+	for i, versionID := range expectedVersions {
+		lvi, err = obj.ListObjectVersions(ctx, bucket, "", keyMarker, versionIDMarker, "", 1)
+		if err != nil {
+			t.Fatalf("Test %d: %s: %s", i+1, instanceType, err)
+		}
+		if len(lvi.Objects) != 1 || lvi.Objects[0].VersionID != versionID {
+			t.Fatalf("Test %d: %s: expected version %q, got %+v", i+1, instanceType, versionID, lvi.Objects)
+		}
+		keyMarker, versionIDMarker = lvi.NextMarker, lvi.NextVersionIDMarker
+	}
+
+	// Deleting the delete marker promotes the newest noncurrent version.
+	if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true, VersionID: marker.VersionID}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	content, err := getObject("")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if content != "v2" {
+		t.Errorf("%s: expected %q after removing the delete marker, got %q", instanceType, "v2", content)
+	}
+
+	// Permanently deleting a noncurrent version leaves the others intact.
+	if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{Versioned: true, VersionID: v1.VersionID}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = getObject(v1.VersionID); !isErrVersionNotFound(err) {
+		t.Errorf("%s: expected VersionNotFound, got %v", instanceType, err)
+	}
+	if content, err = getObject(nullVersionID); err != nil || content != "null" {
+		t.Errorf("%s: expected null version to be intact, got %q, %v", instanceType, content, err)
+	}
+}
@@ -247,27 +247,34 @@ func isETagEqual(left, right string) bool {
 // deleteObject is a convenient wrapper to delete an object, this
 // is a common function to be called from object handlers and
 // web handlers.
-func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, r *http.Request) (err error) {
+func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, r *http.Request, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	deleteObject := obj.DeleteObject
 	if cache != nil {
 		deleteObject = cache.DeleteObject
 	}
 	// Proceed to delete the object.
-	if err = deleteObject(ctx, bucket, object); err != nil {
-		return err
+	if objInfo, err = deleteObject(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
 	}
 
-	// Notify object deleted event.
+	// Notify object deleted event, or delete marker created event
+	// if deleting the latest version of a versioned object created
+	// a delete marker.
+	eventName := event.ObjectRemovedDelete
+	if objInfo.DeleteMarker && opts.VersionID == "" {
+		eventName = event.ObjectRemovedDeleteMarkerCreated
+	}
 	sendEvent(eventArgs{
-		EventName:  event.ObjectRemovedDelete,
+		EventName:  eventName,
 		BucketName: bucket,
 		Object: ObjectInfo{
-			Name: object,
+			Name:      object,
+			VersionID: objInfo.VersionID,
 		},
 		ReqParams: extractReqParams(r),
 		UserAgent: r.UserAgent(),
 		Host:      handlers.GetSourceIP(r),
 	})
 
-	return nil
+	return objInfo, nil
 }
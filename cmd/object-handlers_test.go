@@ -843,6 +843,133 @@ func testAPIGetObjectWithMPHandler(obj ObjectLayer, instanceType, bucketName str
 
 }
 
+// Wrapper for calling GetObject API handler tests of compressed and encrypted objects for both XL multiple disks and FS single drive setup.
+func TestAPIGetObjectCompressedWithEncryption(t *testing.T) {
+	globalPolicySys = NewPolicySys()
+	defer func() { globalPolicySys = nil }()
+
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectCompressedWithEncryption, []string{"NewMultipart", "PutObjectPart", "CompleteMultipart", "GetObject", "PutObject"})
+}
+
+func testAPIGetObjectCompressedWithEncryption(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	// Set SSL to on to do encryption tests
+	globalIsSSL = true
+	globalIsCompressionEnabled = true
+	defer func() {
+		globalIsSSL = false
+		globalIsCompressionEnabled = false
+		globalCompressAllowEncryption = false
+	}()
+
+	var (
+		oneMiB        int64 = 1024 * 1024
+		key32Bytes          = generateBytesData(32 * humanize.Byte)
+		key32BytesMd5       = md5.Sum(key32Bytes)
+		metaWithSSEC        = map[string]string{
+			crypto.SSECAlgorithm: crypto.SSEAlgorithmAES256,
+			crypto.SSECKey:       base64.StdEncoding.EncodeToString(key32Bytes),
+			crypto.SSECKeyMD5:    base64.StdEncoding.EncodeToString(key32BytesMd5[:]),
+		}
+	)
+
+	// Encrypted objects are not compressed without the opt-in.
+	uploadTestObject(t, apiRouter, credentials, bucketName, "enc-only.txt", []int64{509}, metaWithSSEC, false)
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, "enc-only.txt", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if objInfo.IsCompressed() {
+		t.Fatalf("%s: encrypted object is compressed without the opt-in", instanceType)
+	}
+
+	globalCompressAllowEncryption = true
+
+	objectInputs := []struct {
+		objectName  string
+		partLengths []int64
+	}{
+		{"small.txt", []int64{509}},
+		{"large.txt", []int64{3*oneMiB + 5}},
+		{"mp.txt", []int64{5*oneMiB + 1, 2*oneMiB + 7}},
+	}
+	for _, input := range objectInputs {
+		uploadTestObject(t, apiRouter, credentials, bucketName, input.objectName, input.partLengths, metaWithSSEC, false)
+	}
+
+	for _, input := range objectInputs {
+		objInfo, err = obj.GetObjectInfo(context.Background(), bucketName, input.objectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if !objInfo.IsCompressed() || !crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+			t.Fatalf("%s: Object %s is not compressed and encrypted", instanceType, input.objectName)
+		}
+
+		var objLen int64
+		for _, l := range input.partLengths {
+			objLen += l
+		}
+		if objInfo.GetActualSize() != objLen {
+			t.Fatalf("%s: Object %s: expected actual size %d, got %d", instanceType, input.objectName, objLen, objInfo.GetActualSize())
+		}
+
+		rangeHdrs := []string{
+			"",
+			"bytes=0-0",
+			"bytes=1-",
+			"bytes=-1",
+			fmt.Sprintf("bytes=%d-%d", objLen/4, objLen*3/4),
+			fmt.Sprintf("bytes=%d-%d", objLen*7/8, objLen-2),
+			fmt.Sprintf("bytes=-%d", objLen/2),
+		}
+		for i, rangeHdr := range rangeHdrs {
+			rec := httptest.NewRecorder()
+			req, err := newTestSignedRequestV4("GET", getGetObjectURL("", bucketName, input.objectName),
+				0, nil, credentials.AccessKey, credentials.SecretKey, metaWithSSEC)
+			if err != nil {
+				t.Fatalf("Object: %s Case %d: Failed to create HTTP request for Get Object: <ERROR> %v", input.objectName, i+1, err)
+			}
+			if rangeHdr != "" {
+				req.Header.Set("Range", rangeHdr)
+			}
+			apiRouter.ServeHTTP(rec, req)
+			if rec.Code != http.StatusPartialContent && rec.Code != http.StatusOK {
+				t.Fatalf("%s Object: %s Case %d ByteRange: %s: Got response status `%d` and body: %s",
+					instanceType, input.objectName, i+1, rangeHdr, rec.Code, rec.Body.String())
+			}
+
+			var rs *HTTPRangeSpec
+			if rangeHdr != "" {
+				if rs, err = parseRequestRangeSpec(rangeHdr); err != nil {
+					t.Fatalf("Object: %s Case %d: Unexpected err: %v", input.objectName, i+1, err)
+				}
+			}
+			off, length, err := rs.GetOffsetLength(objLen)
+			if err != nil {
+				t.Fatalf("Object: %s Case %d: Unexpected err: %v", input.objectName, i+1, err)
+			}
+			if rec.Header().Get("Content-Length") != strconv.FormatInt(length, 10) {
+				t.Fatalf("%s Object: %s Case %d ByteRange: %s: expected Content-Length %d, got %s",
+					instanceType, input.objectName, i+1, rangeHdr, length, rec.Header().Get("Content-Length"))
+			}
+
+			readers := []io.Reader{}
+			cumulativeSum := int64(0)
+			for _, p := range input.partLengths {
+				readers = append(readers, NewDummyDataGen(p, cumulativeSum))
+				cumulativeSum += p
+			}
+			refReader := io.LimitReader(ioutilx.NewSkipReader(io.MultiReader(readers...), off), length)
+			if ok, msg := cmpReaders(refReader, rec.Body); !ok {
+				t.Fatalf("(%s) Object: %s Case %d ByteRange: %s --> data mismatch! (msg: %s)", instanceType, input.objectName, i+1, rangeHdr, msg)
+			}
+		}
+	}
+}
+
 // Wrapper for calling PutObject API handler tests using streaming signature v4 for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectStreamSigV4Handler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// existingObjectTagActions - actions whose policy conditions may refer
+// to the tags of the existing object as "s3:ExistingObjectTag/<tag-key>".
+var existingObjectTagActions = map[policy.Action]struct{}{
+	policy.GetObjectAction:           {},
+	policy.GetObjectTaggingAction:    {},
+	policy.PutObjectTaggingAction:    {},
+	policy.DeleteObjectTaggingAction: {},
+}
+
+// getObjectTags - returns the tags stored in object metadata,
+// invalid tags are ignored.
+func getObjectTags(metadata map[string]string) *tagging.Tagging {
+	tags, err := tagging.ParseObjectTags(metadata[xhttp.AmzObjectTagging])
+	if err != nil {
+		return &tagging.Tagging{}
+	}
+	return tags
+}
+
+// setObjectTags - saves the tags in object metadata, an empty
+// value removes the tags when metadata is merged.
+func setObjectTags(metadata map[string]string, tags *tagging.Tagging) {
+	metadata[xhttp.AmzObjectTagging] = tags.String()
+}
+
+// setObjectTaggingMetadata - validates the x-amz-tagging header of a
+// write request and saves the requested tags in metadata.
+func setObjectTaggingMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	delete(metadata, xhttp.AmzObjectTagging)
+	if _, ok := r.Header[xhttp.AmzObjectTagging]; !ok {
+		return ErrNone
+	}
+
+	tags, err := tagging.ParseObjectTags(r.Header.Get(xhttp.AmzObjectTagging))
+	if err != nil {
+		return ErrInvalidTag
+	}
+	if tags.Count() == 0 {
+		return ErrNone
+	}
+
+	if s3Err := isActionAllowed(getRequestAuthType(r), bucket, object, r, policy.PutObjectTaggingAction); s3Err != ErrNone {
+		return s3Err
+	}
+	setObjectTags(metadata, tags)
+	return ErrNone
+}
+
+// removeExistingObjectTagConditions - removes "ExistingObjectTag/<tag-key>"
+// values copied from request headers or query parameters, these may only
+// be set from the tags of the existing object.
+func removeExistingObjectTagConditions(conditionValues map[string][]string) {
+	prefix := strings.ToLower(condition.S3ExistingObjectTag.Name() + "/")
+	for key := range conditionValues {
+		if strings.HasPrefix(strings.ToLower(key), prefix) {
+			delete(conditionValues, key)
+		}
+	}
+}
+
+// addExistingObjectTagConditions - adds the tags of the object addressed
+// by the request as "ExistingObjectTag/<tag-key>" condition values.
+func addExistingObjectTagConditions(ctx context.Context, r *http.Request, action policy.Action, bucket, object string, conditionValues map[string][]string) {
+	if _, ok := existingObjectTagActions[action]; !ok || object == "" {
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+
+	opts := ObjectOptions{VersionID: r.URL.Query().Get("versionId")}
+	setVersioningOpts(bucket, &opts)
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		// Objects which do not exist have no tags.
+		return
+	}
+	for key, value := range getObjectTags(objInfo.UserDefined).ToMap() {
+		conditionValues[condition.NewExistingObjectTagKey(key).Name()] = []string{value}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/policy/condition"
+	"github.com/minio/minio/pkg/tagging"
+)
+
+// Wrapper for calling object tagging tests for both XL multiple disks and single node setup.
+func TestObjectTagging(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectTagging)
+}
+
+// Unit test for storing, replacing and removing the tags of an object.
+func testObjectTagging(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	tags, err := tagging.ParseObjectTags("project=minio&team=storage")
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	metadata := make(map[string]string)
+	setObjectTags(metadata, tags)
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"),
+		4, "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if m := getObjectTags(objInfo.UserDefined).ToMap(); len(m) != 2 || m["project"] != "minio" {
+		t.Fatalf("%s: unexpected tags %v", instanceType, m)
+	}
+
+	w := httptest.NewRecorder()
+	setObjectHeaders(w, objInfo, nil)
+	if w.Header().Get(xhttp.AmzObjectTagging) != "" {
+		t.Errorf("%s: expected tags not to be returned as a header", instanceType)
+	}
+	if count := w.Header().Get(xhttp.AmzTagCount); count != "2" {
+		t.Errorf("%s: expected tag count 2, got %q", instanceType, count)
+	}
+
+	// Removing the tags leaves other metadata in place.
+	objInfo, err = obj.UpdateObjectMetadata(ctx, bucket, object, map[string]string{
+		xhttp.AmzObjectTagging: "",
+	}, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if count := getObjectTags(objInfo.UserDefined).Count(); count != 0 {
+		t.Errorf("%s: expected no tags, got %d", instanceType, count)
+	}
+	if objInfo.Size != 4 {
+		t.Errorf("%s: expected object size 4, got %d", instanceType, objInfo.Size)
+	}
+}
+
+// Tests that clients can not supply the tags of the existing object as condition values.
+func TestRemoveExistingObjectTagConditions(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object?existingobjecttag%2Fproject=minio", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("ExistingObjectTag/team", "storage")
+
+	for key := range getConditionValues(r, "", "") {
+		if strings.HasPrefix(strings.ToLower(key), "existingobjecttag/") {
+			t.Errorf("unexpected condition value %s", key)
+		}
+	}
+
+	conditionValues := map[string][]string{
+		condition.NewExistingObjectTagKey("project").Name(): {"minio"},
+		"versionid": {"1"},
+	}
+	removeExistingObjectTagConditions(conditionValues)
+	if len(conditionValues) != 1 {
+		t.Errorf("expected only versionid to remain, got %v", conditionValues)
+	}
+}
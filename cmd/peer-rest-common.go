@@ -34,6 +34,7 @@ const (
 	peerRESTMethodLoadPolicy               = "loadpolicy"
 	peerRESTMethodDeletePolicy             = "deletepolicy"
 	peerRESTMethodLoadUsers                = "loadusers"
+	peerRESTMethodLoadGroup                = "loadgroup"
 	peerRESTMethodStartProfiling           = "startprofiling"
 	peerRESTMethodDownloadProfilingData    = "downloadprofilingdata"
 	peerRESTMethodBucketPolicySet          = "setbucketpolicy"
@@ -45,6 +46,17 @@ const (
 	peerRESTMethodTrace                    = "trace"
 	peerRESTMethodBucketLifecycleSet       = "setbucketlifecycle"
 	peerRESTMethodBucketLifecycleRemove    = "removebucketlifecycle"
+	peerRESTMethodBucketVersioningSet      = "setbucketversioning"
+	peerRESTMethodBucketVersioningRemove   = "removebucketversioning"
+	peerRESTMethodBucketObjectLockSet      = "setbucketobjectlock"
+	peerRESTMethodBucketObjectLockRemove   = "removebucketobjectlock"
+	peerRESTMethodBucketReplicationSet     = "setbucketreplication"
+	peerRESTMethodBucketReplicationRemove  = "removebucketreplication"
+	peerRESTMethodBucketEncryptionSet      = "setbucketencryption"
+	peerRESTMethodBucketEncryptionRemove   = "removebucketencryption"
+	peerRESTMethodBucketQuotaSet           = "setbucketquota"
+	peerRESTMethodBucketQuotaRemove        = "removebucketquota"
+	peerRESTMethodBucketUsageSet           = "setbucketusage"
 )
 
 const (
@@ -52,6 +64,7 @@ const (
 	peerRESTUser     = "user"
 	peerRESTUserTemp = "user-temp"
 	peerRESTPolicy   = "policy"
+	peerRESTGroup    = "group"
 	peerRESTSignal   = "signal"
 	peerRESTProfiler = "profiler"
 	peerRESTDryRun   = "dry-run"
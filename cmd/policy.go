@@ -28,6 +28,7 @@ import (
 
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
 	"github.com/minio/minio-go/v6/pkg/set"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
@@ -226,6 +227,15 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 		args["LocationConstraint"] = []string{locationConstraint}
 	}
 
+	// SSE-KMS requests without a key ID are encrypted with the
+	// default key of the KMS.
+	if crypto.S3KMS.IsRequested(request.Header) && request.Header.Get(crypto.SSEKmsID) == "" {
+		args[crypto.SSEKmsID] = []string{globalKMSKeyID}
+	}
+
+	// Tags of the existing object can not be supplied by the client.
+	removeExistingObjectTagConditions(args)
+
 	return args
 }
 
@@ -262,7 +272,7 @@ func removePolicyConfig(ctx context.Context, objAPI ObjectLayer, bucketName stri
 	// Construct path to policy.json for the given bucket.
 	configFile := path.Join(bucketConfigPrefix, bucketName, bucketPolicyConfig)
 
-	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+	if _, err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile, ObjectOptions{}); err != nil {
 		if _, ok := err.(ObjectNotFound); ok {
 			return BucketPolicyNotFound{Bucket: bucketName}
 		}
@@ -17,11 +17,14 @@
 package cmd
 
 import (
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
 	"github.com/minio/minio-go/v6/pkg/set"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/policy/condition"
 )
@@ -422,3 +425,48 @@ func TestBucketAccessPolicyToPolicy(t *testing.T) {
 		}
 	}
 }
+
+// Tests policies restricting uploads to an SSE-KMS key ID.
+func TestPolicySSEKMSKeyIDCondition(t *testing.T) {
+	defer func(keyID string) { globalKMSKeyID = keyID }(globalKMSKeyID)
+	globalKMSKeyID = "my-key"
+
+	bucketPolicy, err := policy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[`+
+		`{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"]},`+
+		`{"Effect":"Deny","Principal":{"AWS":["*"]},"Action":["s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"],`+
+		`"Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption-aws-kms-key-id":"my-key"}}}]}`), "mybucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		headers         map[string]string
+		expectedAllowed bool
+	}{
+		// A foreign key ID is denied.
+		{map[string]string{crypto.SSEHeader: crypto.SSEAlgorithmKMS, crypto.SSEKmsID: "foreign-key"}, false},
+		{map[string]string{crypto.SSEHeader: crypto.SSEAlgorithmKMS, crypto.SSEKmsID: "my-key"}, true},
+		// Without a key ID the default key of the KMS is used.
+		{map[string]string{crypto.SSEHeader: crypto.SSEAlgorithmKMS}, true},
+		{map[string]string{crypto.SSEHeader: crypto.SSEAlgorithmAES256}, false},
+		{map[string]string{}, false},
+	}
+	for i, testCase := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://127.0.0.1:9000/mybucket/myobject", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range testCase.headers {
+			r.Header.Set(k, v)
+		}
+		allowed := bucketPolicy.IsAllowed(policy.Args{
+			Action:          policy.PutObjectAction,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: getConditionValues(r, "", ""),
+		})
+		if allowed != testCase.expectedAllowed {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedAllowed, allowed)
+		}
+	}
+}
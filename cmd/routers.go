@@ -120,8 +120,8 @@ func configureServerHandler(endpoints EndpointList) (http.Handler, error) {
 	}
 
 	// Add API router, additionally all server mode support encryption
-	// but don't allow SSE-KMS.
-	registerAPIRouter(router, true, false)
+	// including SSE-KMS.
+	registerAPIRouter(router, true, true)
 
 	// Register rest of the handlers.
 	return registerHandlers(router, globalHandlers...), nil
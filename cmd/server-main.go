@@ -85,11 +85,20 @@ ENVIRONMENT VARIABLES:
      MINIO_CACHE_EXPIRY: Cache expiry duration in days.
      MINIO_CACHE_MAXUSE: Maximum permitted usage of the cache in percentage (0-100).
 
+  COMPRESS:
+     MINIO_COMPRESS: To enable compression of objects, set this value to "true".
+     MINIO_COMPRESS_EXTENSIONS: List of file extensions to compress delimited by ",".
+     MINIO_COMPRESS_MIMETYPES: List of content-types to compress delimited by ",".
+     MINIO_COMPRESS_ALLOW_ENCRYPTION: To compress objects encrypted with SSE-C or SSE-S3, set this value to "true".
+                 The size of an encrypted object then reveals how well its data compresses, which
+                 allows CRIME/BREACH style attacks if the object mixes secret and attacker-controlled data.
+
   DOMAIN:
      MINIO_DOMAIN: To enable virtual-host-style requests, set this value to MinIO host domain name.
 
   WORM:
      MINIO_WORM: To turn on Write-Once-Read-Many in server, set this value to "on".
+                 Deprecated, use bucket object lock for per bucket retention instead.
 
   BUCKET-DNS:
      MINIO_DOMAIN:    To enable bucket DNS requests, set this value to MinIO host domain name.
@@ -296,6 +305,9 @@ func serverMain(ctx *cli.Context) {
 		globalSweepHealState = initHealState()
 	}
 
+	// Init global KMS key rotation state
+	globalAllKMSKeyRotateState = initKMSKeyRotateState()
+
 	// Configure server.
 	var handler http.Handler
 	handler, err = configureServerHandler(globalEndpoints)
@@ -373,6 +385,51 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize lifecycle system")
 	}
 
+	// Create new bucket versioning system.
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Initialize bucket versioning system.
+	if err = globalBucketVersioningSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket versioning system")
+	}
+
+	// Create new bucket object lock system.
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+
+	// Initialize bucket object lock system.
+	if err = globalBucketObjectLockSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket object lock system")
+	}
+
+	// Create new bucket replication system.
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// Initialize bucket replication system.
+	if err = globalBucketReplicationSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket replication system")
+	}
+
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Initialize bucket quota system.
+	if err = globalBucketQuotaSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket quota system")
+	}
+
+	// Create new bucket encryption system.
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// Initialize bucket encryption system.
+	if err = globalBucketSSEConfigSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket encryption system")
+	}
+
+	// Resume replication of queued objects.
+	if err = initBucketReplication(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket replication queue")
+	}
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
@@ -387,9 +444,13 @@ func serverMain(ctx *cli.Context) {
 	if globalIsXL {
 		initBackgroundHealing()
 		initDailyHeal()
+		initDailyLifecycle()
 		initDailySweeper()
 	}
 
+	initBucketQuotaScanner()
+	initDataUsageStats()
+
 	globalObjLayerMutex.Lock()
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
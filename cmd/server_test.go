@@ -581,7 +581,7 @@ func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	c.Assert(err, nil)
 	for i := 0; i < 10; i++ {
 		// All the objects should be under deleted list (including non-existent object)
-		c.Assert(deleteResp.DeletedObjects[i], delObjReq.Objects[i])
+		c.Assert(deleteResp.DeletedObjects[i], DeletedObject{ObjectName: delObjReq.Objects[i].ObjectName})
 	}
 	c.Assert(len(deleteResp.Errors), 0)
 
@@ -601,7 +601,7 @@ func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	err = xml.Unmarshal(delRespBytes, &deleteResp)
 	c.Assert(err, nil)
 	for i := 0; i < 10; i++ {
-		c.Assert(deleteResp.DeletedObjects[i], delObjReq.Objects[i])
+		c.Assert(deleteResp.DeletedObjects[i], DeletedObject{ObjectName: delObjReq.Objects[i].ObjectName})
 	}
 	c.Assert(len(deleteResp.Errors), 0)
 }
@@ -61,4 +61,10 @@ type FileInfo struct {
 	Parts []ObjectPartInfo
 
 	Quorum int
+
+	// Version ID of the object, empty for the null version.
+	VersionID string
+
+	// Set if the object is a delete marker.
+	DeleteMarker bool
 }
@@ -373,13 +373,15 @@ func readMetadata(buf []byte, volume, entry string) FileInfo {
 		return FileInfo{}
 	}
 	return FileInfo{
-		Volume:   volume,
-		Name:     entry,
-		ModTime:  m.Stat.ModTime,
-		Size:     m.Stat.Size,
-		Metadata: m.Meta,
-		Parts:    m.Parts,
-		Quorum:   m.Erasure.DataBlocks,
+		Volume:       volume,
+		Name:         entry,
+		ModTime:      m.Stat.ModTime,
+		Size:         m.Stat.Size,
+		Metadata:     m.Meta,
+		Parts:        m.Parts,
+		Quorum:       m.Erasure.DataBlocks,
+		VersionID:    m.VersionID,
+		DeleteMarker: m.DeleteMarker,
 	}
 }
 
@@ -174,3 +174,20 @@ type ClientGrantsResult struct {
 	// provider as the token's sub (Subject) claim.
 	SubjectFromToken string `xml:",omitempty"`
 }
+
+// AssumeRoleWithLDAPResponse contains the result of successful AssumeRoleWithLDAPIdentity request.
+type AssumeRoleWithLDAPResponse struct {
+	XMLName          xml.Name           `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithLDAPIdentityResponse" json:"-"`
+	Result           LDAPIdentityResult `xml:"AssumeRoleWithLDAPIdentityResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// LDAPIdentityResult - Contains the response to a successful AssumeRoleWithLDAPIdentity
+// request, including temporary credentials that can be used to make MinIO API requests.
+type LDAPIdentityResult struct {
+	// The temporary security credentials, which include an access key ID,
+	// a secret access key, and a security token.
+	Credentials auth.Credentials `xml:",omitempty"`
+}
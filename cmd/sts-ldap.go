@@ -0,0 +1,176 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/minio/minio-go/pkg/set"
+	ldap "gopkg.in/ldap.v3"
+)
+
+var (
+	errLDAPInvalidServerAddr     = errors.New("serverAddr must be of the form host:port")
+	errLDAPInvalidUsernameFormat = errors.New("usernameFormat must contain a single %s")
+	errLDAPMissingGroupSearch    = errors.New("groupSearchBaseDN and groupSearchFilter must be set together")
+)
+
+// ldapServerConfig - configuration of the LDAP (or Active Directory)
+// server users are authenticated against by the STS
+// AssumeRoleWithLDAPIdentity API.
+type ldapServerConfig struct {
+	// Address of the LDAP server as host:port, LDAP is disabled when empty.
+	ServerAddr string `json:"serverAddr"`
+
+	// Connect without TLS, only meant for testing.
+	ServerInsecure bool `json:"serverInsecure"`
+
+	// Skip verification of the LDAP server certificate.
+	TLSSkipVerify bool `json:"tlsSkipVerify"`
+
+	// Format of the DN users bind with, the %s is replaced
+	// by the username, e.g. "uid=%s,ou=people,dc=example,dc=com".
+	UsernameFormat string `json:"usernameFormat"`
+
+	// Base DN and filter of the search for the groups of a user.
+	// In the filter %s is replaced by the username and %d by the
+	// DN of the user, e.g. "(&(objectclass=groupOfNames)(member=%d))".
+	GroupSearchBaseDN string `json:"groupSearchBaseDN"`
+	GroupSearchFilter string `json:"groupSearchFilter"`
+
+	// Canned policies keyed by the DN of the LDAP group they are
+	// mapped to.
+	GroupPolicies map[string]string `json:"groupPolicies"`
+}
+
+// IsEnabled - returns true if an LDAP server is configured.
+func (l ldapServerConfig) IsEnabled() bool {
+	return l.ServerAddr != ""
+}
+
+// Validate - validates the LDAP server configuration.
+func (l ldapServerConfig) Validate() error {
+	if !l.IsEnabled() {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(l.ServerAddr); err != nil {
+		return errLDAPInvalidServerAddr
+	}
+	if strings.Count(l.UsernameFormat, "%s") != 1 {
+		return errLDAPInvalidUsernameFormat
+	}
+	if (l.GroupSearchBaseDN == "") != (l.GroupSearchFilter == "") {
+		return errLDAPMissingGroupSearch
+	}
+	return nil
+}
+
+// escapeDNValue - escapes the special characters of an attribute
+// value of a DN as described in RFC 4514.
+func escapeDNValue(value string) string {
+	var b strings.Builder
+	for i, c := range value {
+		switch {
+		case strings.ContainsRune(`"+,;<>\`, c),
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			b.WriteRune('\\')
+			b.WriteRune(c)
+		case c == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// connect - opens a connection to the LDAP server.
+func (l ldapServerConfig) connect() (*ldap.Conn, error) {
+	if l.ServerInsecure {
+		return ldap.Dial("tcp", l.ServerAddr)
+	}
+	return ldap.DialTLS("tcp", l.ServerAddr, &tls.Config{
+		InsecureSkipVerify: l.TLSSkipVerify,
+		RootCAs:            globalRootCAs,
+	})
+}
+
+// authenticate - binds to the LDAP server as the given user and
+// returns the DN of the user and the DNs of the groups the user is
+// a member of.
+func (l ldapServerConfig) authenticate(username, password string) (userDN string, groups []string, err error) {
+	// An empty password would be an unauthenticated bind, which
+	// succeeds for any user on most LDAP servers.
+	if username == "" || password == "" {
+		return "", nil, errAuthentication
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	userDN = fmt.Sprintf(l.UsernameFormat, escapeDNValue(username))
+	if err = conn.Bind(userDN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return "", nil, errAuthentication
+		}
+		return "", nil, err
+	}
+
+	if l.GroupSearchFilter == "" {
+		return userDN, nil, nil
+	}
+
+	filter := strings.Replace(l.GroupSearchFilter, "%s", ldap.EscapeFilter(username), -1)
+	filter = strings.Replace(filter, "%d", ldap.EscapeFilter(userDN), -1)
+	searchRequest := ldap.NewSearchRequest(
+		l.GroupSearchBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{"dn"},
+		nil,
+	)
+	result, err := conn.Search(searchRequest)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.DN)
+	}
+	return userDN, groups, nil
+}
+
+// policiesOf - returns the sorted canned policies mapped to the given
+// LDAP groups. Group DNs are compared case insensitively.
+func (l ldapServerConfig) policiesOf(groups []string) []string {
+	policies := set.NewStringSet()
+	for _, group := range groups {
+		for groupDN, policy := range l.GroupPolicies {
+			if strings.EqualFold(groupDN, group) {
+				policies.Add(policy)
+			}
+		}
+	}
+	return policies.ToSlice()
+}
@@ -0,0 +1,279 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	ber "gopkg.in/asn1-ber.v1"
+	ldap "gopkg.in/ldap.v3"
+)
+
+// ldapTestServer - minimal in-process LDAP server supporting simple
+// binds and searches, a search returns the groups of the bound user.
+type ldapTestServer struct {
+	listener net.Listener
+	users    map[string]string   // user DN to password
+	groups   map[string][]string // user DN to group DNs
+}
+
+func startLDAPTestServer(t *testing.T, users map[string]string, groups map[string][]string) *ldapTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &ldapTestServer{listener: listener, users: users, groups: groups}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *ldapTestServer) Addr() string { return s.listener.Addr().String() }
+
+func (s *ldapTestServer) Close() { s.listener.Close() }
+
+func (s *ldapTestServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	respond := func(messageID int64, response *ber.Packet) {
+		packet := ber.NewSequence("LDAP Response")
+		packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+		packet.AppendChild(response)
+		conn.Write(packet.Bytes())
+	}
+	result := func(tag ber.Tag, resultCode int64) *ber.Packet {
+		response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+		response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, resultCode, "Result Code"))
+		response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+		response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+		return response
+	}
+
+	var boundDN string
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, _ := request.Children[1].Value.(string)
+			if password, ok := s.users[dn]; ok && password == request.Children[2].Data.String() {
+				boundDN = dn
+				respond(messageID, result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+			} else {
+				boundDN = ""
+				respond(messageID, result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+			}
+		case ldap.ApplicationSearchRequest:
+			for _, group := range s.groups[boundDN] {
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, group, "DN"))
+				entry.AppendChild(ber.NewSequence("Attributes"))
+				respond(messageID, entry)
+			}
+			respond(messageID, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		default:
+			return
+		}
+	}
+}
+
+func TestLDAPServerConfigValidate(t *testing.T) {
+	testCases := []struct {
+		config      ldapServerConfig
+		expectedErr error
+	}{
+		{ldapServerConfig{}, nil},
+		{ldapServerConfig{ServerAddr: "localhost:389", UsernameFormat: "uid=%s,dc=example,dc=com"}, nil},
+		{ldapServerConfig{ServerAddr: "localhost", UsernameFormat: "uid=%s,dc=example,dc=com"}, errLDAPInvalidServerAddr},
+		{ldapServerConfig{ServerAddr: "localhost:389", UsernameFormat: "uid=user,dc=example,dc=com"}, errLDAPInvalidUsernameFormat},
+		{ldapServerConfig{ServerAddr: "localhost:389", UsernameFormat: "uid=%s,dc=example,dc=com", GroupSearchFilter: "(member=%d)"}, errLDAPMissingGroupSearch},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.config.Validate(); err != testCase.expectedErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestEscapeDNValue(t *testing.T) {
+	testCases := []struct {
+		value, expected string
+	}{
+		{"alice", "alice"},
+		{"smith, john", `smith\, john`},
+		{"#admin ", `\#admin\ `},
+		{`a+b=c"<d>;\`, `a\+b=c\"\<d\>\;\\`},
+	}
+
+	for i, testCase := range testCases {
+		if escaped := escapeDNValue(testCase.value); escaped != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, escaped)
+		}
+	}
+}
+
+// Tests issuing temporary credentials to LDAP users with the canned
+// policies mapped to their LDAP groups.
+func TestAssumeRoleWithLDAPIdentity(t *testing.T) {
+	const (
+		aliceDN     = "uid=alice,ou=people,dc=example,dc=com"
+		bobDN       = "uid=bob,ou=people,dc=example,dc=com"
+		readersDN   = "cn=readers,ou=groups,dc=example,dc=com"
+		auditorsDN  = "cn=auditors,ou=groups,dc=example,dc=com"
+		bucket      = "ldapbucket"
+		objectName  = "object"
+		objectValue = "hello"
+	)
+
+	ldapServer := startLDAPTestServer(t,
+		map[string]string{aliceDN: "alicepass", bobDN: "bobpass"},
+		map[string][]string{aliceDN: {readersDN, auditorsDN}, bobDN: {auditorsDN}},
+	)
+	defer ldapServer.Close()
+
+	server := StartTestServer(t, "FS")
+	defer server.Stop()
+
+	globalServerConfig.LDAPServerConfig = ldapServerConfig{
+		ServerAddr:        ldapServer.Addr(),
+		ServerInsecure:    true,
+		UsernameFormat:    "uid=%s,ou=people,dc=example,dc=com",
+		GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+		GroupSearchFilter: "(&(objectclass=groupOfNames)(member=%d))",
+		GroupPolicies:     map[string]string{strings.ToUpper(readersDN): "readonly"},
+	}
+
+	ctx := context.Background()
+	if err := server.Obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.Obj.PutObject(ctx, bucket, objectName, mustGetPutObjReader(t, strings.NewReader(objectValue), int64(len(objectValue)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	assumeRole := func(username, password string) (*http.Response, error) {
+		form := url.Values{}
+		form.Set("Action", ldapIdentity)
+		form.Set("Version", stsAPIVersion)
+		form.Set("LDAPUsername", username)
+		form.Set("LDAPPassword", password)
+		return http.Post(server.Server.URL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	}
+
+	testCases := []struct {
+		username, password string
+		expectedStatus     int
+	}{
+		{"alice", "wrongpass", http.StatusForbidden},
+		{"nobody", "alicepass", http.StatusForbidden},
+		// bob is only in groups without a mapped policy.
+		{"bob", "bobpass", http.StatusForbidden},
+		{"alice", "", http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		resp, err := assumeRole(testCase.username, testCase.password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, resp.StatusCode)
+		}
+	}
+
+	// Credentials are not accepted in the query string.
+	query := url.Values{}
+	query.Set("Action", ldapIdentity)
+	query.Set("Version", stsAPIVersion)
+	query.Set("LDAPUsername", "alice")
+	query.Set("LDAPPassword", "alicepass")
+	for _, body := range []string{"", "Action=" + ldapIdentity + "&Version=" + stsAPIVersion} {
+		resp, err := http.Post(server.Server.URL+"/?"+query.Encode(), "application/x-www-form-urlencoded", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Errorf("expected credentials in the query string to be rejected, body %q", body)
+		}
+	}
+
+	resp, err := assumeRole("alice", "alicepass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	var response AssumeRoleWithLDAPResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	cred := response.Result.Credentials
+	if cred.AccessKey == "" || cred.SecretKey == "" || cred.SessionToken == "" {
+		t.Fatalf("unexpected credentials %+v", cred)
+	}
+
+	// The temporary credentials get the policy of the readers group.
+	u, err := url.Parse(server.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := miniogo.NewWithOptions(u.Host, &miniogo.Options{
+		Creds: credentials.NewStaticV4(cred.AccessKey, cred.SecretKey, cred.SessionToken),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	object, err := client.GetObject(bucket, objectName, miniogo.GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err = buf.ReadFrom(object); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != objectValue {
+		t.Fatalf("expected object value %s, got %s", objectValue, buf.String())
+	}
+	_, err = client.PutObject(bucket, objectName, strings.NewReader(objectValue), int64(len(objectValue)), miniogo.PutObjectOptions{})
+	if miniogo.ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %v", err)
+	}
+}
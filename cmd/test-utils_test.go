@@ -368,6 +368,18 @@ func UnstartedTestServer(t TestErrHandler, instanceType string) TestServer {
 	globalLifecycleSys = NewLifecycleSys()
 	globalLifecycleSys.Init(objLayer)
 
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalBucketVersioningSys.Init(objLayer)
+
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketObjectLockSys.Init(objLayer)
+
+	globalBucketReplicationSys = NewBucketReplicationSys()
+	globalBucketReplicationSys.Init(objLayer)
+
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+	globalBucketSSEConfigSys.Init(objLayer)
+
 	return testServer
 }
 
@@ -2138,7 +2150,7 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 func registerAPIFunctions(muxRouter *mux.Router, objLayer ObjectLayer, apiFunctions ...string) {
 	if len(apiFunctions) == 0 {
 		// Register all api endpoints by default.
-		registerAPIRouter(muxRouter, true, false)
+		registerAPIRouter(muxRouter, true, true)
 		return
 	}
 	// API Router.
@@ -2179,7 +2191,7 @@ func initTestAPIEndPoints(objLayer ObjectLayer, apiFunctions []string) http.Hand
 		registerAPIFunctions(muxRouter, objLayer, apiFunctions...)
 		return muxRouter
 	}
-	registerAPIRouter(muxRouter, true, false)
+	registerAPIRouter(muxRouter, true, true)
 	return muxRouter
 }
 
@@ -0,0 +1,185 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/logger"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+var (
+	errTierInvalidEndpoint = errors.New("endpoint must be a http or https URL")
+	errTierMissingBucket   = errors.New("bucket must be specified")
+	errTierInvalidName     = errors.New("tier name must not be empty or a MinIO storage class")
+)
+
+// tierConfig - remote tier, an S3 compatible endpoint object data is
+// transitioned to by lifecycle rules naming the tier as storage class.
+type tierConfig struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	Region    string `json:"region"`
+}
+
+// Validate - validates the remote tier configuration.
+func (t tierConfig) Validate() error {
+	u, err := xnet.ParseURL(t.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errTierInvalidEndpoint
+	}
+	if t.Bucket == "" {
+		return errTierMissingBucket
+	}
+	return nil
+}
+
+// validateTierName - tier names are reported as the storage class of
+// transitioned objects, they may not collide with MinIO storage classes.
+func validateTierName(name string) error {
+	if name == "" || isValidStorageClassMeta(name) {
+		return errTierInvalidName
+	}
+	return nil
+}
+
+// GetTier - returns the configuration of the named remote tier.
+func (s *serverConfig) GetTier(name string) (tierConfig, bool) {
+	if s == nil {
+		return tierConfig{}, false
+	}
+	t, ok := s.Tier[name]
+	return t, ok
+}
+
+// transitionInfo - location of object data transitioned to a remote
+// tier, saved in `xl.json` of the object in place of its parts.
+type transitionInfo struct {
+	Tier   string `json:"tier"`
+	Bucket string `json:"bucket"`
+	Object string `json:"object"`
+}
+
+// Clients of remote tiers, keyed by their configuration so that
+// configuration changes get a new client.
+var globalTierClients = struct {
+	sync.Mutex
+	clients map[tierConfig]*miniogo.Core
+}{clients: make(map[tierConfig]*miniogo.Core)}
+
+// getTierClient - returns a client and the configuration of the named remote tier.
+func getTierClient(name string) (*miniogo.Core, tierConfig, error) {
+	cfg, ok := globalServerConfig.GetTier(name)
+	if !ok {
+		return nil, cfg, RemoteTierNotFound{Tier: name}
+	}
+
+	globalTierClients.Lock()
+	defer globalTierClients.Unlock()
+
+	if client, ok := globalTierClients.clients[cfg]; ok {
+		return client, cfg, nil
+	}
+
+	core, err := newRemoteClient(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Region, NewCustomHTTPTransport())
+	if err != nil {
+		return nil, cfg, err
+	}
+	globalTierClients.clients[cfg] = core
+	return core, cfg, nil
+}
+
+// newRemoteClient - returns a client of a remote S3 compatible endpoint
+// sending its requests through the given transport.
+func newRemoteClient(endpoint, accessKey, secretKey, region string, transport http.RoundTripper) (*miniogo.Core, error) {
+	u, err := xnet.ParseURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client, err := miniogo.NewWithRegion(u.Host, accessKey, secretKey, u.Scheme == "https", region)
+	if err != nil {
+		return nil, err
+	}
+	client.SetCustomTransport(transport)
+	return &miniogo.Core{Client: client}, nil
+}
+
+// putTransitionedObject - uploads object data to the named remote tier.
+func putTransitionedObject(ctx context.Context, tier, object string, reader io.Reader, size int64) (transitionInfo, error) {
+	client, cfg, err := getTierClient(tier)
+	if err != nil {
+		return transitionInfo{}, err
+	}
+
+	ti := transitionInfo{
+		Tier:   tier,
+		Bucket: cfg.Bucket,
+		Object: pathJoin(cfg.Prefix, object),
+	}
+	if _, err = client.Client.PutObjectWithContext(ctx, ti.Bucket, ti.Object, reader, size, miniogo.PutObjectOptions{}); err != nil {
+		return ti, fmt.Errorf("Unable to transition to remote tier %s: %v", tier, err)
+	}
+	return ti, nil
+}
+
+// getTransitionedObject - reads length bytes of transitioned object
+// data starting at startOffset from the remote tier.
+func getTransitionedObject(ctx context.Context, ti *transitionInfo, startOffset, length int64, writer io.Writer) error {
+	if length == 0 {
+		return nil
+	}
+
+	client, _, err := getTierClient(ti.Tier)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+
+	opts := miniogo.GetObjectOptions{}
+	if err = opts.SetRange(startOffset, startOffset+length-1); err != nil {
+		return err
+	}
+	reader, _, err := client.GetObject(ti.Bucket, ti.Object, opts)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.CopyN(writer, reader, length)
+	return err
+}
+
+// deleteTransitionedObject - removes transitioned object data from
+// the remote tier, errors are logged and otherwise ignored.
+func deleteTransitionedObject(ctx context.Context, ti *transitionInfo) {
+	client, _, err := getTierClient(ti.Tier)
+	if err == nil {
+		err = client.RemoveObject(ti.Bucket, ti.Object)
+	}
+	logger.LogIf(ctx, err)
+}
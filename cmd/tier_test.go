@@ -0,0 +1,46 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestTierConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tier        tierConfig
+		expectedErr error
+	}{
+		{"WARM", tierConfig{Endpoint: "http://localhost:9001", Bucket: "warm"}, nil},
+		{"GLACIER", tierConfig{Endpoint: "https://s3.amazonaws.com", Bucket: "warm", Prefix: "minio"}, nil},
+		{"WARM", tierConfig{Endpoint: "localhost:9001", Bucket: "warm"}, errTierInvalidEndpoint},
+		{"WARM", tierConfig{Endpoint: "ftp://localhost:9001", Bucket: "warm"}, errTierInvalidEndpoint},
+		{"WARM", tierConfig{Endpoint: "http://localhost:9001"}, errTierMissingBucket},
+		{"", tierConfig{Endpoint: "http://localhost:9001", Bucket: "warm"}, errTierInvalidName},
+		{"STANDARD", tierConfig{Endpoint: "http://localhost:9001", Bucket: "warm"}, errTierInvalidName},
+		{"REDUCED_REDUNDANCY", tierConfig{Endpoint: "http://localhost:9001", Bucket: "warm"}, errTierInvalidName},
+	}
+
+	for i, testCase := range testCases {
+		err := validateTierName(testCase.name)
+		if err == nil {
+			err = testCase.tier.Validate()
+		}
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
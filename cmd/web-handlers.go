@@ -546,7 +546,9 @@ func (web *webAPIHandlers) ListObjects(r *http.Request, args *ListObjectsArgs, r
 			return &json2.Error{Message: err.Error()}
 		}
 		for i := range lo.Objects {
-			if crypto.IsEncrypted(lo.Objects[i].UserDefined) {
+			if lo.Objects[i].IsCompressed() {
+				lo.Objects[i].Size = lo.Objects[i].GetActualSize()
+			} else if crypto.IsEncrypted(lo.Objects[i].UserDefined) {
 				lo.Objects[i].Size, err = lo.Objects[i].DecryptedSize()
 				if err != nil {
 					return toJSONError(ctx, err)
@@ -667,6 +669,9 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		return nil
 	}
 
+	opts := ObjectOptions{}
+	setVersioningOpts(args.BucketName, &opts)
+
 	var err error
 next:
 	for _, objectName := range args.Objects {
@@ -694,7 +699,7 @@ next:
 				}
 			}
 
-			if err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, objectName, r); err != nil {
+			if _, err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, objectName, r, opts); err != nil {
 				break next
 			}
 			continue
@@ -721,7 +726,7 @@ next:
 			}
 			marker = lo.NextMarker
 			for _, obj := range lo.Objects {
-				err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, obj.Name, r)
+				_, err = deleteObject(ctx, objectAPI, web.CacheAPI(), args.BucketName, obj.Name, r, opts)
 				if err != nil {
 					break next
 				}
@@ -996,9 +1001,13 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Apply the default retention of the bucket, if any.
+	setDefaultRetention(bucket, metadata)
+
 	var pReader *PutObjReader
 	var reader io.Reader = r.Body
 	actualSize := size
+	var indexCB func() []byte
 
 	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
 	if err != nil {
@@ -1018,7 +1027,9 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 
 		// Set compression metrics.
 		size = -1 // Since compressed size is un-predictable.
-		reader = newSnappyCompressReader(actualReader)
+		compressReader := newSnappyCompressReader(actualReader)
+		indexCB = compressReader.Index
+		reader = compressReader
 		hashReader, err = hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
 		if err != nil {
 			writeWebErrorResponse(w, err)
@@ -1033,6 +1044,8 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setVersioningOpts(bucket, &opts)
+	opts.IndexCB = indexCB
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(r.Header) && !hasSuffix(object, slashSeparator) { // handle SSE requests
 			rawReader := hashReader
@@ -1042,9 +1055,13 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
 			}
-			info := ObjectInfo{Size: size}
+			wantSize := int64(-1)
+			if size >= 0 {
+				info := ObjectInfo{Size: size}
+				wantSize = info.EncryptedSize()
+			}
 			// do not try to verify encrypted content
-			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+			hashReader, err = hash.NewReader(reader, wantSize, "", "", actualSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -1076,6 +1093,8 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
 			switch {
+			case crypto.S3KMS.IsEncrypted(objInfo.UserDefined):
+				setSSEKMSResponseHeaders(w.Header(), objInfo.UserDefined)
 			case crypto.S3.IsEncrypted(objInfo.UserDefined):
 				w.Header().Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 			case crypto.SSEC.IsRequested(r.Header):
@@ -1181,6 +1200,8 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
 			switch {
+			case crypto.S3KMS.IsEncrypted(objInfo.UserDefined):
+				setSSEKMSResponseHeaders(w.Header(), objInfo.UserDefined)
 			case crypto.S3.IsEncrypted(objInfo.UserDefined):
 				w.Header().Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 			case crypto.SSEC.IsEncrypted(objInfo.UserDefined):
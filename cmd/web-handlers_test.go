@@ -382,7 +382,7 @@ func testDeleteBucketWebHandler(obj ObjectLayer, instanceType string, t TestErrH
 
 		// If we created the bucket with an object, now delete the object to cleanup.
 		if test.initWithObject {
-			err = obj.DeleteObject(context.Background(), test.bucketName, "object")
+			_, err = obj.DeleteObject(context.Background(), test.bucketName, "object", ObjectOptions{})
 			if err != nil {
 				t.Fatalf("could not delete object, %s", err.Error())
 			}
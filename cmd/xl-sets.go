@@ -31,8 +31,12 @@ import (
 	"github.com/minio/minio/pkg/bpool"
 	"github.com/minio/minio/pkg/lifecycle"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/sse"
 	"github.com/minio/minio/pkg/sync/errgroup"
+	"github.com/minio/minio/pkg/versioning"
 )
 
 // setsStorageAPI is encapsulated type for Close()
@@ -301,6 +305,15 @@ func newXLSets(endpoints EndpointList, format *formatXLV3, setCount int, drivesP
 	return s, nil
 }
 
+// CrawlAndGetDataUsage - combines the data usage of all erasure coded object sets.
+func (s *xlSets) CrawlAndGetDataUsage(ctx context.Context, endCh <-chan struct{}) madmin.DataUsageInfo {
+	dataUsageInfo := newDataUsageInfo()
+	for _, set := range s.sets {
+		mergeDataUsageInfo(&dataUsageInfo, set.CrawlAndGetDataUsage(ctx, endCh))
+	}
+	return dataUsageInfo
+}
+
 // StorageInfo - combines output of StorageInfo across all erasure coded object sets.
 func (s *xlSets) StorageInfo(ctx context.Context) StorageInfo {
 	var storageInfo StorageInfo
@@ -534,6 +547,56 @@ func (s *xlSets) DeleteBucketLifecycle(ctx context.Context, bucket string) error
 	return removeLifecycleConfig(ctx, s, bucket)
 }
 
+// SetBucketVersioning sets versioning configuration on bucket
+func (s *xlSets) SetBucketVersioning(ctx context.Context, bucket string, v *versioning.Versioning) error {
+	return saveVersioningConfig(ctx, s, bucket, v)
+}
+
+// GetBucketVersioning will get versioning configuration on bucket
+func (s *xlSets) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getVersioningConfig(s, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (s *xlSets) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, s, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (s *xlSets) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(s, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (s *xlSets) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveReplicationConfig(ctx, s, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (s *xlSets) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getReplicationConfig(s, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (s *xlSets) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeReplicationConfig(ctx, s, bucket)
+}
+
+// SetBucketSSEConfig sets encryption configuration on bucket
+func (s *xlSets) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.Config) error {
+	return saveBucketSSEConfig(ctx, s, bucket, config)
+}
+
+// GetBucketSSEConfig will get encryption configuration on bucket
+func (s *xlSets) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.Config, error) {
+	return getBucketSSEConfig(s, bucket)
+}
+
+// DeleteBucketSSEConfig deletes encryption configuration on bucket
+func (s *xlSets) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, s, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (s *xlSets) IsNotificationSupported() bool {
 	return s.getHashedSet("").IsNotificationSupported()
@@ -635,15 +698,25 @@ func (s *xlSets) GetObjectInfo(ctx context.Context, bucket, object string, opts
 }
 
 // DeleteObject - deletes an object from the hashedSet based on the object name.
-func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string) (err error) {
-	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
+func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).DeleteObject(ctx, bucket, object, opts)
+}
+
+// UpdateObjectMetadata - updates the metadata of an object version from the hashedSet based on the object name.
+func (s *xlSets) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).UpdateObjectMetadata(ctx, bucket, object, metadata, opts)
+}
+
+// TransitionObject - moves object data to a remote tier from the hashedSet based on object name.
+func (s *xlSets) TransitionObject(ctx context.Context, bucket, object, tier string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).TransitionObject(ctx, bucket, object, tier, opts)
 }
 
 // DeleteObjects - bulk delete of objects
 // Bulk delete is only possible within one set. For that purpose
 // objects are group by set first, and then bulk delete is invoked
 // for each set, the error response of each delete will be returned
-func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error) {
 
 	type delObj struct {
 		// Set index associated to this object
@@ -679,7 +752,7 @@ func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []str
 	// Invoke bulk delete on objects per set and save
 	// the result of the delete operation
 	for _, objsGroup := range objSetMap {
-		errs, err := s.getHashedSet(objsGroup[0].name).DeleteObjects(ctx, bucket, toNames(objsGroup))
+		errs, err := s.getHashedSet(objsGroup[0].name).DeleteObjects(ctx, bucket, toNames(objsGroup), opts)
 		if err != nil {
 			return nil, err
 		}
@@ -696,20 +769,26 @@ func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucke
 	srcSet := s.getHashedSet(srcObject)
 	destSet := s.getHashedSet(destObject)
 
-	// Check if this request is only metadata update.
+	// Copying an object onto itself happens within its set,
+	// which holds the object lock during the copy.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
-	if cpSrcDstSame && srcInfo.metadataOnly {
+	if cpSrcDstSame {
 		return srcSet.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
 	}
 
-	if !cpSrcDstSame {
-		objectDWLock := destSet.nsMutex.NewNSLock(ctx, destBucket, destObject)
-		if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
-			return objInfo, err
-		}
-		defer objectDWLock.Unlock()
+	objectDWLock := destSet.nsMutex.NewNSLock(ctx, destBucket, destObject)
+	if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectDWLock.Unlock()
+	putOpts := ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+		BypassGovernance:     dstOpts.BypassGovernance,
+		IndexCB:              dstOpts.IndexCB,
 	}
-	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
 	return destSet.putObject(ctx, destBucket, destObject, srcInfo.PutObjReader, putOpts)
 }
 
@@ -957,6 +1036,8 @@ func (s *xlSets) listObjectsNonSlash(ctx context.Context, bucket, prefix, marker
 				Size:            result.Size,
 				ContentType:     result.Metadata["content-type"],
 				ContentEncoding: result.Metadata["content-encoding"],
+				VersionID:       result.VersionID,
+				DeleteMarker:    result.DeleteMarker,
 			}
 
 			// Extract etag from metadata.
@@ -1105,6 +1186,8 @@ func (s *xlSets) listObjects(ctx context.Context, bucket, prefix, marker, delimi
 				Size:            entry.Size,
 				ContentType:     entry.Metadata["content-type"],
 				ContentEncoding: entry.Metadata["content-encoding"],
+				VersionID:       entry.VersionID,
+				DeleteMarker:    entry.DeleteMarker,
 			}
 
 			// Extract etag from metadata.
@@ -1137,7 +1220,32 @@ func (s *xlSets) listObjects(ctx context.Context, bucket, prefix, marker, delimi
 // walked and merged at this layer. Resulting value through the merge process sends
 // the data in lexically sorted order.
 func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, err error) {
-	return s.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, false)
+	loi, err = s.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, false)
+	if err != nil {
+		return loi, err
+	}
+	// Delete markers are only visible when listing versions.
+	return filterDeleteMarkers(loi), nil
+}
+
+// ListObjectVersions - lists all versions of the objects in a bucket, the
+// noncurrent versions of an object are kept in its hashedSet.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, delimiter, s); err != nil {
+		return ListObjectVersionsInfo{}, err
+	}
+
+	listFn := func(marker string, maxKeys int) (ListObjectsInfo, error) {
+		return s.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, false)
+	}
+	latestFn := func(object string) (ObjectInfo, error) {
+		objInfo, err := s.getHashedSet(object).getObjectInfo(ctx, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	versionsFn := func(object string) ([]ObjectInfo, error) {
+		return s.getHashedSet(object).listObjectVersionsOf(ctx, bucket, object)
+	}
+	return listObjectVersions(marker, versionIDMarker, maxKeys, listFn, latestFn, versionsFn)
 }
 
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
@@ -1146,6 +1254,18 @@ func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMa
 	return s.getHashedSet(prefix).ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
 }
 
+// ListAllMultipartUploads - lists the multipart uploads of all objects with the given prefix in all sets.
+func (s *xlSets) ListAllMultipartUploads(ctx context.Context, bucket, prefix string) (uploads []MultipartInfo, err error) {
+	for _, set := range s.sets {
+		setUploads, err := set.ListAllMultipartUploads(ctx, bucket, prefix)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, setUploads...)
+	}
+	return uploads, nil
+}
+
 // Initiate a new multipart upload on a hashedSet based on object name.
 func (s *xlSets) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
 	return s.getHashedSet(object).NewMultipartUpload(ctx, bucket, object, opts)
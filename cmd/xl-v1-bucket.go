@@ -24,7 +24,11 @@ import (
 	"github.com/minio/minio-go/v6/pkg/s3utils"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/sse"
+	"github.com/minio/minio/pkg/versioning"
 )
 
 // list all errors that can be ignore in a bucket operation.
@@ -268,6 +272,13 @@ func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string) error {
 
 			// Cleanup all the previously incomplete multiparts.
 			err = cleanupDir(ctx, disk, minioMetaMultipartBucket, bucket)
+			if err != nil && err != errVolumeNotFound {
+				dErrs[index] = err
+				return
+			}
+
+			// Cleanup the version store of the bucket.
+			err = cleanupDir(ctx, disk, minioMetaBucket, pathJoin(versionsMetaPrefix, bucket))
 			if err != nil && err != errVolumeNotFound {
 				dErrs[index] = err
 			}
@@ -324,6 +335,56 @@ func (xl xlObjects) DeleteBucketLifecycle(ctx context.Context, bucket string) er
 	return removeLifecycleConfig(ctx, xl, bucket)
 }
 
+// SetBucketVersioning sets versioning configuration on bucket
+func (xl xlObjects) SetBucketVersioning(ctx context.Context, bucket string, v *versioning.Versioning) error {
+	return saveVersioningConfig(ctx, xl, bucket, v)
+}
+
+// GetBucketVersioning will get versioning configuration on bucket
+func (xl xlObjects) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getVersioningConfig(xl, bucket)
+}
+
+// SetBucketObjectLockConfig sets object lock configuration on bucket
+func (xl xlObjects) SetBucketObjectLockConfig(ctx context.Context, bucket string, config *objectlock.Config) error {
+	return saveObjectLockConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketObjectLockConfig will get object lock configuration on bucket
+func (xl xlObjects) GetBucketObjectLockConfig(ctx context.Context, bucket string) (*objectlock.Config, error) {
+	return getObjectLockConfig(xl, bucket)
+}
+
+// SetBucketReplication sets replication configuration on bucket
+func (xl xlObjects) SetBucketReplication(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveReplicationConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketReplication will get replication configuration on bucket
+func (xl xlObjects) GetBucketReplication(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getReplicationConfig(xl, bucket)
+}
+
+// DeleteBucketReplication deletes replication configuration on bucket
+func (xl xlObjects) DeleteBucketReplication(ctx context.Context, bucket string) error {
+	return removeReplicationConfig(ctx, xl, bucket)
+}
+
+// SetBucketSSEConfig sets encryption configuration on bucket
+func (xl xlObjects) SetBucketSSEConfig(ctx context.Context, bucket string, config *sse.Config) error {
+	return saveBucketSSEConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketSSEConfig will get encryption configuration on bucket
+func (xl xlObjects) GetBucketSSEConfig(ctx context.Context, bucket string) (*sse.Config, error) {
+	return getBucketSSEConfig(xl, bucket)
+}
+
+// DeleteBucketSSEConfig deletes encryption configuration on bucket
+func (xl xlObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) error {
+	return removeBucketSSEConfig(ctx, xl, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (xl xlObjects) IsNotificationSupported() bool {
 	return true
@@ -169,6 +169,12 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 			continue
 		}
 
+		// Parts of transitioned objects are held by the remote tier.
+		if partsMetadata[i].Transition != nil {
+			availableDisks[i] = onlineDisk
+			continue
+		}
+
 		switch scanMode {
 		case madmin.HealDeepScan:
 			erasureInfo := partsMetadata[i].Erasure
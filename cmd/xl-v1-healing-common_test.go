@@ -173,7 +173,7 @@ func TestListOnlineDisks(t *testing.T) {
 		// Prepare bucket/object backend for the tests below.
 
 		// Cleanup from previous test.
-		obj.DeleteObject(context.Background(), bucket, object)
+		obj.DeleteObject(context.Background(), bucket, object, ObjectOptions{})
 		obj.DeleteBucket(context.Background(), bucket)
 
 		err = obj.MakeBucketWithLocation(context.Background(), "bucket", "")
@@ -376,8 +376,20 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 		return result, toObjectErr(err, bucket, object)
 	}
 
+	// Parts of transitioned objects are held by the remote tier,
+	// only their metadata is healed.
+	healParts := latestMeta.Parts
+	if latestMeta.Transition != nil {
+		for i := range outDatedDisks {
+			if outDatedDisks[i] != nil {
+				partsMetadata[i].Parts = latestMeta.Parts
+			}
+		}
+		healParts = nil
+	}
+
 	erasureInfo := latestMeta.Erasure
-	for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
+	for partIndex := 0; partIndex < len(healParts); partIndex++ {
 		partName := latestMeta.Parts[partIndex].Name
 		partSize := latestMeta.Parts[partIndex].Size
 		partActualSize := latestMeta.Parts[partIndex].ActualSize
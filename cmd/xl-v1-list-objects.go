@@ -177,8 +177,9 @@ func (xl xlObjects) ListObjects(ctx context.Context, bucket, prefix, marker, del
 	// Initiate a list operation, if successful filter and return quickly.
 	listObjInfo, err := xl.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
 	if err == nil {
-		// We got the entries successfully return.
-		return listObjInfo, nil
+		// We got the entries successfully return, delete
+		// markers are only visible when listing versions.
+		return filterDeleteMarkers(listObjInfo), nil
 	}
 
 	// Return error at the end.
@@ -157,6 +157,13 @@ type xlMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// Captures all the individual object `xl.json`.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of the current object `xl.json`, empty for the null version.
+	VersionID string `json:"versionId,omitempty"`
+	// Set if the current object `xl.json` represents a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
+	// Remote location of the object data, set once the data has been
+	// transitioned to a remote tier and the object has no parts left.
+	Transition *transitionInfo `json:"transition,omitempty"`
 }
 
 // XL metadata constants.
@@ -228,6 +235,8 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		ModTime:         m.Stat.ModTime,
 		ContentType:     m.Meta["content-type"],
 		ContentEncoding: m.Meta["content-encoding"],
+		VersionID:       m.VersionID,
+		DeleteMarker:    m.DeleteMarker,
 	}
 	// Update expires
 	var (
@@ -259,6 +268,12 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		objInfo.StorageClass = globalMinioDefaultStorageClass
 	}
 
+	// Transitioned objects report their remote tier as storage class.
+	if m.Transition != nil {
+		objInfo.TransitionTier = m.Transition.Tier
+		objInfo.StorageClass = m.Transition.Tier
+	}
+
 	// Success.
 	return objInfo
 }
@@ -176,7 +176,12 @@ func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, object, ke
 			if len(result.Uploads) == maxUploads {
 				break
 			}
-			result.Uploads = append(result.Uploads, MultipartInfo{Object: object, UploadID: uploadID})
+			// Uploads being removed concurrently are skipped.
+			fi, err := disk.StatFile(minioMetaMultipartBucket, pathJoin(xl.getUploadIDDir(bucket, object, uploadID), xlMetaJSONFile))
+			if err != nil {
+				continue
+			}
+			result.Uploads = append(result.Uploads, MultipartInfo{Object: object, UploadID: uploadID, Initiated: fi.ModTime})
 		}
 		break
 	}
@@ -192,6 +197,53 @@ func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, object, ke
 // '.minio.sys/multipart/bucket/object/uploads.json' on all the
 // disks. `uploads.json` carries metadata regarding on-going multipart
 // operation(s) on the object.
+// ListAllMultipartUploads - lists the multipart uploads of all objects of
+// the bucket with the given prefix. Uploads started before the name of
+// the object was saved with them are not listed.
+func (xl xlObjects) ListAllMultipartUploads(ctx context.Context, bucket, prefix string) (uploads []MultipartInfo, err error) {
+	for _, disk := range xl.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		shaDirs, err := disk.ListDir(minioMetaMultipartBucket, "", -1, "")
+		if err != nil {
+			if err == errFileNotFound {
+				return nil, nil
+			}
+			logger.LogIf(ctx, err)
+			return nil, err
+		}
+		for _, shaDir := range shaDirs {
+			uploadIDs, err := disk.ListDir(minioMetaMultipartBucket, shaDir, -1, "")
+			if err != nil {
+				continue
+			}
+			for _, uploadID := range uploadIDs {
+				// Uploads being removed concurrently are skipped.
+				xlMeta, err := readXLMeta(ctx, disk, minioMetaMultipartBucket, pathJoin(shaDir, uploadID))
+				if err != nil {
+					continue
+				}
+				uploadBucket, object := getMultipartObject(xlMeta.Meta)
+				if uploadBucket == "" {
+					continue
+				}
+				if uploadBucket != bucket || !hasPrefix(object, prefix) {
+					// All uploads of a directory belong to the same object.
+					break
+				}
+				uploads = append(uploads, MultipartInfo{
+					Object:    object,
+					UploadID:  strings.TrimSuffix(uploadID, slashSeparator),
+					Initiated: xlMeta.Stat.ModTime,
+				})
+			}
+		}
+		break
+	}
+	return uploads, nil
+}
+
 func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, object string, meta map[string]string) (string, error) {
 
 	dataBlocks, parityBlocks := getRedundancyCount(meta[amzStorageClass], len(xl.getDisks()))
@@ -208,6 +260,7 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 	}
 	xlMeta.Stat.ModTime = UTCNow()
 	xlMeta.Meta = meta
+	xlMeta.Meta[multipartObjectKey] = pathJoin(bucket, object)
 
 	uploadID := mustGetUUID()
 	uploadIDPath := xl.getUploadIDDir(bucket, object, uploadID)
@@ -724,16 +777,21 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Save successfully calculated md5sum.
 	xlMeta.Meta["etag"] = s3MD5
+	delete(xlMeta.Meta, multipartObjectKey)
 
 	// Save the consolidated actual size.
 	xlMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// Save the version ID of the new object version.
+	xlMeta.VersionID = newObjectVersionID(opts)
+
 	// Update all xl metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
 		partsMetadata[index].Stat = xlMeta.Stat
 		partsMetadata[index].Meta = xlMeta.Meta
 		partsMetadata[index].Parts = xlMeta.Parts
+		partsMetadata[index].VersionID = xlMeta.VersionID
 	}
 
 	tempXLMetaPath := mustGetUUID()
@@ -753,23 +811,39 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 
 	if xl.isObject(bucket, object) {
-		// Deny if WORM is enabled
-		if globalWORMEnabled {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		// Deny overwriting a version protected by WORM or object lock.
+		err := enforceRetentionForOverwrite(bucket, object, opts, func() (ObjectInfo, error) {
+			return xl.getObjectInfo(ctx, bucket, object)
+		})
+		if err != nil {
+			return ObjectInfo{}, err
 		}
 
-		// Rename if an object already exists to temporary location.
-		newUniqueID := mustGetUUID()
+		// Keep the existing object as a noncurrent version if versioning is configured.
+		archived, err := xl.archiveLatestVersion(ctx, bucket, object, opts, writeQuorum)
+		if err != nil {
+			return oi, err
+		}
 
-		// Delete success renamed object.
-		defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+		if !archived {
+			// Remove the data of the replaced object from its remote tier.
+			if ti := xl.readTransitionInfo(ctx, bucket, object); ti != nil {
+				defer deleteTransitionedObject(ctx, ti)
+			}
 
-		// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
-		// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming of the
-		// existing object if it is not present in quorum disks so users can overwrite stale objects.
-		_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
-		if err != nil {
-			return oi, toObjectErr(err, bucket, object)
+			// Rename if an object already exists to temporary location.
+			newUniqueID := mustGetUUID()
+
+			// Delete success renamed object.
+			defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+
+			// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
+			// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming of the
+			// existing object if it is not present in quorum disks so users can overwrite stale objects.
+			_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
+			if err != nil {
+				return oi, toObjectErr(err, bucket, object)
+			}
 		}
 	}
 
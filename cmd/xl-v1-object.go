@@ -62,8 +62,18 @@ func (xl xlObjects) putObjectDir(ctx context.Context, bucket, object string, wri
 func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, e error) {
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
-	// Check if this request is only metadata update.
+	// The source is read without a lock when it is the destination,
+	// hold the write lock while the object is updated or replaced.
 	if cpSrcDstSame {
+		objectDWLock := xl.nsMutex.NewNSLock(ctx, dstBucket, dstObject)
+		if err := objectDWLock.GetLock(globalObjectTimeout); err != nil {
+			return oi, err
+		}
+		defer objectDWLock.Unlock()
+	}
+
+	// Check if this request is only metadata update.
+	if cpSrcDstSame && srcInfo.metadataOnly {
 		// Read metadata associated with the object from all disks.
 		storageDisks := xl.getDisks()
 
@@ -88,6 +98,11 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 			return oi, toObjectErr(err, srcBucket, srcObject)
 		}
 
+		// Deny changing the retention or legal hold of a protected object.
+		if err = enforceRetentionForUpdate(xlMeta.ToObjectInfo(srcBucket, srcObject), srcInfo.UserDefined, dstOpts); err != nil {
+			return oi, err
+		}
+
 		// Update `xl.json` content on each disks.
 		for index := range metaArr {
 			metaArr[index].Meta = srcInfo.UserDefined
@@ -114,7 +129,17 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 		return xlMeta.ToObjectInfo(srcBucket, srcObject), nil
 	}
 
-	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
+	putOpts := ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+		BypassGovernance:     dstOpts.BypassGovernance,
+		IndexCB:              dstOpts.IndexCB,
+	}
+	if cpSrcDstSame {
+		return xl.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
+	}
 	return xl.PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
 }
 
@@ -157,10 +182,10 @@ func (xl xlObjects) GetObjectNInfo(ctx context.Context, bucket, object string, r
 	}
 
 	var objInfo ObjectInfo
-	objInfo, err = xl.getObjectInfo(ctx, bucket, object)
+	_, _, objInfo, err = xl.getObjectVersionInfo(ctx, bucket, object, opts)
 	if err != nil {
 		nsUnlocker()
-		return nil, toObjectErr(err, bucket, object)
+		return nil, err
 	}
 
 	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, opts.CheckCopyPrecondFn, nsUnlocker)
@@ -222,8 +247,17 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return toObjectErr(err, bucket, object)
 	}
 
+	// Noncurrent versions are read from the version store.
+	srcBucket, srcObject := bucket, object
+	if opts.VersionID != "" {
+		var err error
+		if srcBucket, srcObject, _, err = xl.getObjectVersionInfo(ctx, bucket, object, opts); err != nil {
+			return err
+		}
+	}
+
 	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), srcBucket, srcObject)
 
 	// get Quorum for this object
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -244,6 +278,11 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return err
 	}
 
+	// Delete markers have no content.
+	if xlMeta.DeleteMarker {
+		return ObjectNotFound{Bucket: bucket, Object: object}
+	}
+
 	// Reorder online disks based on erasure distribution order.
 	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
 
@@ -261,6 +300,11 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return InvalidRange{startOffset, length, xlMeta.Stat.Size}
 	}
 
+	// Data of transitioned objects is read from the remote tier.
+	if xlMeta.Transition != nil {
+		return getTransitionedObject(ctx, xlMeta.Transition, startOffset, length, writer)
+	}
+
 	// Get start part index and offset.
 	partIndex, partOffset, err := xlMeta.ObjectToPartOffset(ctx, startOffset)
 	if err != nil {
@@ -307,7 +351,7 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 				continue
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partName)
-			readers[index] = newBitrotReader(disk, bucket, pathJoin(object, partName), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			readers[index] = newBitrotReader(disk, srcBucket, pathJoin(srcObject, partName), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 		}
 		err := erasure.Decode(ctx, writer, readers, partOffset, partLength, partSize)
 		// Note: we should not be defer'ing the following closeBitrotReaders() call as we are inside a for loop i.e if we use defer, we would accumulate a lot of open files by the time
@@ -387,9 +431,9 @@ func (xl xlObjects) GetObjectInfo(ctx context.Context, bucket, object string, op
 		return info, nil
 	}
 
-	info, err := xl.getObjectInfo(ctx, bucket, object)
+	_, _, info, err := xl.getObjectVersionInfo(ctx, bucket, object, opts)
 	if err != nil {
-		return oi, toObjectErr(err, bucket, object)
+		return info, err
 	}
 
 	return info, nil
@@ -645,6 +689,7 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	modTime := UTCNow()
 
 	opts.UserDefined["etag"] = r.MD5CurrentHexString()
+	setCompressionIndex(opts.UserDefined, opts.IndexCB)
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -652,32 +697,50 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	}
 
 	if xl.isObject(bucket, object) {
-		// Deny if WORM is enabled
-		if globalWORMEnabled {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		// Deny overwriting a version protected by WORM or object lock.
+		err := enforceRetentionForOverwrite(bucket, object, opts, func() (ObjectInfo, error) {
+			return xl.getObjectInfo(ctx, bucket, object)
+		})
+		if err != nil {
+			return ObjectInfo{}, err
 		}
 
-		// Rename if an object already exists to temporary location.
-		newUniqueID := mustGetUUID()
+		// Keep the existing object as a noncurrent version if versioning is configured.
+		archived, err := xl.archiveLatestVersion(ctx, bucket, object, opts, writeQuorum)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+
+		if !archived {
+			// Remove the data of the replaced object from its remote tier.
+			if ti := xl.readTransitionInfo(ctx, bucket, object); ti != nil {
+				defer deleteTransitionedObject(ctx, ti)
+			}
 
-		// Delete successfully renamed object.
-		defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+			// Rename if an object already exists to temporary location.
+			newUniqueID := mustGetUUID()
 
-		// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
-		// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming the
-		// existing object if it is not present in quorum disks so users can overwrite stale objects.
-		_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
-		if err != nil {
-			return ObjectInfo{}, toObjectErr(err, bucket, object)
+			// Delete successfully renamed object.
+			defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+
+			// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
+			// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming the
+			// existing object if it is not present in quorum disks so users can overwrite stale objects.
+			_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
+			if err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
 		}
 	}
 
 	// Fill all the necessary metadata.
 	// Update `xl.json` content on each disks.
+	versionID := newObjectVersionID(opts)
 	for index := range partsMetadata {
 		partsMetadata[index].Meta = opts.UserDefined
 		partsMetadata[index].Stat.Size = n
 		partsMetadata[index].Stat.ModTime = modTime
+		partsMetadata[index].VersionID = versionID
 	}
 
 	// Write unique `xl.json` for each disk.
@@ -704,6 +767,8 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ContentType:     xlMeta.Meta["content-type"],
 		ContentEncoding: xlMeta.Meta["content-encoding"],
 		UserDefined:     xlMeta.Meta,
+		VersionID:       xlMeta.VersionID,
+		IsLatest:        true,
 	}
 
 	return objInfo, nil
@@ -845,6 +910,7 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 	errs := make([]error, len(objects))
 	writeQuorums := make([]int, len(objects))
 	isObjectDirs := make([]bool, len(objects))
+	transitions := make([]*transitionInfo, len(objects))
 
 	for i, object := range objects {
 		errs[i] = checkDelObjArgs(ctx, bucket, object)
@@ -900,16 +966,36 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 				errs[i] = toObjectErr(err, bucket, object)
 				continue
 			}
+			transitions[i] = getTransitionInfo(partsMetadata)
 		}
 	}
 
-	return xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	errs, err := xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	if err != nil {
+		return errs, err
+	}
+
+	// Remove the data of deleted objects from their remote tiers.
+	for i := range objects {
+		if errs[i] == nil && transitions[i] != nil {
+			deleteTransitionedObject(ctx, transitions[i])
+		}
+	}
+	return errs, nil
 }
 
 // DeleteObjects deletes objects in bulk, this function will still automatically split objects list
 // into smaller bulks if some object names are found to be duplicated in the delete list, splitting
 // into smaller bulks will avoid holding twice the write lock of the duplicated object names.
-func (xl xlObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+func (xl xlObjects) DeleteObjects(ctx context.Context, bucket string, objects []string, opts ObjectOptions) ([]error, error) {
+	// Each object in a versioned bucket gets its own delete marker.
+	if opts.isVersioningConfigured() {
+		deleteErrs := make([]error, len(objects))
+		for i, object := range objects {
+			_, deleteErrs[i] = xl.DeleteObject(ctx, bucket, object, opts)
+		}
+		return deleteErrs, nil
+	}
 
 	var (
 		i, start, end int
@@ -959,20 +1045,32 @@ func (xl xlObjects) DeleteObjects(ctx context.Context, bucket string, objects []
 // DeleteObject - deletes an object, this call doesn't necessary reply
 // any error as it is not necessary for the handler to reply back a
 // response to the client request.
-func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (err error) {
+func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	// Acquire a write lock before deleting the object.
 	objectLock := xl.nsMutex.NewNSLock(ctx, bucket, object)
 	if perr := objectLock.GetLock(globalOperationTimeout); perr != nil {
-		return perr
+		return objInfo, perr
 	}
 	defer objectLock.Unlock()
 
 	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
-		return err
+		return objInfo, err
 	}
 
 	var writeQuorum int
 	var isObjectDir = hasSuffix(object, slashSeparator)
+	var transition *transitionInfo
+
+	if !isObjectDir {
+		// Permanently delete a specific version.
+		if opts.VersionID != "" {
+			return xl.deleteObjectVersion(ctx, bucket, object, opts)
+		}
+		// Hide the object behind a delete marker.
+		if opts.isVersioningConfigured() {
+			return xl.putDeleteMarker(ctx, bucket, object, opts)
+		}
+	}
 
 	if isObjectDir {
 		_, err = xl.getObjectInfoDir(ctx, bucket, object)
@@ -983,7 +1081,7 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 			}
 		}
 		if err != nil {
-			return toObjectErr(err, bucket, object)
+			return objInfo, toObjectErr(err, bucket, object)
 		}
 	}
 
@@ -995,17 +1093,103 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		// get Quorum for this object
 		_, writeQuorum, err = objectQuorumFromMeta(ctx, xl, partsMetadata, errs)
 		if err != nil {
-			return toObjectErr(err, bucket, object)
+			return objInfo, toObjectErr(err, bucket, object)
 		}
+		transition = getTransitionInfo(partsMetadata)
 	}
 
 	// Delete the object on all disks.
 	if err = xl.deleteObject(ctx, bucket, object, writeQuorum, isObjectDir); err != nil {
-		return toObjectErr(err, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Remove the data of the object from its remote tier.
+	if transition != nil {
+		deleteTransitionedObject(ctx, transition)
 	}
 
 	// Success.
-	return nil
+	return ObjectInfo{Bucket: bucket, Name: object}, nil
+}
+
+// UpdateObjectMetadata - merges metadata into the metadata of the object
+// version requested in opts, keys with empty values are removed.
+func (xl xlObjects) UpdateObjectMetadata(ctx context.Context, bucket, object string, metadata map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	// Acquire a write lock before updating the object.
+	objectLock := xl.nsMutex.NewNSLock(ctx, bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	srcBucket, srcObject, objInfo, err := xl.getObjectVersionInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return objInfo, err
+	}
+
+	// The object may have changed since the caller read it.
+	if opts.CheckUpdatePrecondFn != nil && opts.CheckUpdatePrecondFn(objInfo) {
+		return objInfo, PreConditionFailed{}
+	}
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), srcBucket, srcObject)
+
+	// get Quorum for this object
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return objInfo, toObjectErr(reducedErr, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(xl.getDisks(), metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Reorder online disks and metadata based on erasure distribution order.
+	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
+	metaArr = shufflePartsMetadata(metaArr, xlMeta.Erasure.Distribution)
+
+	// Update `xl.json` content on each online disk.
+	for index := range metaArr {
+		if onlineDisks[index] == nil {
+			continue
+		}
+		metaArr[index].Meta = mergeObjectMetadata(metaArr[index].Meta, metadata)
+	}
+	xlMeta.Meta = mergeObjectMetadata(xlMeta.Meta, metadata)
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Rename atomically `xl.json` from tmp location to destination for each disk.
+	if _, err = renameXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, srcBucket, srcObject, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	isLatest := objInfo.IsLatest
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = isLatest
+	return objInfo, nil
 }
 
 // ListObjectsV2 lists all blobs in bucket filtered by prefix
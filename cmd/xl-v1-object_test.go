@@ -100,7 +100,7 @@ func TestXLDeleteObjectBasic(t *testing.T) {
 		t.Fatalf("XL Object upload failed: <ERROR> %s", err)
 	}
 	for i, test := range testCases {
-		actualErr := xl.DeleteObject(context.Background(), test.bucket, test.object)
+		_, actualErr := xl.DeleteObject(context.Background(), test.bucket, test.object, ObjectOptions{})
 		if test.expectedErr != nil && actualErr != test.expectedErr {
 			t.Errorf("Test %d: Expected to fail with %s, but failed with %s", i+1, test.expectedErr, actualErr)
 		}
@@ -164,7 +164,7 @@ func TestXLDeleteObjectsXLSet(t *testing.T) {
 	}
 
 	objectNames := toObjectNames(testCases)
-	delErrs, err := xlSets.DeleteObjects(context.Background(), bucketName, objectNames)
+	delErrs, err := xlSets.DeleteObjects(context.Background(), bucketName, objectNames, ObjectOptions{})
 	if err != nil {
 		t.Errorf("Failed to call DeleteObjects with the error: `%v`", err)
 	}
@@ -215,7 +215,7 @@ func TestXLDeleteObjectDiskNotFound(t *testing.T) {
 	for i := range xl.storageDisks[:7] {
 		xl.storageDisks[i] = newNaughtyDisk(xl.storageDisks[i], nil, errFaultyDisk)
 	}
-	err = obj.DeleteObject(context.Background(), bucket, object)
+	_, err = obj.DeleteObject(context.Background(), bucket, object, ObjectOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -229,7 +229,7 @@ func TestXLDeleteObjectDiskNotFound(t *testing.T) {
 	// Remove one more disk to 'lose' quorum, by setting it to nil.
 	xl.storageDisks[7] = nil
 	xl.storageDisks[8] = nil
-	err = obj.DeleteObject(context.Background(), bucket, object)
+	_, err = obj.DeleteObject(context.Background(), bucket, object, ObjectOptions{})
 	// since majority of disks are not available, metaquorum is not achieved and hence errXLReadQuorum error
 	if err != toObjectErr(errXLReadQuorum, bucket, object) {
 		t.Errorf("Expected deleteObject to fail with %v, but failed with %v", toObjectErr(errXLReadQuorum, bucket, object), err)
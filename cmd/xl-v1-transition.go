@@ -0,0 +1,169 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+)
+
+// getTransitionInfo - returns the remote location of the data of an
+// object from its `xl.json`, nil if the data is stored locally.
+func getTransitionInfo(metaArr []xlMetaV1) *transitionInfo {
+	for _, meta := range metaArr {
+		if meta.IsValid() && meta.Transition != nil {
+			return meta.Transition
+		}
+	}
+	return nil
+}
+
+// readTransitionInfo - reads the remote location of the data of an
+// object, nil if the data is stored locally or the object is missing.
+func (xl xlObjects) readTransitionInfo(ctx context.Context, bucket, object string) *transitionInfo {
+	metaArr, _ := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	return getTransitionInfo(metaArr)
+}
+
+// TransitionObject - moves the data of the object version requested in
+// opts to the remote tier, `xl.json` of the version is kept as a stub
+// recording the remote location which is read from on GET.
+func (xl xlObjects) TransitionObject(ctx context.Context, bucket, object, tier string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	// Object data is uploaded to the remote tier under a read lock.
+	readLock := xl.nsMutex.NewNSLock(ctx, bucket, object)
+	if err = readLock.GetRLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+
+	_, _, objInfo, err = xl.getObjectVersionInfo(ctx, bucket, object, opts)
+	if err != nil || objInfo.TransitionTier != "" || hasSuffix(object, slashSeparator) {
+		readLock.RUnlock()
+		return objInfo, err
+	}
+
+	// Data is transitioned as stored, encrypted and compressed
+	// objects are decrypted and decompressed when read through.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(xl.getObject(ctx, bucket, object, 0, objInfo.Size, pw, "", opts))
+	}()
+	ti, err := putTransitionedObject(ctx, tier, pathJoin(bucket, object, mustGetUUID()), pr, objInfo.Size)
+	pr.Close()
+	readLock.RUnlock()
+	if err != nil {
+		return objInfo, err
+	}
+
+	// Acquire a write lock before replacing `xl.json` with the stub.
+	writeLock := xl.nsMutex.NewNSLock(ctx, bucket, object)
+	if err = writeLock.GetLock(globalOperationTimeout); err != nil {
+		deleteTransitionedObject(ctx, &ti)
+		return objInfo, err
+	}
+	defer writeLock.Unlock()
+
+	if objInfo, err = xl.putTransitionStub(ctx, bucket, object, objInfo, ti, opts); err != nil {
+		deleteTransitionedObject(ctx, &ti)
+	}
+	return objInfo, err
+}
+
+// putTransitionStub - replaces the object version with a stub recording
+// the remote location of its data, fails if the version was modified
+// since its data was transitioned.
+func (xl xlObjects) putTransitionStub(ctx context.Context, bucket, object string, transitioned ObjectInfo, ti transitionInfo, opts ObjectOptions) (ObjectInfo, error) {
+	srcBucket, srcObject, objInfo, err := xl.getObjectVersionInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return objInfo, err
+	}
+	if objInfo.VersionID != transitioned.VersionID || objInfo.ETag != transitioned.ETag ||
+		!objInfo.ModTime.Equal(transitioned.ModTime) || objInfo.TransitionTier != "" {
+		return objInfo, PreConditionFailed{}
+	}
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), srcBucket, srcObject)
+
+	// get Quorum for this object
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return objInfo, toObjectErr(reducedErr, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(xl.getDisks(), metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Reorder online disks and metadata based on erasure distribution order.
+	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
+	metaArr = shufflePartsMetadata(metaArr, xlMeta.Erasure.Distribution)
+
+	// The stub keeps the metadata of the object and its parts, needed
+	// to decrypt multipart objects, without the erasure checksums.
+	for index := range metaArr {
+		if onlineDisks[index] == nil {
+			continue
+		}
+		metaArr[index].Erasure.Checksums = nil
+		metaArr[index].Transition = &ti
+	}
+	xlMeta.Erasure.Checksums = nil
+	xlMeta.Transition = &ti
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Rename the object with its parts to a temporary location.
+	newUniqueID := mustGetUUID()
+
+	// Delete successfully renamed object.
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+
+	if _, err = rename(ctx, xl.getDisks(), srcBucket, srcObject, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound}); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Rename the stub to the location of the object.
+	if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, srcBucket, srcObject, true, writeQuorum, nil); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	isLatest := objInfo.IsLatest
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = isLatest
+	return objInfo, nil
+}
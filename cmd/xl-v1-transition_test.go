@@ -0,0 +1,237 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/sio"
+)
+
+// Tests transitioning object data to a remote tier served by a
+// second MinIO server, reading it back and deleting it.
+func TestXLTransitionObject(t *testing.T) {
+	ctx := context.Background()
+
+	remote := StartTestServer(t, "FS")
+	defer remote.Stop()
+	if err := remote.Obj.MakeBucketWithLocation(ctx, "warm", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalServerConfig.Tier = map[string]tierConfig{
+		"WARM": {
+			Endpoint:  remote.Server.URL,
+			AccessKey: remote.AccessKey,
+			SecretKey: remote.SecretKey,
+			Bucket:    "warm",
+			Prefix:    "tier",
+		},
+	}
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 3*1024)
+	if _, err = rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = obj.TransitionObject(ctx, bucket, object, "COLD", ObjectOptions{}); err != (RemoteTierNotFound{Tier: "COLD"}) {
+		t.Fatalf("expected RemoteTierNotFound, got %v", err)
+	}
+
+	objInfo, err := obj.TransitionObject(ctx, bucket, object, "WARM", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.TransitionTier != "WARM" || objInfo.StorageClass != "WARM" || objInfo.Size != int64(len(data)) {
+		t.Fatalf("unexpected object info after transition %+v", objInfo)
+	}
+
+	result, err := remote.Obj.ListObjects(ctx, "warm", "tier/", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("expected one object on the remote tier, got %d", len(result.Objects))
+	}
+
+	// Object data and ranges of it are read from the remote tier.
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("transitioned object data mismatch")
+	}
+	buf.Reset()
+	if err = obj.GetObject(ctx, bucket, object, 1024, 100, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data[1024:1124]) {
+		t.Fatal("transitioned object range mismatch")
+	}
+
+	// Transitioning an already transitioned object is a no-op.
+	if objInfo, err = obj.TransitionObject(ctx, bucket, object, "WARM", ObjectOptions{}); err != nil || objInfo.TransitionTier != "WARM" {
+		t.Fatalf("unexpected result transitioning again %+v, %v", objInfo, err)
+	}
+
+	// Deleting the object removes its data from the remote tier.
+	if _, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = remote.Obj.ListObjects(ctx, "warm", "tier/", "", "", 10); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("expected no objects on the remote tier, got %d", len(result.Objects))
+	}
+}
+
+// Tests transitioning a multipart SSE-S3 object and reading it, and
+// ranges spanning its parts, back through the decryption of its parts.
+func TestXLTransitionEncryptedMultipartObject(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(kms crypto.KMS, keyID string) { GlobalKMS, globalKMSKeyID = kms, keyID }(GlobalKMS, globalKMSKeyID)
+	GlobalKMS, globalKMSKeyID = crypto.NewKMS([32]byte{}), "my-key"
+
+	remote := StartTestServer(t, "FS")
+	defer remote.Stop()
+	if err := remote.Obj.MakeBucketWithLocation(ctx, "warm", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalServerConfig.Tier = map[string]tierConfig{
+		"WARM": {
+			Endpoint:  remote.Server.URL,
+			AccessKey: remote.AccessKey,
+			SecretKey: remote.SecretKey,
+			Bucket:    "warm",
+			Prefix:    "tier",
+		},
+	}
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, "http://localhost/", nil)
+	req.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	metadata := map[string]string{}
+	if err = setEncryptionMetadata(req, bucket, object, metadata); err != nil {
+		t.Fatal(err)
+	}
+	metadata[crypto.SSEMultipart] = ""
+	objectKey, err := decryptObjectInfo(nil, bucket, object, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{UserDefined: metadata})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 6*humanize.MiByte)
+	if _, err = rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for partID, part := range [][]byte{data[:5*humanize.MiByte], data[5*humanize.MiByte:]} {
+		var partIDbin [4]byte
+		binary.LittleEndian.PutUint32(partIDbin[:], uint32(partID+1))
+		mac := hmac.New(sha256.New, objectKey)
+		mac.Write(partIDbin[:])
+		encReader, err := sio.EncryptReader(bytes.NewReader(part), sio.Config{Key: mac.Sum(nil)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := ObjectInfo{Size: int64(len(part))}
+		hashReader, err := hash.NewReader(encReader, info.EncryptedSize(), "", "", int64(len(part)), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pReader := NewPutObjReader(hashReader, hashReader, objectKey)
+		partInfo, err := obj.PutObjectPart(ctx, bucket, object, uploadID, partID+1, pReader, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: partInfo.PartNumber, ETag: partInfo.ETag})
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objInfo, err := obj.TransitionObject(ctx, bucket, object, "WARM", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objInfo.Parts) != 2 || !isEncryptedMultipart(objInfo) {
+		t.Fatalf("expected the transitioned object to keep its two encrypted parts, got %+v", objInfo.Parts)
+	}
+
+	testCases := []struct {
+		rs       *HTTPRangeSpec
+		expected []byte
+	}{
+		{nil, data},
+		{&HTTPRangeSpec{Start: 5*humanize.MiByte - 100, End: 5*humanize.MiByte + 99}, data[5*humanize.MiByte-100 : 5*humanize.MiByte+100]},
+		{&HTTPRangeSpec{Start: 5*humanize.MiByte + 1000, End: 5*humanize.MiByte + 1999}, data[5*humanize.MiByte+1000 : 5*humanize.MiByte+2000]},
+	}
+	for i, testCase := range testCases {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, testCase.rs, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(got, testCase.expected) {
+			t.Fatalf("Test %d: transitioned object data mismatch", i+1)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path"
+)
+
+// getObjectVersionInfo - resolves the version of an object requested in
+// opts, returns the location of its `xl.json` along with the object info.
+// Delete markers are reported as not found unless explicitly requested.
+func (xl xlObjects) getObjectVersionInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (string, string, ObjectInfo, error) {
+	objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return "", "", objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if opts.VersionID == "" {
+		if err != nil {
+			return "", "", objInfo, toObjectErr(err, bucket, object)
+		}
+		if objInfo.DeleteMarker {
+			return "", "", objInfo, ObjectNotFound{Bucket: bucket, Object: object}
+		}
+		objInfo.IsLatest = true
+		return bucket, object, objInfo, nil
+	}
+
+	srcBucket, srcObject := bucket, object
+	versionID := versionIDFromOpts(opts)
+	if err == nil && objInfo.VersionID == versionID {
+		objInfo.IsLatest = true
+	} else {
+		srcBucket, srcObject = minioMetaBucket, versionStorePath(bucket, object, versionID)
+		objInfo, err = xl.getObjectInfo(ctx, srcBucket, srcObject)
+		if err != nil {
+			if err == errFileNotFound {
+				return "", "", objInfo, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+			}
+			return "", "", objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.Bucket, objInfo.Name = bucket, object
+	}
+
+	if objInfo.DeleteMarker {
+		return "", "", objInfo, MethodNotAllowed{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	}
+	return srcBucket, srcObject, objInfo, nil
+}
+
+// listObjectVersionsOf - returns all noncurrent versions of an object
+// from the version store, newest version first.
+func (xl xlObjects) listObjectVersionsOf(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	versionsDir := retainSlash(pathJoin(versionsMetaPrefix, bucket, object))
+	listDir := listDirFactory(ctx, xl.getLoadBalancedDisks()...)
+
+	var versions []ObjectInfo
+	for _, entry := range listDir(minioMetaBucket, versionsDir, "") {
+		// Directories without `xl.json` belong to other objects.
+		if hasSuffix(entry, slashSeparator) {
+			continue
+		}
+		objInfo, err := xl.getObjectInfo(ctx, minioMetaBucket, pathJoin(versionsDir, entry))
+		if err != nil {
+			if IsErrIgnored(err, errFileNotFound, errXLReadQuorum) {
+				continue
+			}
+			return nil, toObjectErr(err, bucket, object)
+		}
+		objInfo.Bucket, objInfo.Name = bucket, object
+		versions = append(versions, objInfo)
+	}
+	sortObjectVersions(versions)
+	return versions, nil
+}
+
+// deleteStoredVersion - removes a noncurrent version from the version store.
+func (xl xlObjects) deleteStoredVersion(ctx context.Context, bucket, object, versionID string, writeQuorum int) error {
+	versionPath := versionStorePath(bucket, object, versionID)
+	ti := xl.readTransitionInfo(ctx, minioMetaBucket, versionPath)
+	if err := xl.deleteObject(ctx, minioMetaBucket, versionPath, writeQuorum, false); err != nil {
+		return err
+	}
+	if ti != nil {
+		deleteTransitionedObject(ctx, ti)
+	}
+	return nil
+}
+
+// archiveLatestVersion - moves the latest version of an object into the
+// version store before it gets replaced by a new version. Returns false
+// if the latest version is a null version which is to be overwritten
+// instead, which is the case for buckets with versioning suspended.
+func (xl xlObjects) archiveLatestVersion(ctx context.Context, bucket, object string, opts ObjectOptions, writeQuorum int) (bool, error) {
+	if !opts.isVersioningConfigured() {
+		return false, nil
+	}
+
+	// There is only ever one null version of an object, a new
+	// null version replaces the one in the version store.
+	if !opts.Versioned {
+		if err := xl.deleteStoredVersion(ctx, bucket, object, "", writeQuorum); err != nil {
+			return false, toObjectErr(err, bucket, object)
+		}
+	}
+
+	objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		if err == errFileNotFound {
+			return false, nil
+		}
+		return false, toObjectErr(err, bucket, object)
+	}
+	if objInfo.VersionID == "" && !opts.Versioned {
+		return false, nil
+	}
+
+	_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaBucket, versionStorePath(bucket, object, objInfo.VersionID),
+		true, writeQuorum, []error{errFileNotFound})
+	if err != nil {
+		return false, toObjectErr(err, bucket, object)
+	}
+	return true, nil
+}
+
+// promoteLatestVersion - makes the newest noncurrent version of an object
+// its latest version, called after the latest version has been deleted.
+func (xl xlObjects) promoteLatestVersion(ctx context.Context, bucket, object string) error {
+	versions, err := xl.listObjectVersionsOf(ctx, bucket, object)
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+
+	versionPath := versionStorePath(bucket, object, versions[0].VersionID)
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), minioMetaBucket, versionPath)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	_, err = rename(ctx, xl.getDisks(), minioMetaBucket, versionPath, bucket, object, true, writeQuorum, []error{errFileNotFound})
+	return toObjectErr(err, bucket, object)
+}
+
+// putDeleteMarker - replaces the latest version of an object with a
+// delete marker, the replaced version is kept in the version store.
+func (xl xlObjects) putDeleteMarker(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	// A delete marker can not be created beneath another object.
+	if xl.parentDirIsObject(ctx, bucket, path.Dir(object)) {
+		return ObjectInfo{}, toObjectErr(errFileParentIsFile, bucket, object)
+	}
+
+	dataDrives, parityDrives := getRedundancyCount("", len(xl.getDisks()))
+	writeQuorum := dataDrives + 1
+
+	archived, err := xl.archiveLatestVersion(ctx, bucket, object, opts, writeQuorum)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if !archived && xl.isObject(bucket, object) {
+		// Latest version is the null version, delete it.
+		ti := xl.readTransitionInfo(ctx, bucket, object)
+		if err = xl.deleteObject(ctx, bucket, object, writeQuorum, false); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		if ti != nil {
+			deleteTransitionedObject(ctx, ti)
+		}
+	}
+
+	xlMeta := newXLMetaV1(object, dataDrives, parityDrives)
+	xlMeta.Stat.ModTime = UTCNow()
+	xlMeta.Meta = map[string]string{}
+	xlMeta.VersionID = newObjectVersionID(opts)
+	xlMeta.DeleteMarker = true
+
+	metaArr := make([]xlMetaV1, len(xl.getDisks()))
+	for index := range metaArr {
+		metaArr[index] = xlMeta
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	onlineDisks := shuffleDisks(xl.getDisks(), xlMeta.Erasure.Distribution)
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	objInfo := xlMeta.ToObjectInfo(bucket, object)
+	objInfo.IsLatest = true
+	return objInfo, nil
+}
+
+// deleteObjectVersion - permanently deletes the version of an object
+// requested in opts, deleting the latest version promotes the newest
+// noncurrent version.
+func (xl xlObjects) deleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	versionID := versionIDFromOpts(opts)
+
+	objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+	if err != nil && err != errFileNotFound {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	srcBucket, srcObject := bucket, object
+	isLatest := err == nil && objInfo.VersionID == versionID
+	if !isLatest {
+		srcBucket, srcObject = minioMetaBucket, versionStorePath(bucket, object, versionID)
+		objInfo, err = xl.getObjectInfo(ctx, srcBucket, srcObject)
+		if err != nil {
+			if err == errFileNotFound {
+				return objInfo, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+			}
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		objInfo.Bucket, objInfo.Name = bucket, object
+	}
+
+	if err = enforceRetentionForDeletion(objInfo, opts); err != nil {
+		return objInfo, err
+	}
+
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), srcBucket, srcObject)
+	_, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if err = xl.deleteObject(ctx, srcBucket, srcObject, writeQuorum, false); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Remove the data of the version from its remote tier.
+	if ti := getTransitionInfo(metaArr); ti != nil {
+		deleteTransitionedObject(ctx, ti)
+	}
+
+	if isLatest {
+		objInfo.IsLatest = true
+		if err = xl.promoteLatestVersion(ctx, bucket, object); err != nil {
+			return objInfo, err
+		}
+	}
+
+	return objInfo, nil
+}
+
+// ListObjectVersions - lists all versions of the objects in a bucket.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, delimiter, xl); err != nil {
+		return ListObjectVersionsInfo{}, err
+	}
+
+	listFn := func(marker string, maxKeys int) (ListObjectsInfo, error) {
+		return xl.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	}
+	latestFn := func(object string) (ObjectInfo, error) {
+		objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	versionsFn := func(object string) ([]ObjectInfo, error) {
+		return xl.listObjectVersionsOf(ctx, bucket, object)
+	}
+	return listObjectVersions(marker, versionIDMarker, maxKeys, listFn, latestFn, versionsFn)
+}
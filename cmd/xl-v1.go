@@ -22,6 +22,7 @@ import (
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bpool"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // XL constants.
@@ -151,3 +152,62 @@ func getStorageInfo(disks []StorageAPI) StorageInfo {
 func (xl xlObjects) StorageInfo(ctx context.Context) StorageInfo {
 	return getStorageInfo(xl.getDisks())
 }
+
+// CrawlAndGetDataUsage - returns the data usage of all buckets by
+// walking the objects of a single online disk, stops early when endCh
+// is closed. Objects missing on the crawled disk are not accounted.
+func (xl xlObjects) CrawlAndGetDataUsage(ctx context.Context, endCh <-chan struct{}) madmin.DataUsageInfo {
+	dataUsageInfo := newDataUsageInfo()
+
+	for _, disk := range xl.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		volsInfo, err := disk.ListVols()
+		if err != nil {
+			continue
+		}
+
+		for _, volInfo := range volsInfo {
+			if isReservedOrInvalidBucket(volInfo.Name, true) {
+				continue
+			}
+			addBucketToDataUsage(&dataUsageInfo, volInfo.Name)
+			if !crawlDiskBucket(ctx, disk, volInfo.Name, &dataUsageInfo, endCh) {
+				return dataUsageInfo
+			}
+		}
+		return dataUsageInfo
+	}
+
+	return dataUsageInfo
+}
+
+// crawlDiskBucket - accounts for all the objects of a bucket on the
+// given disk, returns false if the crawl was stopped by endCh.
+func crawlDiskBucket(ctx context.Context, disk StorageAPI, bucket string, dataUsageInfo *madmin.DataUsageInfo, endCh <-chan struct{}) bool {
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	walkCh, err := disk.Walk(bucket, "", "", true, xlMetaJSONFile, readMetadata, endWalkCh)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return true
+	}
+
+	for fi := range walkCh {
+		select {
+		case <-endCh:
+			return false
+		default:
+		}
+		// Skip directories and the latest versions
+		// of objects which are delete markers.
+		if fi.Mode.IsDir() || fi.DeleteMarker {
+			continue
+		}
+		waitForLowHTTPReq()
+		addObjectToDataUsage(dataUsageInfo, bucket, fi.Size)
+	}
+	return true
+}
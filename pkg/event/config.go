@@ -20,7 +20,9 @@ import (
 	"encoding/xml"
 	"errors"
 	"io"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -119,9 +121,113 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
+// Names of the object filter rules.
+const (
+	filterMinSize        = "min-size"
+	filterMaxSize        = "max-size"
+	filterContentType    = "content-type"
+	filterMetadataPrefix = "x-amz-meta-"
+)
+
+// ObjectFilterRule - represents elements inside <S3Object><FilterRule>...</FilterRule></S3Object>,
+// a MinIO extension to filter events by the size, content type and user metadata of the object.
+type ObjectFilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (filter *ObjectFilterRule) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectFilterRule ObjectFilterRule
+	rule := objectFilterRule{}
+	if err := d.DecodeElement(&rule, &start); err != nil {
+		return err
+	}
+
+	rule.Name = strings.ToLower(rule.Name)
+	switch {
+	case rule.Name == filterMinSize, rule.Name == filterMaxSize:
+		size, err := strconv.ParseInt(rule.Value, 10, 64)
+		if err != nil || size < 0 || (size == 0 && rule.Name == filterMaxSize) {
+			return &ErrInvalidObjectFilterValue{rule.Name, rule.Value}
+		}
+	case rule.Name == filterContentType:
+	case strings.HasPrefix(rule.Name, filterMetadataPrefix) && len(rule.Name) > len(filterMetadataPrefix):
+	default:
+		return &ErrInvalidObjectFilterName{rule.Name}
+	}
+
+	if len(rule.Value) > 1024 || !utf8.ValidString(rule.Value) {
+		return &ErrInvalidObjectFilterValue{rule.Name, rule.Value}
+	}
+
+	*filter = ObjectFilterRule(rule)
+
+	return nil
+}
+
+// ObjectFilterRuleList - represents multiple <FilterRule>...</FilterRule> inside <S3Object>
+type ObjectFilterRuleList struct {
+	Rules []ObjectFilterRule `xml:"FilterRule,omitempty"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (ruleList *ObjectFilterRuleList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type objectFilterRuleList ObjectFilterRuleList
+	rules := objectFilterRuleList{}
+	if err := d.DecodeElement(&rules, &start); err != nil {
+		return err
+	}
+
+	// Every filter rule name must be used only once.
+	nameSet := set.NewStringSet()
+	for _, rule := range rules.Rules {
+		if nameSet.Contains(rule.Name) {
+			return &ErrDuplicateObjectFilterName{rule.Name}
+		}
+
+		nameSet.Add(rule.Name)
+	}
+
+	filter := ObjectFilterRuleList(rules).Filter()
+	if filter.MaxSize > 0 && filter.MinSize > filter.MaxSize {
+		return &ErrInvalidObjectFilterValue{filterMinSize, strconv.FormatInt(filter.MinSize, 10)}
+	}
+
+	*ruleList = ObjectFilterRuleList(rules)
+	return nil
+}
+
+// Filter - returns object filter using the filter rules.
+func (ruleList ObjectFilterRuleList) Filter() ObjectFilter {
+	var filter ObjectFilter
+	metadata := make(url.Values)
+
+	for _, rule := range ruleList.Rules {
+		switch rule.Name {
+		case filterMinSize:
+			filter.MinSize, _ = strconv.ParseInt(rule.Value, 10, 64)
+		case filterMaxSize:
+			filter.MaxSize, _ = strconv.ParseInt(rule.Value, 10, 64)
+		case filterContentType:
+			filter.ContentType = rule.Value
+		default:
+			metadata.Set(rule.Name, rule.Value)
+		}
+	}
+
+	// Encode sorts metadata by name.
+	filter.Metadata = metadata.Encode()
+	return filter
+}
+
 // S3Key - represents elements inside <S3Key>...</S3Key>
 type S3Key struct {
 	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+	// MinIO extension to filter events by the object.
+	ObjectRuleList *ObjectFilterRuleList `xml:"S3Object,omitempty" json:"S3Object,omitempty"`
 }
 
 // common - represents common elements inside <QueueConfiguration>, <CloudFunctionConfiguration>
@@ -185,8 +291,11 @@ func (q *Queue) SetRegion(region string) {
 
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
-	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	rule := Rule{Pattern: q.Filter.RuleList.Pattern()}
+	if q.Filter.ObjectRuleList != nil {
+		rule.Filter = q.Filter.ObjectRuleList.Filter()
+	}
+	return newRulesMap(q.Events, rule, q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
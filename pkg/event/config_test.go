@@ -129,6 +129,42 @@ func TestFilterRuleListPattern(t *testing.T) {
 	}
 }
 
+func TestObjectFilterRuleListUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		data           []byte
+		expectedResult ObjectFilter
+		expectErr      bool
+	}{
+		{[]byte(`<S3Object></S3Object>`), ObjectFilter{}, false},
+		{[]byte(`<S3Object><FilterRule><Name>min-size</Name><Value>1024</Value></FilterRule><FilterRule><Name>max-size</Name><Value>1048576</Value></FilterRule></S3Object>`), ObjectFilter{MinSize: 1024, MaxSize: 1048576}, false},
+		{[]byte(`<S3Object><FilterRule><Name>content-type</Name><Value>image/*</Value></FilterRule></S3Object>`), ObjectFilter{ContentType: "image/*"}, false},
+		{[]byte(`<S3Object><FilterRule><Name>X-Amz-Meta-Project</Name><Value>alpha</Value></FilterRule><FilterRule><Name>x-amz-meta-env</Name><Value>prod*</Value></FilterRule></S3Object>`), ObjectFilter{Metadata: "x-amz-meta-env=prod%2A&x-amz-meta-project=alpha"}, false},
+		{[]byte(`<S3Object><FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>x-amz-meta-</Name><Value>alpha</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>min-size</Name><Value>-1</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>max-size</Name><Value>0</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>max-size</Name><Value>1KiB</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>min-size</Name><Value>2048</Value></FilterRule><FilterRule><Name>max-size</Name><Value>1024</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+		{[]byte(`<S3Object><FilterRule><Name>content-type</Name><Value>image/*</Value></FilterRule><FilterRule><Name>Content-Type</Name><Value>text/*</Value></FilterRule></S3Object>`), ObjectFilter{}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := &ObjectFilterRuleList{}
+		err := xml.Unmarshal(testCase.data, result)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if filter := result.Filter(); filter != testCase.expectedResult {
+				t.Fatalf("test %v: data: expected: %+v, got: %+v", i+1, testCase.expectedResult, filter)
+			}
+		}
+	}
+}
+
 func TestQueueUnmarshalXML(t *testing.T) {
 	dataCase1 := []byte(`
 <QueueConfiguration>
@@ -376,8 +412,38 @@ func TestQueueToRulesMap(t *testing.T) {
 		panic(err)
 	}
 
+	data = []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+    <Filter>
+        <S3Key>
+            <FilterRule>
+                <Name>prefix</Name>
+                <Value>images/</Value>
+            </FilterRule>
+        </S3Key>
+        <S3Object>
+            <FilterRule>
+                <Name>min-size</Name>
+                <Value>1024</Value>
+            </FilterRule>
+            <FilterRule>
+                <Name>content-type</Name>
+                <Value>image/*</Value>
+            </FilterRule>
+        </S3Object>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>`)
+	queueCase3 := &Queue{}
+	if err := xml.Unmarshal(data, queueCase3); err != nil {
+		panic(err)
+	}
+
 	rulesMapCase1 := NewRulesMap([]Name{ObjectAccessedAll, ObjectCreatedAll, ObjectRemovedAll}, "*", TargetID{"1", "webhook"})
 	rulesMapCase2 := NewRulesMap([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"1", "webhook"})
+	rulesMapCase3 := newRulesMap([]Name{ObjectCreatedPut}, Rule{"images/*", ObjectFilter{MinSize: 1024, ContentType: "image/*"}}, TargetID{"1", "webhook"})
 
 	testCases := []struct {
 		queue          *Queue
@@ -385,6 +451,7 @@ func TestQueueToRulesMap(t *testing.T) {
 	}{
 		{queueCase1, rulesMapCase1},
 		{queueCase2, rulesMapCase2},
+		{queueCase3, rulesMapCase3},
 	}
 
 	for i, testCase := range testCases {
@@ -810,7 +877,7 @@ func TestConfigToRulesMap(t *testing.T) {
 	rulesMapCase2 := NewRulesMap([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"1", "webhook"})
 
 	rulesMapCase3 := NewRulesMap([]Name{ObjectAccessedAll, ObjectCreatedAll, ObjectRemovedAll}, "*", TargetID{"1", "webhook"})
-	rulesMapCase3.add([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"2", "amqp"})
+	rulesMapCase3.add([]Name{ObjectCreatedPut}, Rule{Pattern: "images/*jpg"}, TargetID{"2", "amqp"})
 
 	testCases := []struct {
 		config         *Config
@@ -32,6 +32,12 @@ func IsEventError(err error) bool {
 		return true
 	case ErrInvalidFilterValue, *ErrInvalidFilterValue:
 		return true
+	case ErrInvalidObjectFilterName, *ErrInvalidObjectFilterName:
+		return true
+	case ErrDuplicateObjectFilterName, *ErrDuplicateObjectFilterName:
+		return true
+	case ErrInvalidObjectFilterValue, *ErrInvalidObjectFilterValue:
+		return true
 	case ErrDuplicateEventName, *ErrDuplicateEventName:
 		return true
 	case ErrUnsupportedConfiguration, *ErrUnsupportedConfiguration:
@@ -83,6 +89,34 @@ func (err ErrInvalidFilterValue) Error() string {
 	return fmt.Sprintf("invalid filter value '%v'", err.FilterValue)
 }
 
+// ErrInvalidObjectFilterName - invalid object filter name error.
+type ErrInvalidObjectFilterName struct {
+	FilterName string
+}
+
+func (err ErrInvalidObjectFilterName) Error() string {
+	return fmt.Sprintf("invalid object filter name '%v'", err.FilterName)
+}
+
+// ErrDuplicateObjectFilterName - more than one usage of an object filter name error.
+type ErrDuplicateObjectFilterName struct {
+	FilterName string
+}
+
+func (err ErrDuplicateObjectFilterName) Error() string {
+	return fmt.Sprintf("more than one %v in object filter rule", err.FilterName)
+}
+
+// ErrInvalidObjectFilterValue - invalid object filter value error.
+type ErrInvalidObjectFilterValue struct {
+	FilterName  string
+	FilterValue string
+}
+
+func (err ErrInvalidObjectFilterValue) Error() string {
+	return fmt.Sprintf("invalid object filter value '%v' of '%v'", err.FilterValue, err.FilterName)
+}
+
 // ErrDuplicateEventName - duplicate event name error.
 type ErrDuplicateEventName struct {
 	EventName Name
@@ -16,6 +16,8 @@
 
 package event
 
+import "encoding/json"
+
 const (
 	// NamespaceFormat - namespace log format used in some event targets.
 	NamespaceFormat = "namespace"
@@ -78,6 +80,54 @@ type Event struct {
 	ResponseElements  map[string]string `json:"responseElements"`
 	S3                Metadata          `json:"s3"`
 	Source            Source            `json:"source"`
+
+	// Set on compact events, which are sent without request
+	// parameters and response elements.
+	compact bool
+}
+
+// event - Event without its JSON methods.
+type event Event
+
+// compactEvent - JSON representation of compact events.
+type compactEvent struct {
+	event
+	RequestParameters map[string]string `json:"requestParameters,omitempty"`
+	ResponseElements  map[string]string `json:"responseElements,omitempty"`
+}
+
+// Compact - returns the event without its request parameters and
+// response elements, to reduce the size of notifications.
+func (e Event) Compact() Event {
+	e.RequestParameters = nil
+	e.ResponseElements = nil
+	e.compact = true
+	return e
+}
+
+// MarshalJSON - encodes compact events without request parameters
+// and response elements.
+func (e Event) MarshalJSON() ([]byte, error) {
+	if e.compact {
+		return json.Marshal(compactEvent{event: event(e)})
+	}
+	return json.Marshal(event(e))
+}
+
+// UnmarshalJSON - decodes the event, events without request parameters
+// stay compact, e.g. when they are read back from a queue store.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*event)(e)); err != nil {
+		return err
+	}
+	var fields struct {
+		RequestParameters json.RawMessage `json:"requestParameters"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	e.compact = fields.RequestParameters == nil
+	return nil
 }
 
 // Log represents event information for some event targets.
@@ -35,8 +35,19 @@ const (
 	ObjectCreatedCopy
 	ObjectCreatedPost
 	ObjectCreatedPut
+	ObjectCreatedPutTagging
+	ObjectCreatedDeleteTagging
 	ObjectRemovedAll
 	ObjectRemovedDelete
+	ObjectRemovedDeleteMarkerCreated
+	ObjectRemovedServerDelete
+	ObjectHealed
+	MultipartUploadAll
+	MultipartUploadInitiate
+	MultipartUploadAbort
+	ReplicationAll
+	ReplicationOperationCompleted
+	ReplicationOperationFailed
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -45,9 +56,14 @@ func (name Name) Expand() []Name {
 	case ObjectAccessedAll:
 		return []Name{ObjectAccessedGet, ObjectAccessedHead}
 	case ObjectCreatedAll:
-		return []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}
+		return []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut,
+			ObjectCreatedPutTagging, ObjectCreatedDeleteTagging}
 	case ObjectRemovedAll:
-		return []Name{ObjectRemovedDelete}
+		return []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated, ObjectRemovedServerDelete}
+	case MultipartUploadAll:
+		return []Name{MultipartUploadInitiate, MultipartUploadAbort}
+	case ReplicationAll:
+		return []Name{ReplicationOperationCompleted, ReplicationOperationFailed}
 	default:
 		return []Name{name}
 	}
@@ -72,10 +88,32 @@ func (name Name) String() string {
 		return "s3:ObjectCreated:Post"
 	case ObjectCreatedPut:
 		return "s3:ObjectCreated:Put"
+	case ObjectCreatedPutTagging:
+		return "s3:ObjectCreated:PutTagging"
+	case ObjectCreatedDeleteTagging:
+		return "s3:ObjectCreated:DeleteTagging"
 	case ObjectRemovedAll:
 		return "s3:ObjectRemoved:*"
 	case ObjectRemovedDelete:
 		return "s3:ObjectRemoved:Delete"
+	case ObjectRemovedDeleteMarkerCreated:
+		return "s3:ObjectRemoved:DeleteMarkerCreated"
+	case ObjectRemovedServerDelete:
+		return "s3:ObjectRemoved:ServerDelete"
+	case ObjectHealed:
+		return "s3:ObjectHealed"
+	case MultipartUploadAll:
+		return "s3:MultipartUpload:*"
+	case MultipartUploadInitiate:
+		return "s3:MultipartUpload:Initiate"
+	case MultipartUploadAbort:
+		return "s3:MultipartUpload:Abort"
+	case ReplicationAll:
+		return "s3:Replication:*"
+	case ReplicationOperationCompleted:
+		return "s3:Replication:OperationCompletedReplication"
+	case ReplicationOperationFailed:
+		return "s3:Replication:OperationFailedReplication"
 	}
 
 	return ""
@@ -142,10 +180,32 @@ func ParseName(s string) (Name, error) {
 		return ObjectCreatedPost, nil
 	case "s3:ObjectCreated:Put":
 		return ObjectCreatedPut, nil
+	case "s3:ObjectCreated:PutTagging":
+		return ObjectCreatedPutTagging, nil
+	case "s3:ObjectCreated:DeleteTagging":
+		return ObjectCreatedDeleteTagging, nil
 	case "s3:ObjectRemoved:*":
 		return ObjectRemovedAll, nil
 	case "s3:ObjectRemoved:Delete":
 		return ObjectRemovedDelete, nil
+	case "s3:ObjectRemoved:DeleteMarkerCreated":
+		return ObjectRemovedDeleteMarkerCreated, nil
+	case "s3:ObjectRemoved:ServerDelete":
+		return ObjectRemovedServerDelete, nil
+	case "s3:ObjectHealed":
+		return ObjectHealed, nil
+	case "s3:MultipartUpload:*":
+		return MultipartUploadAll, nil
+	case "s3:MultipartUpload:Initiate":
+		return MultipartUploadInitiate, nil
+	case "s3:MultipartUpload:Abort":
+		return MultipartUploadAbort, nil
+	case "s3:Replication:*":
+		return ReplicationAll, nil
+	case "s3:Replication:OperationCompletedReplication":
+		return ReplicationOperationCompleted, nil
+	case "s3:Replication:OperationFailedReplication":
+		return ReplicationOperationFailed, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
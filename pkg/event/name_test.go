@@ -29,8 +29,12 @@ func TestNameExpand(t *testing.T) {
 		expectedResult []Name
 	}{
 		{ObjectAccessedAll, []Name{ObjectAccessedGet, ObjectAccessedHead}},
-		{ObjectCreatedAll, []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}},
-		{ObjectRemovedAll, []Name{ObjectRemovedDelete}},
+		{ObjectCreatedAll, []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut,
+			ObjectCreatedPutTagging, ObjectCreatedDeleteTagging}},
+		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated, ObjectRemovedServerDelete}},
+		{MultipartUploadAll, []Name{MultipartUploadInitiate, MultipartUploadAbort}},
+		{ReplicationAll, []Name{ReplicationOperationCompleted, ReplicationOperationFailed}},
+		{ObjectHealed, []Name{ObjectHealed}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
 	}
 
@@ -58,8 +62,19 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedCopy, "s3:ObjectCreated:Copy"},
 		{ObjectCreatedPost, "s3:ObjectCreated:Post"},
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
+		{ObjectCreatedPutTagging, "s3:ObjectCreated:PutTagging"},
+		{ObjectCreatedDeleteTagging, "s3:ObjectCreated:DeleteTagging"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{ObjectRemovedDeleteMarkerCreated, "s3:ObjectRemoved:DeleteMarkerCreated"},
+		{ObjectRemovedServerDelete, "s3:ObjectRemoved:ServerDelete"},
+		{ObjectHealed, "s3:ObjectHealed"},
+		{MultipartUploadAll, "s3:MultipartUpload:*"},
+		{MultipartUploadInitiate, "s3:MultipartUpload:Initiate"},
+		{MultipartUploadAbort, "s3:MultipartUpload:Abort"},
+		{ReplicationAll, "s3:Replication:*"},
+		{ReplicationOperationCompleted, "s3:Replication:OperationCompletedReplication"},
+		{ReplicationOperationFailed, "s3:Replication:OperationFailedReplication"},
 		{blankName, ""},
 	}
 
@@ -200,6 +215,11 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"s3:ObjectRemoved:DeleteMarkerCreated", ObjectRemovedDeleteMarkerCreated, false},
+		{"s3:ObjectCreated:PutTagging", ObjectCreatedPutTagging, false},
+		{"s3:MultipartUpload:*", MultipartUploadAll, false},
+		{"s3:Replication:OperationFailedReplication", ReplicationOperationFailed, false},
+		{"s3:ObjectHealed", ObjectHealed, false},
 		{"", blankName, true},
 	}
 
@@ -17,6 +17,7 @@
 package event
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/minio/minio/pkg/wildcard"
@@ -45,20 +46,81 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
+// ObjectFilter - filter of events by the size, content type and user
+// metadata of the object. User metadata names and value patterns are
+// kept URL encoded so that the filter is comparable.
+type ObjectFilter struct {
+	MinSize     int64
+	MaxSize     int64
+	ContentType string
+	Metadata    string
+}
+
+// Match - checks whether the object of an event matches the filter.
+func (filter ObjectFilter) Match(object Object) bool {
+	if filter.MinSize > 0 && object.Size < filter.MinSize {
+		return false
+	}
+
+	if filter.MaxSize > 0 && object.Size > filter.MaxSize {
+		return false
+	}
+
+	if filter.ContentType != "" && !wildcard.MatchSimple(filter.ContentType, object.ContentType) {
+		return false
+	}
+
+	if filter.Metadata == "" {
+		return true
+	}
+
+	metadata, err := url.ParseQuery(filter.Metadata)
+	if err != nil {
+		return false
+	}
+
+	for name := range metadata {
+		matched := false
+		for key, value := range object.UserMetadata {
+			if strings.EqualFold(key, name) {
+				matched = wildcard.MatchSimple(metadata.Get(name), value)
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Rule - object name pattern and object filter of events.
+type Rule struct {
+	Pattern string
+	Filter  ObjectFilter
+}
+
 // Rules - event rules
-type Rules map[string]TargetIDSet
+type Rules map[Rule]TargetIDSet
 
 // Add - adds pattern and target ID.
 func (rules Rules) Add(pattern string, targetID TargetID) {
-	rules[pattern] = NewTargetIDSet(targetID).Union(rules[pattern])
+	rules.AddRule(Rule{Pattern: pattern}, targetID)
+}
+
+// AddRule - adds rule and target ID.
+func (rules Rules) AddRule(rule Rule, targetID TargetID) {
+	rules[rule] = NewTargetIDSet(targetID).Union(rules[rule])
 }
 
-// Match - returns TargetIDSet matching object name in rules.
-func (rules Rules) Match(objectName string) TargetIDSet {
+// Match - returns TargetIDSet matching object name and object in rules.
+func (rules Rules) Match(objectName string, object Object) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for rule, targetIDSet := range rules {
+		if wildcard.MatchSimple(rule.Pattern, objectName) && rule.Filter.Match(object) {
 			targetIDs = targetIDs.Union(targetIDSet)
 		}
 	}
@@ -70,8 +132,8 @@ func (rules Rules) Match(objectName string) TargetIDSet {
 func (rules Rules) Clone() Rules {
 	rulesCopy := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		rulesCopy[pattern] = targetIDSet.Clone()
+	for rule, targetIDSet := range rules {
+		rulesCopy[rule] = targetIDSet.Clone()
 	}
 
 	return rulesCopy
@@ -81,8 +143,8 @@ func (rules Rules) Clone() Rules {
 func (rules Rules) Union(rules2 Rules) Rules {
 	nrules := rules.Clone()
 
-	for pattern, targetIDSet := range rules2 {
-		nrules[pattern] = nrules[pattern].Union(targetIDSet)
+	for rule, targetIDSet := range rules2 {
+		nrules[rule] = nrules[rule].Union(targetIDSet)
 	}
 
 	return nrules
@@ -92,9 +154,9 @@ func (rules Rules) Union(rules2 Rules) Rules {
 func (rules Rules) Difference(rules2 Rules) Rules {
 	nrules := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		if nv := targetIDSet.Difference(rules2[pattern]); len(nv) > 0 {
-			nrules[pattern] = nv
+	for rule, targetIDSet := range rules {
+		if nv := targetIDSet.Difference(rules2[rule]); len(nv) > 0 {
+			nrules[rule] = nv
 		}
 	}
 
@@ -124,7 +124,68 @@ func TestRulesMatch(t *testing.T) {
 	}
 
 	for i, testCase := range testCases {
-		result := testCase.rules.Match(testCase.objectName)
+		result := testCase.rules.Match(testCase.objectName, Object{})
+
+		if !reflect.DeepEqual(testCase.expectedResult, result) {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestObjectFilterMatch(t *testing.T) {
+	object := Object{
+		Size:         2048,
+		ContentType:  "image/jpeg",
+		UserMetadata: map[string]string{"content-type": "image/jpeg", "X-Amz-Meta-Project": "alpha"},
+	}
+
+	testCases := []struct {
+		filter         ObjectFilter
+		object         Object
+		expectedResult bool
+	}{
+		{ObjectFilter{}, object, true},
+		{ObjectFilter{}, Object{}, true},
+		{ObjectFilter{MinSize: 1024}, object, true},
+		{ObjectFilter{MinSize: 4096}, object, false},
+		{ObjectFilter{MaxSize: 2048}, object, true},
+		{ObjectFilter{MaxSize: 1024}, object, false},
+		{ObjectFilter{MinSize: 1024}, Object{}, false},
+		{ObjectFilter{ContentType: "image/*"}, object, true},
+		{ObjectFilter{ContentType: "text/*"}, object, false},
+		{ObjectFilter{Metadata: "x-amz-meta-project=alpha"}, object, true},
+		{ObjectFilter{Metadata: "x-amz-meta-project=al%2A"}, object, true},
+		{ObjectFilter{Metadata: "x-amz-meta-project=beta"}, object, false},
+		{ObjectFilter{Metadata: "x-amz-meta-env=prod"}, object, false},
+		{ObjectFilter{MinSize: 1024, ContentType: "image/*", Metadata: "x-amz-meta-project=alpha"}, object, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.filter.Match(testCase.object)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestRulesMatchObject(t *testing.T) {
+	rules := make(Rules)
+	rules.Add(NewPattern("images/", ""), TargetID{"1", "webhook"})
+	rules.AddRule(Rule{NewPattern("images/", ""), ObjectFilter{MinSize: 1024}}, TargetID{"2", "amqp"})
+
+	testCases := []struct {
+		objectName     string
+		object         Object
+		expectedResult TargetIDSet
+	}{
+		{"images/photos.jpg", Object{Size: 512}, NewTargetIDSet(TargetID{"1", "webhook"})},
+		{"images/photos.jpg", Object{Size: 2048}, NewTargetIDSet(TargetID{"1", "webhook"}, TargetID{"2", "amqp"})},
+		{"videos/movie.mp4", Object{Size: 2048}, NewTargetIDSet()},
+	}
+
+	for i, testCase := range testCases {
+		result := rules.Match(testCase.objectName, testCase.object)
 
 		if !reflect.DeepEqual(testCase.expectedResult, result) {
 			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
@@ -66,7 +66,7 @@ func TestRulesMapAdd(t *testing.T) {
 	rulesMapCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "*", TargetID{"1", "webhook"})
 	rulesMapToAddCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
 	expectedResultCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
-	expectedResultCase3.add([]Name{ObjectCreatedAll}, "*", TargetID{"1", "webhook"})
+	expectedResultCase3.add([]Name{ObjectCreatedAll}, Rule{Pattern: "*"}, TargetID{"1", "webhook"})
 
 	testCases := []struct {
 		rulesMap       RulesMap
@@ -97,7 +97,7 @@ func TestRulesMapRemove(t *testing.T) {
 	expectedResultCase2 := make(RulesMap)
 
 	rulesMapCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
-	rulesMapCase3.add([]Name{ObjectCreatedAll}, "*", TargetID{"1", "webhook"})
+	rulesMapCase3.add([]Name{ObjectCreatedAll}, Rule{Pattern: "*"}, TargetID{"1", "webhook"})
 	rulesMapToAddCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
 	expectedResultCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "*", TargetID{"1", "webhook"})
 
@@ -128,7 +128,7 @@ func TestRulesMapMatch(t *testing.T) {
 	rulesMapCase3 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
 
 	rulesMapCase4 := NewRulesMap([]Name{ObjectCreatedAll}, "2010*.jpg", TargetID{"1", "webhook"})
-	rulesMapCase4.add([]Name{ObjectCreatedAll}, "*", TargetID{"2", "amqp"})
+	rulesMapCase4.add([]Name{ObjectCreatedAll}, Rule{Pattern: "*"}, TargetID{"2", "amqp"})
 
 	testCases := []struct {
 		rulesMap       RulesMap
@@ -143,7 +143,7 @@ func TestRulesMapMatch(t *testing.T) {
 	}
 
 	for i, testCase := range testCases {
-		result := testCase.rulesMap.Match(testCase.eventName, testCase.objectName)
+		result := testCase.rulesMap.Match(testCase.eventName, testCase.objectName, Object{})
 
 		if !reflect.DeepEqual(result, testCase.expectedResult) {
 			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
@@ -153,13 +153,13 @@ func TestRulesMapMatch(t *testing.T) {
 
 func TestNewRulesMap(t *testing.T) {
 	rulesMapCase1 := make(RulesMap)
-	rulesMapCase1.add([]Name{ObjectAccessedGet, ObjectAccessedHead}, "*", TargetID{"1", "webhook"})
+	rulesMapCase1.add([]Name{ObjectAccessedGet, ObjectAccessedHead}, Rule{Pattern: "*"}, TargetID{"1", "webhook"})
 
 	rulesMapCase2 := make(RulesMap)
-	rulesMapCase2.add([]Name{ObjectAccessedGet, ObjectAccessedHead, ObjectCreatedPut}, "*", TargetID{"1", "webhook"})
+	rulesMapCase2.add([]Name{ObjectAccessedGet, ObjectAccessedHead, ObjectCreatedPut}, Rule{Pattern: "*"}, TargetID{"1", "webhook"})
 
 	rulesMapCase3 := make(RulesMap)
-	rulesMapCase3.add([]Name{ObjectRemovedDelete}, "2010*.jpg", TargetID{"1", "webhook"})
+	rulesMapCase3.add([]Name{ObjectRemovedDelete}, Rule{Pattern: "2010*.jpg"}, TargetID{"1", "webhook"})
 
 	testCases := []struct {
 		eventNames     []Name
@@ -48,6 +48,7 @@ type AMQPArgs struct {
 	AutoDeleted  bool     `json:"autoDeleted"`
 	QueueDir     string   `json:"queueDir"`
 	QueueLimit   uint64   `json:"queueLimit"`
+	Compact      bool     `json:"compact"`
 }
 
 // Validate AMQP arguments
@@ -118,7 +119,7 @@ func (target *AMQPTarget) channel() (*amqp.Channel, error) {
 	conn, err = amqp.Dial(target.args.URL.String())
 	if err != nil {
 		if IsConnRefusedErr(err) {
-			return nil, errNotConnected
+			return nil, ErrNotConnected
 		}
 		return nil, err
 	}
@@ -166,7 +167,8 @@ func (target *AMQPTarget) send(eventData event.Event, ch *amqp.Channel) error {
 // Save - saves the events to the store which will be replayed when the amqp connection is active.
 func (target *AMQPTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	ch, err := target.channel()
 	if err != nil {
@@ -191,9 +193,10 @@ func (target *AMQPTarget) Send(eventKey string) error {
 		logger.LogOnceIf(context.Background(), cErr, target.ID())
 	}()
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -223,7 +226,7 @@ func NewAMQPTarget(id string, args AMQPArgs, doneCh <-chan struct{}) (*AMQPTarge
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-amqp-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -245,9 +248,9 @@ func NewAMQPTarget(id string, args AMQPArgs, doneCh <-chan struct{}) (*AMQPTarge
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
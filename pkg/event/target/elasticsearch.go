@@ -40,6 +40,7 @@ type ElasticsearchArgs struct {
 	Index      string   `json:"index"`
 	QueueDir   string   `json:"queueDir"`
 	QueueLimit uint64   `json:"queueLimit"`
+	Compact    bool     `json:"compact"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -81,10 +82,11 @@ func (target *ElasticsearchTarget) ID() event.TargetID {
 // Save - saves the events to the store if queuestore is configured, which will be replayed when the elasticsearch connection is active.
 func (target *ElasticsearchTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if _, err := net.Dial("tcp", target.args.URL.Host); err != nil {
-		return errNotConnected
+		return ErrNotConnected
 	}
 	return target.send(eventData)
 }
@@ -152,12 +154,13 @@ func (target *ElasticsearchTarget) Send(eventKey string) error {
 	}
 
 	if _, err := net.Dial("tcp", target.args.URL.Host); err != nil {
-		return errNotConnected
+		return ErrNotConnected
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -221,7 +224,7 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-elasticsearch-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -248,9 +251,9 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
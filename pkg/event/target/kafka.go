@@ -18,17 +18,27 @@ package target
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/minio/minio/pkg/event"
 	xnet "github.com/minio/minio/pkg/net"
 
-	sarama "gopkg.in/Shopify/sarama.v1"
+	"github.com/Shopify/sarama"
+)
+
+// Placeholders of the Kafka topic, replaced by the values of each event.
+const (
+	kafkaTopicBucket    = "${bucket}"
+	kafkaTopicEventType = "${eventType}"
+	kafkaTopicEventName = "${eventName}"
 )
 
 // KafkaArgs - Kafka target arguments.
@@ -38,15 +48,20 @@ type KafkaArgs struct {
 	Topic      string      `json:"topic"`
 	QueueDir   string      `json:"queueDir"`
 	QueueLimit uint64      `json:"queueLimit"`
+	Compact    bool        `json:"compact"`
 	TLS        struct {
-		Enable     bool               `json:"enable"`
-		SkipVerify bool               `json:"skipVerify"`
-		ClientAuth tls.ClientAuthType `json:"clientAuth"`
+		Enable        bool               `json:"enable"`
+		RootCAs       *x509.CertPool     `json:"-"`
+		SkipVerify    bool               `json:"skipVerify"`
+		ClientAuth    tls.ClientAuthType `json:"clientAuth"`
+		ClientTLSCert string             `json:"clientTLSCert"`
+		ClientTLSKey  string             `json:"clientTLSKey"`
 	} `json:"tls"`
 	SASL struct {
-		Enable   bool   `json:"enable"`
-		User     string `json:"username"`
-		Password string `json:"password"`
+		Enable    bool   `json:"enable"`
+		User      string `json:"username"`
+		Password  string `json:"password"`
+		Mechanism string `json:"mechanism"`
 	} `json:"sasl"`
 }
 
@@ -63,6 +78,17 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
+	if err := validateKafkaTopic(k.Topic); err != nil {
+		return err
+	}
+	if (k.TLS.ClientTLSCert == "") != (k.TLS.ClientTLSKey == "") {
+		return errors.New("clientTLSCert and clientTLSKey should be specified together")
+	}
+	switch sarama.SASLMechanism(k.SASL.Mechanism) {
+	case "", sarama.SASLTypePlaintext, sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512:
+	default:
+		return fmt.Errorf("unsupported SASL mechanism %s", k.SASL.Mechanism)
+	}
 	if k.QueueDir != "" {
 		if !filepath.IsAbs(k.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -74,6 +100,44 @@ func (k KafkaArgs) Validate() error {
 	return nil
 }
 
+// validateKafkaTopic - checks that the topic is a valid Kafka topic name
+// for any value of its placeholders.
+func validateKafkaTopic(topic string) error {
+	if topic == "" {
+		return errors.New("empty topic")
+	}
+	name := strings.NewReplacer(kafkaTopicBucket, "bucket", kafkaTopicEventType, "type", kafkaTopicEventName, "name").Replace(topic)
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+		default:
+			return fmt.Errorf("invalid topic %s, only alphanumerics, '.', '_', '-' and the placeholders %s, %s and %s are allowed",
+				topic, kafkaTopicBucket, kafkaTopicEventType, kafkaTopicEventName)
+		}
+	}
+	return nil
+}
+
+// kafkaTopic - returns the topic of an event by replacing the placeholders
+// of the topic, e.g. "${bucket}" is replaced by the bucket name, and for
+// "s3:ObjectCreated:Put" events "${eventType}" by "ObjectCreated" and
+// "${eventName}" by "ObjectCreated.Put".
+func kafkaTopic(topic string, eventData event.Event) string {
+	if !strings.Contains(topic, "${") {
+		return topic
+	}
+	eventName := strings.TrimPrefix(eventData.EventName.String(), "s3:")
+	eventType := eventName
+	if i := strings.Index(eventName, ":"); i >= 0 {
+		eventType = eventName[:i]
+	}
+	return strings.NewReplacer(
+		kafkaTopicBucket, eventData.S3.Bucket.Name,
+		kafkaTopicEventType, eventType,
+		kafkaTopicEventName, strings.Replace(eventName, ":", ".", -1),
+	).Replace(topic)
+}
+
 // KafkaTarget - Kafka target.
 type KafkaTarget struct {
 	id       event.TargetID
@@ -91,10 +155,11 @@ func (target *KafkaTarget) ID() event.TargetID {
 // Save - saves the events to the store which will be replayed when the Kafka connection is active.
 func (target *KafkaTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if !target.args.pingBrokers() {
-		return errNotConnected
+		return ErrNotConnected
 	}
 	return target.send(eventData)
 }
@@ -113,7 +178,7 @@ func (target *KafkaTarget) send(eventData event.Event) error {
 	}
 
 	msg := sarama.ProducerMessage{
-		Topic: target.args.Topic,
+		Topic: kafkaTopic(target.args.Topic, eventData),
 		Key:   sarama.StringEncoder(key),
 		Value: sarama.ByteEncoder(data),
 	}
@@ -128,7 +193,7 @@ func (target *KafkaTarget) Send(eventKey string) error {
 	var err error
 
 	if !target.args.pingBrokers() {
-		return errNotConnected
+		return ErrNotConnected
 	}
 
 	if target.producer == nil {
@@ -141,13 +206,14 @@ func (target *KafkaTarget) Send(eventKey string) error {
 			if err != sarama.ErrOutOfBrokers {
 				return err
 			}
-			return errNotConnected
+			return ErrNotConnected
 		}
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -159,7 +225,7 @@ func (target *KafkaTarget) Send(eventKey string) error {
 	if err != nil {
 		// Sarama opens the ciruit breaker after 3 consecutive connection failures.
 		if err == sarama.ErrLeaderNotAvailable || err.Error() == "circuit breaker is open" {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -195,10 +261,26 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}) (*KafkaTa
 	config.Net.SASL.User = args.SASL.User
 	config.Net.SASL.Password = args.SASL.Password
 	config.Net.SASL.Enable = args.SASL.Enable
+	if args.SASL.Mechanism != "" {
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(args.SASL.Mechanism)
+	}
+	switch config.Net.SASL.Mechanism {
+	case sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512:
+		config.Net.SASL.SCRAMClientGeneratorFunc = newKafkaSCRAMClient(config.Net.SASL.Mechanism)
+	}
 
 	config.Net.TLS.Enable = args.TLS.Enable
 	tlsConfig := &tls.Config{
-		ClientAuth: args.TLS.ClientAuth,
+		ClientAuth:         args.TLS.ClientAuth,
+		InsecureSkipVerify: args.TLS.SkipVerify,
+		RootCAs:            args.TLS.RootCAs,
+	}
+	if args.TLS.ClientTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(args.TLS.ClientTLSCert, args.TLS.ClientTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 	config.Net.TLS.Config = tlsConfig
 
@@ -215,7 +297,7 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}) (*KafkaTa
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-kafka-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -238,9 +320,9 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}) (*KafkaTa
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
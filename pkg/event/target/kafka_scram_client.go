@@ -0,0 +1,69 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg/scram"
+)
+
+// Hash functions of the SCRAM-SHA-256 and SCRAM-SHA-512 mechanisms.
+var (
+	kafkaSHA256 scram.HashGeneratorFcn = sha256.New
+	kafkaSHA512 scram.HashGeneratorFcn = sha512.New
+)
+
+// kafkaSCRAMClient - SCRAM client of the SASL exchange with the Kafka brokers.
+type kafkaSCRAMClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	scram.HashGeneratorFcn
+}
+
+// newKafkaSCRAMClient - returns a generator of SCRAM clients for the mechanism.
+func newKafkaSCRAMClient(mechanism sarama.SASLMechanism) func() sarama.SCRAMClient {
+	hashGenerator := kafkaSHA256
+	if mechanism == sarama.SASLTypeSCRAMSHA512 {
+		hashGenerator = kafkaSHA512
+	}
+	return func() sarama.SCRAMClient {
+		return &kafkaSCRAMClient{HashGeneratorFcn: hashGenerator}
+	}
+}
+
+// Begin - prepares the client for the SCRAM exchange.
+func (c *kafkaSCRAMClient) Begin(userName, password, authzID string) (err error) {
+	c.Client, err = c.HashGeneratorFcn.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.ClientConversation = c.Client.NewConversation()
+	return nil
+}
+
+// Step - steps the client through the SCRAM exchange with the challenge of the broker.
+func (c *kafkaSCRAMClient) Step(challenge string) (string, error) {
+	return c.ClientConversation.Step(challenge)
+}
+
+// Done - returns true when the SCRAM exchange is complete.
+func (c *kafkaSCRAMClient) Done() bool {
+	return c.ClientConversation.Done()
+}
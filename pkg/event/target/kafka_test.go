@@ -0,0 +1,142 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg/scram"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+func TestKafkaArgs_Validate(t *testing.T) {
+	broker, err := xnet.ParseHost("localhost:9092")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newArgs := func(topic, mechanism, cert, key string) KafkaArgs {
+		args := KafkaArgs{Enable: true, Brokers: []xnet.Host{*broker}, Topic: topic}
+		args.SASL.Mechanism = mechanism
+		args.TLS.ClientTLSCert = cert
+		args.TLS.ClientTLSKey = key
+		return args
+	}
+
+	testCases := []struct {
+		args      KafkaArgs
+		expectErr bool
+	}{
+		{newArgs("minio", "", "", ""), false},
+		{newArgs("minio-${bucket}", "", "", ""), false},
+		{newArgs("minio.${eventType}.${eventName}", "", "", ""), false},
+		{newArgs("minio", "PLAIN", "", ""), false},
+		{newArgs("minio", "SCRAM-SHA-256", "", ""), false},
+		{newArgs("minio", "SCRAM-SHA-512", "/certs/client.crt", "/certs/client.key"), false},
+		{newArgs("", "", "", ""), true},
+		{newArgs("minio/events", "", "", ""), true},
+		{newArgs("minio-${object}", "", "", ""), true},
+		{newArgs("minio", "GSSAPI", "", ""), true},
+		{newArgs("minio", "", "/certs/client.crt", ""), true},
+		{newArgs("minio", "", "", "/certs/client.key"), true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Errorf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestKafkaTopic(t *testing.T) {
+	testCases := []struct {
+		topic         string
+		eventName     event.Name
+		expectedTopic string
+	}{
+		{"minio", event.ObjectCreatedPut, "minio"},
+		{"minio-${bucket}", event.ObjectCreatedPut, "minio-images"},
+		{"${bucket}.${eventType}", event.ObjectRemovedDelete, "images.ObjectRemoved"},
+		{"${bucket}.${eventName}", event.ObjectCreatedCompleteMultipartUpload, "images.ObjectCreated.CompleteMultipartUpload"},
+		{"minio-${eventType}", event.ObjectHealed, "minio-ObjectHealed"},
+	}
+
+	for i, testCase := range testCases {
+		eventData := event.Event{EventName: testCase.eventName}
+		eventData.S3.Bucket.Name = "images"
+		if topic := kafkaTopic(testCase.topic, eventData); topic != testCase.expectedTopic {
+			t.Errorf("test %v: expected: %v, got: %v", i+1, testCase.expectedTopic, topic)
+		}
+	}
+}
+
+func TestKafkaSCRAMClient(t *testing.T) {
+	testCases := []struct {
+		mechanism sarama.SASLMechanism
+		password  string
+		expectErr bool
+	}{
+		{sarama.SASLTypeSCRAMSHA256, "minio123", false},
+		{sarama.SASLTypeSCRAMSHA512, "minio123", false},
+		{sarama.SASLTypeSCRAMSHA256, "wrong", true},
+		{sarama.SASLTypeSCRAMSHA512, "wrong", true},
+	}
+
+	for i, testCase := range testCases {
+		hashGenerator := kafkaSHA256
+		if testCase.mechanism == sarama.SASLTypeSCRAMSHA512 {
+			hashGenerator = kafkaSHA512
+		}
+		client, err := hashGenerator.NewClient("minio", "minio123", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		credentials := client.GetStoredCredentials(scram.KeyFactors{Salt: "salt", Iters: 4096})
+		server, err := hashGenerator.NewServer(func(string) (scram.StoredCredentials, error) {
+			return credentials, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		serverConv := server.NewConversation()
+
+		// Run the exchange as the broker does with the challenges of the server.
+		scramClient := newKafkaSCRAMClient(testCase.mechanism)()
+		if err = scramClient.Begin("minio", testCase.password, ""); err != nil {
+			t.Fatal(err)
+		}
+		var challenge, response string
+		for !scramClient.Done() {
+			if response, err = scramClient.Step(challenge); err != nil || scramClient.Done() {
+				break
+			}
+			if challenge, err = serverConv.Step(response); err != nil {
+				break
+			}
+		}
+
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if !testCase.expectErr && !serverConv.Valid() {
+			t.Fatalf("test %v: expected a valid authentication", i+1)
+		}
+	}
+}
@@ -49,6 +49,7 @@ type MQTTArgs struct {
 	RootCAs              *x509.CertPool `json:"-"`
 	QueueDir             string         `json:"queueDir"`
 	QueueLimit           uint64         `json:"queueLimit"`
+	Compact              bool           `json:"compact"`
 }
 
 // Validate MQTTArgs fields
@@ -118,12 +119,13 @@ func (target *MQTTTarget) send(eventData event.Event) error {
 func (target *MQTTTarget) Send(eventKey string) error {
 
 	if !target.client.IsConnectionOpen() {
-		return errNotConnected
+		return ErrNotConnected
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -142,12 +144,13 @@ func (target *MQTTTarget) Send(eventKey string) error {
 // Save - saves the events to the store if queuestore is configured, which will be replayed when the mqtt connection is active.
 func (target *MQTTTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 
 	// Do not send if the connection is not active.
 	if !target.client.IsConnectionOpen() {
-		return errNotConnected
+		return ErrNotConnected
 	}
 
 	return target.send(eventData)
@@ -174,7 +177,7 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}) (*MQTTTarge
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-mqtt-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -217,9 +220,9 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}) (*MQTTTarge
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
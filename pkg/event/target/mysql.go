@@ -93,6 +93,7 @@ type MySQLArgs struct {
 	Database   string   `json:"database"`
 	QueueDir   string   `json:"queueDir"`
 	QueueLimit uint64   `json:"queueLimit"`
+	Compact    bool     `json:"compact"`
 }
 
 // Validate MySQLArgs fields
@@ -161,11 +162,12 @@ func (target *MySQLTarget) ID() event.TargetID {
 // Save - saves the events to the store which will be replayed when the SQL connection is active.
 func (target *MySQLTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if err := target.db.Ping(); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -219,7 +221,7 @@ func (target *MySQLTarget) Send(eventKey string) error {
 
 	if err := target.db.Ping(); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -227,15 +229,16 @@ func (target *MySQLTarget) Send(eventKey string) error {
 	if !target.firstPing {
 		if err := target.executeStmts(); err != nil {
 			if IsConnErr(err) {
-				return errNotConnected
+				return ErrNotConnected
 			}
 			return err
 		}
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -245,7 +248,7 @@ func (target *MySQLTarget) Send(eventKey string) error {
 
 	if err := target.send(eventData); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -335,7 +338,7 @@ func NewMySQLTarget(id string, args MySQLArgs, doneCh <-chan struct{}) (*MySQLTa
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-mysql-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -363,9 +366,9 @@ func NewMySQLTarget(id string, args MySQLArgs, doneCh <-chan struct{}) (*MySQLTa
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
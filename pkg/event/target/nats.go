@@ -41,6 +41,7 @@ type NATSArgs struct {
 	PingInterval int64     `json:"pingInterval"`
 	QueueDir     string    `json:"queueDir"`
 	QueueLimit   uint64    `json:"queueLimit"`
+	Compact      bool      `json:"compact"`
 	Streaming    struct {
 		Enable             bool   `json:"enable"`
 		ClusterID          string `json:"clusterID"`
@@ -130,15 +131,16 @@ func (target *NATSTarget) ID() event.TargetID {
 // Save - saves the events to the store which will be replayed when the Nats connection is active.
 func (target *NATSTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if target.args.Streaming.Enable {
 		if !target.stanConn.NatsConn().IsConnected() {
-			return errNotConnected
+			return ErrNotConnected
 		}
 	} else {
 		if !target.natsConn.IsConnected() {
-			return errNotConnected
+			return ErrNotConnected
 		}
 	}
 	return target.send(eventData)
@@ -178,7 +180,7 @@ func (target *NATSTarget) Send(eventKey string) error {
 			target.stanConn, connErr = target.args.connectStan()
 		} else {
 			if !target.stanConn.NatsConn().IsConnected() {
-				return errNotConnected
+				return ErrNotConnected
 			}
 		}
 	} else {
@@ -186,21 +188,22 @@ func (target *NATSTarget) Send(eventKey string) error {
 			target.natsConn, connErr = target.args.connectNats()
 		} else {
 			if !target.natsConn.IsConnected() {
-				return errNotConnected
+				return ErrNotConnected
 			}
 		}
 	}
 
 	if connErr != nil {
 		if connErr.Error() == nats.ErrNoServers.Error() {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return connErr
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -243,7 +246,7 @@ func NewNATSTarget(id string, args NATSArgs, doneCh <-chan struct{}) (*NATSTarge
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-nats-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -271,9 +274,9 @@ func NewNATSTarget(id string, args NATSArgs, doneCh <-chan struct{}) (*NATSTarge
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
@@ -41,6 +41,7 @@ type NSQArgs struct {
 	} `json:"tls"`
 	QueueDir   string `json:"queueDir"`
 	QueueLimit uint64 `json:"queueLimit"`
+	Compact    bool   `json:"compact"`
 }
 
 // Validate NSQArgs fields
@@ -84,12 +85,13 @@ func (target *NSQTarget) ID() event.TargetID {
 // Save - saves the events to the store which will be replayed when the nsq connection is active.
 func (target *NSQTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if err := target.producer.Ping(); err != nil {
-		// To treat "connection refused" errors as errNotConnected.
+		// To treat "connection refused" errors as ErrNotConnected.
 		if IsConnRefusedErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -116,16 +118,17 @@ func (target *NSQTarget) send(eventData event.Event) error {
 func (target *NSQTarget) Send(eventKey string) error {
 
 	if err := target.producer.Ping(); err != nil {
-		// To treat "connection refused" errors as errNotConnected.
+		// To treat "connection refused" errors as ErrNotConnected.
 		if IsConnRefusedErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -162,7 +165,7 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}) (*NSQTarget,
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-nsq-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -181,7 +184,7 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}) (*NSQTarget,
 	}
 
 	if err := target.producer.Ping(); err != nil {
-		// To treat "connection refused" errors as errNotConnected.
+		// To treat "connection refused" errors as ErrNotConnected.
 		if target.store == nil || !IsConnRefusedErr(err) {
 			return nil, err
 		}
@@ -189,9 +192,9 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}) (*NSQTarget,
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
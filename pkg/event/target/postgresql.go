@@ -94,6 +94,7 @@ type PostgreSQLArgs struct {
 	Database         string    `json:"database"` // default: same as user
 	QueueDir         string    `json:"queueDir"`
 	QueueLimit       uint64    `json:"queueLimit"`
+	Compact          bool      `json:"compact"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -159,11 +160,12 @@ func (target *PostgreSQLTarget) ID() event.TargetID {
 // Save - saves the events to the store if questore is configured, which will be replayed when the PostgreSQL connection is active.
 func (target *PostgreSQLTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	if err := target.db.Ping(); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -221,7 +223,7 @@ func (target *PostgreSQLTarget) Send(eventKey string) error {
 
 	if err := target.db.Ping(); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -229,15 +231,16 @@ func (target *PostgreSQLTarget) Send(eventKey string) error {
 	if !target.firstPing {
 		if err := target.executeStmts(); err != nil {
 			if IsConnErr(err) {
-				return errNotConnected
+				return ErrNotConnected
 			}
 			return err
 		}
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and wouldve been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -247,7 +250,7 @@ func (target *PostgreSQLTarget) Send(eventKey string) error {
 
 	if err := target.send(eventData); err != nil {
 		if IsConnErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -342,7 +345,7 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{})
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-postgresql-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -370,9 +373,9 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{})
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
@@ -0,0 +1,245 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// Timeout of publishing an event to Pub/Sub.
+const pubsubTimeout = 10 * time.Second
+
+// PubSubArgs - Google Cloud Pub/Sub target arguments.
+type PubSubArgs struct {
+	Enable          bool   `json:"enable"`
+	ProjectID       string `json:"projectId"`
+	Topic           string `json:"topic"`
+	CredentialsFile string `json:"credentialsFile"`
+	EmulatorHost    string `json:"emulatorHost"`
+	QueueDir        string `json:"queueDir"`
+	QueueLimit      uint64 `json:"queueLimit"`
+	Compact         bool   `json:"compact"`
+}
+
+// Validate PubSubArgs fields
+func (p PubSubArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+
+	if p.ProjectID == "" {
+		return errors.New("empty projectId")
+	}
+	if p.Topic == "" || strings.Contains(p.Topic, "/") {
+		return errors.New("topic should be the name of a topic of the project")
+	}
+	if p.CredentialsFile != "" && p.EmulatorHost != "" {
+		return errors.New("credentialsFile is not used with emulatorHost")
+	}
+	if p.EmulatorHost != "" {
+		if _, _, err := net.SplitHostPort(p.EmulatorHost); err != nil {
+			return fmt.Errorf("emulatorHost should be host:port, %v", err)
+		}
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if p.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// PubSubTarget - Google Cloud Pub/Sub target.
+type PubSubTarget struct {
+	id     event.TargetID
+	args   PubSubArgs
+	client *pubsub.Client
+	topic  *pubsub.Topic
+	store  Store
+}
+
+// ID - returns target ID.
+func (target *PubSubTarget) ID() event.TargetID {
+	return target.id
+}
+
+// isPubSubUnavailable - checks whether Pub/Sub could not be reached.
+func isPubSubUnavailable(err error) bool {
+	if err == context.DeadlineExceeded {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// Save - saves the events to the store which will be replayed when Pub/Sub is reachable.
+func (target *PubSubTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		_, err := target.store.Put(eventData)
+		return err
+	}
+	return target.send(eventData)
+}
+
+// send - publishes an event to the topic and waits until it is published.
+func (target *PubSubTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pubsubTimeout)
+	defer cancel()
+
+	result := target.topic.Publish(ctx, &pubsub.Message{
+		Data: data,
+		Attributes: map[string]string{
+			"eventName": eventData.EventName.String(),
+			"key":       key,
+		},
+	})
+	if _, err = result.Get(ctx); err != nil {
+		if isPubSubUnavailable(err) {
+			return ErrNotConnected
+		}
+		return err
+	}
+	return nil
+}
+
+// Send - reads an event from store and sends it to Pub/Sub.
+func (target *PubSubTarget) Send(eventKey string) error {
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		// Events are retried until Pub/Sub accepted them.
+		return ErrNotConnected
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - flushes the pending events and closes the connection to Pub/Sub.
+func (target *PubSubTarget) Close() error {
+	target.topic.Stop()
+	return target.client.Close()
+}
+
+// NewPubSubTarget - creates new Pub/Sub target. The Pub/Sub emulator is
+// used if emulatorHost is set, or through the PUBSUB_EMULATOR_HOST
+// environment variable.
+func NewPubSubTarget(id string, args PubSubArgs, doneCh <-chan struct{}) (*PubSubTarget, error) {
+	var opts []option.ClientOption
+	switch {
+	case args.EmulatorHost != "":
+		conn, err := grpc.Dial(args.EmulatorHost, grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, option.WithGRPCConn(conn))
+	case args.CredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(args.CredentialsFile))
+	}
+
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pubsub-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	client, err := pubsub.NewClient(context.Background(), args.ProjectID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	topic := client.Topic(args.Topic)
+	topic.PublishSettings.Timeout = pubsubTimeout
+
+	target := &PubSubTarget{
+		id:     event.TargetID{ID: id, Name: "pubsub"},
+		args:   args,
+		client: client,
+		topic:  topic,
+		store:  store,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pubsubTimeout)
+	exists, err := topic.Exists(ctx)
+	cancel()
+	switch {
+	case err != nil:
+		if target.store == nil || !isPubSubUnavailable(err) {
+			target.Close()
+			return nil, err
+		}
+	case !exists:
+		target.Close()
+		return nil, fmt.Errorf("topic %s does not exist in project %s", args.Topic, args.ProjectID)
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := ReplayItems(target.store, doneCh)
+		// Start replaying events from the store.
+		go SendItems(target.Send, eventKeyCh, doneCh)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	pb "google.golang.org/genproto/googleapis/pubsub/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestPubSubArgs_Validate(t *testing.T) {
+	testCases := []struct {
+		args      PubSubArgs
+		expectErr bool
+	}{
+		{PubSubArgs{Enable: false}, false},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "minio"}, false},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "minio", EmulatorHost: "localhost:8085"}, false},
+		{PubSubArgs{Enable: true, Topic: "minio"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "projects/project/topics/minio"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "minio", EmulatorHost: "localhost"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "minio", EmulatorHost: "localhost:8085", CredentialsFile: "/creds.json"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "minio", QueueDir: "queue"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Errorf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+// pubsubServer - publisher service of the Pub/Sub emulator, storing the
+// published messages of a single topic.
+type pubsubServer struct {
+	pb.PublisherServer
+
+	topic    string
+	mu       sync.Mutex
+	messages []*pb.PubsubMessage
+}
+
+func (s *pubsubServer) GetTopic(ctx context.Context, req *pb.GetTopicRequest) (*pb.Topic, error) {
+	if req.Topic != s.topic {
+		return nil, status.Error(codes.NotFound, "topic not found")
+	}
+	return &pb.Topic{Name: s.topic}, nil
+}
+
+func (s *pubsubServer) Publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error) {
+	if req.Topic != s.topic {
+		return nil, status.Error(codes.NotFound, "topic not found")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &pb.PublishResponse{}
+	for _, msg := range req.Messages {
+		s.messages = append(s.messages, msg)
+		resp.MessageIds = append(resp.MessageIds, strconv.Itoa(len(s.messages)))
+	}
+	return resp, nil
+}
+
+func TestPubSubTarget(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &pubsubServer{topic: "projects/project/topics/minio"}
+	grpcServer := grpc.NewServer()
+	pb.RegisterPublisherServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	args := PubSubArgs{
+		Enable:       true,
+		ProjectID:    "project",
+		Topic:        "unknown",
+		EmulatorHost: listener.Addr().String(),
+	}
+	if _, err = NewPubSubTarget("1", args, nil); err == nil {
+		t.Fatal("expected an error for a topic which does not exist")
+	}
+
+	args.Topic = "minio"
+	target, err := NewPubSubTarget("1", args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	eventData := event.Event{EventName: event.ObjectCreatedPut}
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "object%2Fname"
+	if err = target.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.messages) != 1 {
+		t.Fatalf("expected 1 published message, got %d", len(server.messages))
+	}
+	msg := server.messages[0]
+	if msg.Attributes["eventName"] != "s3:ObjectCreated:Put" || msg.Attributes["key"] != "bucket/object/name" {
+		t.Fatalf("unexpected message attributes %v", msg.Attributes)
+	}
+	var log event.Log
+	if err = json.Unmarshal(msg.Data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Key != "bucket/object/name" || len(log.Records) != 1 {
+		t.Fatalf("unexpected message data %s", msg.Data)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// Timeout of connecting to Pulsar and of publishing an event.
+const pulsarTimeout = 10 * time.Second
+
+// PulsarArgs - Pulsar target arguments.
+type PulsarArgs struct {
+	Enable   bool     `json:"enable"`
+	Endpoint xnet.URL `json:"endpoint"`
+	Topic    string   `json:"topic"`
+	Token    string   `json:"token"`
+	TLS      struct {
+		SkipVerify bool `json:"skipVerify"`
+	} `json:"tls"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+	Compact    bool   `json:"compact"`
+}
+
+// Validate PulsarArgs fields
+func (p PulsarArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+
+	if p.Endpoint.IsEmpty() {
+		return errors.New("empty endpoint")
+	}
+	if p.Endpoint.Scheme != "http" && p.Endpoint.Scheme != "https" {
+		return errors.New("endpoint scheme should be http or https")
+	}
+	if _, err := pulsarTopicPath(p.Topic); err != nil {
+		return err
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if p.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// pulsarTopicPath - returns the path of a topic in the URL of the
+// WebSocket producer. Short topic names are in the public tenant and
+// the default namespace, as with the Pulsar clients.
+func pulsarTopicPath(topic string) (string, error) {
+	domain := "persistent"
+	if i := strings.Index(topic, "://"); i >= 0 {
+		domain, topic = topic[:i], topic[i+len("://"):]
+	}
+	if domain != "persistent" && domain != "non-persistent" {
+		return "", errors.New("topic domain should be persistent or non-persistent")
+	}
+
+	parts := strings.Split(topic, "/")
+	if len(parts) == 1 {
+		parts = []string{"public", "default", parts[0]}
+	}
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", errors.New("topic should be of the form persistent://tenant/namespace/topic")
+	}
+	return domain + "/" + strings.Join(parts, "/"), nil
+}
+
+// pulsarMessage - message sent to the WebSocket producer.
+type pulsarMessage struct {
+	Payload    []byte            `json:"payload"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Key        string            `json:"key,omitempty"`
+	Context    string            `json:"context,omitempty"`
+}
+
+// pulsarResponse - acknowledgement of a message by the WebSocket producer.
+type pulsarResponse struct {
+	Result    string `json:"result"`
+	ErrorMsg  string `json:"errorMsg"`
+	MessageID string `json:"messageId"`
+	Context   string `json:"context"`
+}
+
+// PulsarTarget - Pulsar target, publishing events through the
+// WebSocket API of Pulsar.
+type PulsarTarget struct {
+	id    event.TargetID
+	args  PulsarArgs
+	url   string
+	store Store
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	seq  uint64
+}
+
+// ID - returns target ID.
+func (target *PulsarTarget) ID() event.TargetID {
+	return target.id
+}
+
+// connect - connects to the WebSocket producer of the topic, if not
+// connected already. Must be called with the lock held.
+func (target *PulsarTarget) connect() error {
+	if target.conn != nil {
+		return nil
+	}
+
+	config, err := websocket.NewConfig(target.url, target.args.Endpoint.String())
+	if err != nil {
+		return err
+	}
+	config.TlsConfig = &tls.Config{InsecureSkipVerify: target.args.TLS.SkipVerify}
+	config.Dialer = &net.Dialer{Timeout: pulsarTimeout}
+	if target.args.Token != "" {
+		config.Header.Set("Authorization", "Bearer "+target.args.Token)
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		if dErr, ok := err.(*websocket.DialError); ok && IsConnRefusedErr(dErr.Err) {
+			// To treat "connection refused" errors as ErrNotConnected.
+			return ErrNotConnected
+		}
+		return err
+	}
+	target.conn = conn
+	return nil
+}
+
+// disconnect - closes the connection after a failure, the next event
+// reconnects. Must be called with the lock held.
+func (target *PulsarTarget) disconnect() {
+	if target.conn != nil {
+		target.conn.Close()
+		target.conn = nil
+	}
+}
+
+// Save - saves the events to the store which will be replayed when the Pulsar connection is active.
+func (target *PulsarTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		_, err := target.store.Put(eventData)
+		return err
+	}
+	return target.send(eventData)
+}
+
+// send - sends an event to Pulsar and waits for its acknowledgement.
+func (target *PulsarTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	if err = target.connect(); err != nil {
+		return err
+	}
+
+	target.seq++
+	msg := pulsarMessage{
+		Payload:    data,
+		Properties: map[string]string{"eventName": eventData.EventName.String()},
+		Key:        key,
+		Context:    strconv.FormatUint(target.seq, 10),
+	}
+
+	var resp pulsarResponse
+	target.conn.SetDeadline(time.Now().Add(pulsarTimeout))
+	if err = websocket.JSON.Send(target.conn, msg); err == nil {
+		err = websocket.JSON.Receive(target.conn, &resp)
+	}
+	if err != nil {
+		target.disconnect()
+		return ErrNotConnected
+	}
+
+	if resp.Result != "ok" {
+		return fmt.Errorf("pulsar: %s %s", resp.Result, resp.ErrorMsg)
+	}
+	return nil
+}
+
+// Send - reads an event from store and sends it to Pulsar.
+func (target *PulsarTarget) Send(eventKey string) error {
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		// Events are retried until Pulsar accepted them.
+		return ErrNotConnected
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes the connection to Pulsar.
+func (target *PulsarTarget) Close() error {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	target.disconnect()
+	return nil
+}
+
+// NewPulsarTarget - creates new Pulsar target.
+func NewPulsarTarget(id string, args PulsarArgs, doneCh <-chan struct{}) (*PulsarTarget, error) {
+	topicPath, err := pulsarTopicPath(args.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	u := url.URL(args.Endpoint)
+	u.Scheme = "ws"
+	if args.Endpoint.Scheme == "https" {
+		u.Scheme = "wss"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/ws/v2/producer/" + topicPath
+
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pulsar-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	target := &PulsarTarget{
+		id:    event.TargetID{ID: id, Name: "pulsar"},
+		args:  args,
+		url:   u.String(),
+		store: store,
+	}
+
+	target.mu.Lock()
+	err = target.connect()
+	target.mu.Unlock()
+	if err != nil {
+		if target.store == nil || err != ErrNotConnected {
+			return nil, err
+		}
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := ReplayItems(target.store, doneCh)
+		// Start replaying events from the store.
+		go SendItems(target.Send, eventKeyCh, doneCh)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+func TestPulsarTopicPath(t *testing.T) {
+	testCases := []struct {
+		topic        string
+		expectedPath string
+		expectErr    bool
+	}{
+		{"minio", "persistent/public/default/minio", false},
+		{"persistent://tenant/ns/minio", "persistent/tenant/ns/minio", false},
+		{"non-persistent://tenant/ns/minio", "non-persistent/tenant/ns/minio", false},
+		{"tenant/ns/minio", "persistent/tenant/ns/minio", false},
+		{"", "", true},
+		{"ns/minio", "", true},
+		{"persistent://tenant//minio", "", true},
+		{"kafka://tenant/ns/minio", "", true},
+	}
+
+	for i, testCase := range testCases {
+		path, err := pulsarTopicPath(testCase.topic)
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if path != testCase.expectedPath {
+			t.Fatalf("test %v: path: expected: %v, got: %v", i+1, testCase.expectedPath, path)
+		}
+	}
+}
+
+func TestPulsarTarget(t *testing.T) {
+	var messages []pulsarMessage
+	var authorization string
+	mux := http.NewServeMux()
+	mux.Handle("/ws/v2/producer/persistent/public/default/minio", websocket.Handler(func(conn *websocket.Conn) {
+		authorization = conn.Request().Header.Get("Authorization")
+		for {
+			var msg pulsarMessage
+			if err := websocket.JSON.Receive(conn, &msg); err != nil {
+				return
+			}
+			messages = append(messages, msg)
+			websocket.JSON.Send(conn, pulsarResponse{Result: "ok", MessageID: "CAAQAw==", Context: msg.Context})
+		}
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	endpoint, err := xnet.ParseURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := PulsarArgs{Enable: true, Endpoint: *endpoint, Topic: "minio", Token: "token"}
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	target, err := NewPulsarTarget("1", args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	eventData := event.Event{EventName: event.ObjectRemovedDelete}
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "object"
+	for i := 0; i < 2; i++ {
+		if err = target.Save(eventData); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if authorization != "Bearer token" {
+		t.Fatalf("unexpected authorization %q", authorization)
+	}
+	if len(messages) != 2 || messages[0].Context == messages[1].Context {
+		t.Fatalf("expected 2 messages with distinct contexts, got %v", messages)
+	}
+	if messages[0].Key != "bucket/object" || messages[0].Properties["eventName"] != "s3:ObjectRemoved:Delete" {
+		t.Fatalf("unexpected message %v", messages[0])
+	}
+	var log event.Log
+	if err = json.Unmarshal(messages[0].Payload, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Key != "bucket/object" || len(log.Records) != 1 {
+		t.Fatalf("unexpected message payload %s", messages[0].Payload)
+	}
+}
@@ -22,9 +22,9 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 
-	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/sys"
 )
 
@@ -33,16 +33,19 @@ const (
 	eventExt = ".event"
 )
 
-// QueueStore - Filestore for persisting events.
+// QueueStore - Filestore for persisting items, each item is saved as
+// JSON in its own file with the extension of the store.
 type QueueStore struct {
 	sync.RWMutex
 	directory string
+	ext       string
 	eC        uint64
 	limit     uint64
 }
 
-// NewQueueStore - Creates an instance for QueueStore.
-func NewQueueStore(directory string, limit uint64) *QueueStore {
+// NewQueueStore - Creates an instance for QueueStore saving items in
+// files with the given extension.
+func NewQueueStore(directory string, limit uint64, ext string) *QueueStore {
 	if limit == 0 {
 		limit = maxLimit
 		currRlimit, _, err := sys.GetMaxOpenFileLimit()
@@ -55,6 +58,7 @@ func NewQueueStore(directory string, limit uint64) *QueueStore {
 
 	queueStore := &QueueStore{
 		directory: directory,
+		ext:       ext,
 		limit:     limit,
 	}
 	return queueStore
@@ -71,7 +75,7 @@ func (store *QueueStore) Open() error {
 
 	eCount := uint64(len(store.list()))
 	if eCount >= store.limit {
-		return errLimitExceeded
+		return ErrLimitExceeded
 	}
 
 	store.eC = eCount
@@ -79,66 +83,58 @@ func (store *QueueStore) Open() error {
 	return nil
 }
 
-// write - writes event to the directory.
-func (store *QueueStore) write(directory string, key string, e event.Event) error {
+// write - writes item to the directory.
+func (store *QueueStore) write(key string, item interface{}) error {
 
-	// Marshalls the event.
-	eventData, err := json.Marshal(e)
+	// Marshalls the item.
+	data, err := json.Marshal(item)
 	if err != nil {
 		return err
 	}
 
-	path := filepath.Join(store.directory, key+eventExt)
-	if err := ioutil.WriteFile(path, eventData, os.FileMode(0770)); err != nil {
+	if err := ioutil.WriteFile(store.path(key), data, os.FileMode(0770)); err != nil {
 		return err
 	}
 
-	// Increment the event count.
+	// Increment the item count.
 	store.eC++
 
 	return nil
 }
 
-// Put - puts a event to the store.
-func (store *QueueStore) Put(e event.Event) error {
+// Put - puts an item to the store and returns its key.
+func (store *QueueStore) Put(item interface{}) (string, error) {
 	store.Lock()
 	defer store.Unlock()
 	if store.eC >= store.limit {
-		return errLimitExceeded
+		return "", ErrLimitExceeded
 	}
 	key, kErr := getNewUUID()
 	if kErr != nil {
-		return kErr
+		return "", kErr
 	}
-	return store.write(store.directory, key, e)
+	return key, store.write(key, item)
 }
 
-// Get - gets a event from the store.
-func (store *QueueStore) Get(key string) (event.Event, error) {
-	store.RLock()
-	defer store.RUnlock()
-
-	var event event.Event
-
-	filepath := filepath.Join(store.directory, key+eventExt)
+// Get - reads the item with the given key from the store into item,
+// items which can not be read are removed from the store.
+func (store *QueueStore) Get(key string, item interface{}) error {
+	store.Lock()
+	defer store.Unlock()
 
-	eventData, rerr := ioutil.ReadFile(filepath)
+	data, rerr := ioutil.ReadFile(store.path(key))
 	if rerr != nil {
 		store.del(key)
-		return event, rerr
-	}
-
-	if len(eventData) == 0 {
-		store.del(key)
+		return rerr
 	}
 
-	uerr := json.Unmarshal(eventData, &event)
+	uerr := json.Unmarshal(data, item)
 	if uerr != nil {
 		store.del(key)
-		return event, uerr
+		return uerr
 	}
 
-	return event, nil
+	return nil
 }
 
 // Del - Deletes an entry from the store.
@@ -150,20 +146,18 @@ func (store *QueueStore) Del(key string) error {
 
 // lockless call
 func (store *QueueStore) del(key string) error {
-	p := filepath.Join(store.directory, key+eventExt)
-
-	rerr := os.Remove(p)
+	rerr := os.Remove(store.path(key))
 	if rerr != nil {
 		return rerr
 	}
 
-	// Decrement the event count.
+	// Decrement the item count.
 	store.eC--
 
 	return nil
 }
 
-// List - lists all files from the directory.
+// List - lists the keys of all items in the store, oldest item first.
 func (store *QueueStore) List() []string {
 	store.RLock()
 	defer store.RUnlock()
@@ -172,19 +166,29 @@ func (store *QueueStore) List() []string {
 
 // lockless call.
 func (store *QueueStore) list() []string {
-	var names []string
-	storeDir, _ := os.Open(store.directory)
+	var keys []string
+	storeDir, err := os.Open(store.directory)
+	if err != nil {
+		return nil
+	}
 	files, _ := storeDir.Readdir(-1)
 
 	// Sort the dentries.
 	sort.Slice(files, func(i, j int) bool {
-		return files[i].ModTime().Unix() < files[j].ModTime().Unix()
+		return files[i].ModTime().Before(files[j].ModTime())
 	})
 
 	for _, file := range files {
-		names = append(names, file.Name())
+		if strings.HasSuffix(file.Name(), store.ext) {
+			keys = append(keys, strings.TrimSuffix(file.Name(), store.ext))
+		}
 	}
 
 	_ = storeDir.Close()
-	return names
+	return keys
+}
+
+// path - returns the path of the file of the item with the given key.
+func (store *QueueStore) path(key string) string {
+	return filepath.Join(store.directory, key+store.ext)
 }
@@ -17,10 +17,10 @@
 package target
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
-	"strings"
 	"testing"
 
 	"github.com/minio/minio/pkg/event"
@@ -34,7 +34,7 @@ var testEvent = event.Event{EventVersion: "1.0", EventSource: "test_source", Aws
 
 // Initialize the store.
 func setUpStore(directory string, limit uint64) (Store, error) {
-	store := NewQueueStore(queueDir, limit)
+	store := NewQueueStore(queueDir, limit, eventExt)
 	if oErr := store.Open(); oErr != nil {
 		return nil, oErr
 	}
@@ -60,7 +60,7 @@ func TestQueueStorePut(t *testing.T) {
 	}
 	// Put 100 events.
 	for i := 0; i < 100; i++ {
-		if err := store.Put(testEvent); err != nil {
+		if _, err := store.Put(testEvent); err != nil {
 			t.Fatal("Failed to put to queue store ", err)
 		}
 	}
@@ -83,7 +83,7 @@ func TestQueueStoreGet(t *testing.T) {
 	}
 	// Put 10 events
 	for i := 0; i < 10; i++ {
-		if err := store.Put(testEvent); err != nil {
+		if _, err := store.Put(testEvent); err != nil {
 			t.Fatal("Failed to put to queue store ", err)
 		}
 	}
@@ -91,7 +91,8 @@ func TestQueueStoreGet(t *testing.T) {
 	// Get 10 events.
 	if len(eventKeys) == 10 {
 		for _, key := range eventKeys {
-			event, eErr := store.Get(strings.TrimSuffix(key, eventExt))
+			var event event.Event
+			eErr := store.Get(key, &event)
 			if eErr != nil {
 				t.Fatal("Failed to Get the event from the queue store ", eErr)
 			}
@@ -117,7 +118,7 @@ func TestQueueStoreDel(t *testing.T) {
 	}
 	// Put 20 events.
 	for i := 0; i < 20; i++ {
-		if err := store.Put(testEvent); err != nil {
+		if _, err := store.Put(testEvent); err != nil {
 			t.Fatal("Failed to put to queue store ", err)
 		}
 	}
@@ -125,7 +126,7 @@ func TestQueueStoreDel(t *testing.T) {
 	// Remove all the events.
 	if len(eventKeys) == 20 {
 		for _, key := range eventKeys {
-			err := store.Del(strings.TrimSuffix(key, eventExt))
+			err := store.Del(key)
 			if err != nil {
 				t.Fatal("queue store Del failed with ", err)
 			}
@@ -152,13 +153,13 @@ func TestQueueStoreLimit(t *testing.T) {
 		t.Fatal("Failed to create a queue store ", err)
 	}
 	for i := 0; i < 5; i++ {
-		if err := store.Put(testEvent); err != nil {
+		if _, err := store.Put(testEvent); err != nil {
 			t.Fatal("Failed to put to queue store ", err)
 		}
 	}
 	// Should not allow 6th Put.
-	if err := store.Put(testEvent); err == nil {
-		t.Fatalf("Expected to fail with %s, but passes", errLimitExceeded)
+	if _, err := store.Put(testEvent); err == nil {
+		t.Fatalf("Expected to fail with %s, but passes", ErrLimitExceeded)
 	}
 }
 
@@ -174,7 +175,7 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatal("Failed to create a queue store ", err)
 	}
 	for i := 0; i < 10; i++ {
-		if err := store.Put(testEvent); err != nil {
+		if _, err := store.Put(testEvent); err != nil {
 			t.Fatal("Failed to put to queue store ", err)
 		}
 	}
@@ -183,3 +184,52 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatalf("List() Expected: 10, got %d", len(store.List()))
 	}
 }
+
+// TestQueueStoreItems - tests storing other items than events, and the
+// removal of items which can not be read.
+func TestQueueStoreItems(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	type testItem struct {
+		Bucket string `json:"bucket"`
+		Object string `json:"object"`
+	}
+	store := NewQueueStore(queueDir, 10, ".item")
+	if err := store.Open(); err != nil {
+		t.Fatal("Failed to open queue store ", err)
+	}
+	key, err := store.Put(testItem{Bucket: "bucket", Object: "object"})
+	if err != nil {
+		t.Fatal("Failed to put to queue store ", err)
+	}
+
+	// Files of other stores in the directory are not listed.
+	if _, err = NewQueueStore(queueDir, 10, eventExt).Put(testEvent); err != nil {
+		t.Fatal("Failed to put to queue store ", err)
+	}
+	if keys := store.List(); len(keys) != 1 || keys[0] != key {
+		t.Fatalf("List() Expected: [%s], got %v", key, keys)
+	}
+
+	var item testItem
+	if err = store.Get(key, &item); err != nil {
+		t.Fatal("Failed to get from queue store ", err)
+	}
+	if item.Bucket != "bucket" || item.Object != "object" {
+		t.Fatalf("Get() Expected: bucket/object, got %s/%s", item.Bucket, item.Object)
+	}
+
+	// Items which can not be decoded are removed.
+	if err = ioutil.WriteFile(store.path("corrupt"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Get("corrupt", &item); err == nil {
+		t.Fatal("Get() Expected an error for a corrupt item")
+	}
+	if _, err = os.Stat(store.path("corrupt")); !os.IsNotExist(err) {
+		t.Fatal("Expected the corrupt item to be removed")
+	}
+}
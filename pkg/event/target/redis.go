@@ -42,6 +42,7 @@ type RedisArgs struct {
 	Key        string    `json:"key"`
 	QueueDir   string    `json:"queueDir"`
 	QueueLimit uint64    `json:"queueLimit"`
+	Compact    bool      `json:"compact"`
 }
 
 // Validate RedisArgs fields
@@ -110,7 +111,8 @@ func (target *RedisTarget) ID() event.TargetID {
 // Save - saves the events to the store if questore is configured, which will be replayed when the redis connection is active.
 func (target *RedisTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	conn := target.pool.Get()
 	defer func() {
@@ -120,7 +122,7 @@ func (target *RedisTarget) Save(eventData event.Event) error {
 	_, pingErr := conn.Do("PING")
 	if pingErr != nil {
 		if IsConnRefusedErr(pingErr) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return pingErr
 	}
@@ -180,7 +182,7 @@ func (target *RedisTarget) Send(eventKey string) error {
 	_, pingErr := conn.Do("PING")
 	if pingErr != nil {
 		if IsConnRefusedErr(pingErr) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return pingErr
 	}
@@ -188,16 +190,17 @@ func (target *RedisTarget) Send(eventKey string) error {
 	if !target.firstPing {
 		if err := target.args.validateFormat(conn); err != nil {
 			if IsConnRefusedErr(err) {
-				return errNotConnected
+				return ErrNotConnected
 			}
 			return err
 		}
 		target.firstPing = true
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and would've been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -207,7 +210,7 @@ func (target *RedisTarget) Send(eventKey string) error {
 
 	if err := target.send(eventData); err != nil {
 		if IsConnRefusedErr(err) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return err
 	}
@@ -255,7 +258,7 @@ func NewRedisTarget(id string, args RedisArgs, doneCh <-chan struct{}) (*RedisTa
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-redis-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil, oErr
 		}
@@ -288,9 +291,9 @@ func NewRedisTarget(id string, args RedisArgs, doneCh <-chan struct{}) (*RedisTa
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target, nil
@@ -21,33 +21,30 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"strings"
 	"syscall"
 	"time"
-
-	"github.com/minio/minio/pkg/event"
 )
 
 const retryInterval = 3 * time.Second
 
-// errNotConnected - indicates that the target connection is not active.
-var errNotConnected = errors.New("not connected to target server/service")
+// ErrNotConnected - indicates that the target connection is not active.
+var ErrNotConnected = errors.New("not connected to target server/service")
 
-// errLimitExceeded error is sent when the maximum limit is reached.
-var errLimitExceeded = errors.New("the maximum store limit reached")
+// ErrLimitExceeded error is sent when the maximum limit is reached.
+var ErrLimitExceeded = errors.New("the maximum store limit reached")
 
-// Store - To persist the events.
+// Store - To persist the events, or other items.
 type Store interface {
-	Put(event event.Event) error
-	Get(key string) (event.Event, error)
+	Put(item interface{}) (string, error)
+	Get(key string, item interface{}) error
 	List() []string
 	Del(key string) error
 	Open() error
 }
 
-// replayEvents - Reads the events from the store and replays.
-func replayEvents(store Store, doneCh <-chan struct{}) <-chan string {
-	var names []string
+// ReplayItems - Reads the keys of the items from the store and replays.
+func ReplayItems(store Store, doneCh <-chan struct{}) <-chan string {
+	var keys []string
 	eventKeyCh := make(chan string)
 
 	go func() {
@@ -55,17 +52,17 @@ func replayEvents(store Store, doneCh <-chan struct{}) <-chan string {
 		defer retryTimer.Stop()
 		defer close(eventKeyCh)
 		for {
-			names = store.List()
-			for _, name := range names {
+			keys = store.List()
+			for _, key := range keys {
 				select {
-				case eventKeyCh <- strings.TrimSuffix(name, eventExt):
+				case eventKeyCh <- key:
 					// Get next key.
 				case <-doneCh:
 					return
 				}
 			}
 
-			if len(names) < 2 {
+			if len(keys) < 2 {
 				retryTimer.Reset(retryInterval)
 				select {
 				case <-retryTimer.C:
@@ -105,19 +102,20 @@ func isConnResetErr(err error) bool {
 	return false
 }
 
-// sendEvents - Reads events from the store and re-plays.
-func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan struct{}) {
+// SendItems - Sends the items of the replayed keys with sendFn, and
+// retries them while the target is not connected.
+func SendItems(sendFn func(key string) error, eventKeyCh <-chan string, doneCh <-chan struct{}) {
 	retryTimer := time.NewTimer(retryInterval)
 	defer retryTimer.Stop()
 
 	send := func(eventKey string) bool {
 		for {
-			err := target.Send(eventKey)
+			err := sendFn(eventKey)
 			if err == nil {
 				break
 			}
 
-			if err != errNotConnected && !isConnResetErr(err) {
+			if err != ErrNotConnected && !isConnResetErr(err) {
 				panic(fmt.Errorf("target.Send() failed with '%v'", err))
 			}
 
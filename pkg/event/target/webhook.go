@@ -43,6 +43,7 @@ type WebhookArgs struct {
 	RootCAs    *x509.CertPool `json:"-"`
 	QueueDir   string         `json:"queueDir"`
 	QueueLimit uint64         `json:"queueLimit"`
+	Compact    bool           `json:"compact"`
 }
 
 // Validate WebhookArgs fields
@@ -80,7 +81,8 @@ func (target WebhookTarget) ID() event.TargetID {
 // Save - saves the events to the store if queuestore is configured, which will be replayed when the wenhook connection is active.
 func (target *WebhookTarget) Save(eventData event.Event) error {
 	if target.store != nil {
-		return target.store.Put(eventData)
+		_, err := target.store.Put(eventData)
+		return err
 	}
 	urlStr, pErr := xnet.ParseURL(target.args.Endpoint.String())
 	if pErr != nil {
@@ -88,9 +90,9 @@ func (target *WebhookTarget) Save(eventData event.Event) error {
 	}
 	_, dErr := net.Dial("tcp", urlStr.Host)
 	if dErr != nil {
-		// To treat "connection refused" errors as errNotConnected.
+		// To treat "connection refused" errors as ErrNotConnected.
 		if IsConnRefusedErr(dErr) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return dErr
 	}
@@ -142,16 +144,17 @@ func (target *WebhookTarget) Send(eventKey string) error {
 	}
 	_, dErr := net.Dial("tcp", urlStr.Host)
 	if dErr != nil {
-		// To treat "connection refused" errors as errNotConnected.
+		// To treat "connection refused" errors as ErrNotConnected.
 		if IsConnRefusedErr(dErr) {
-			return errNotConnected
+			return ErrNotConnected
 		}
 		return dErr
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	var eventData event.Event
+	eErr := target.store.Get(eventKey, &eventData)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// The last event key in a successful batch will be sent in the channel atmost once by the ReplayItems()
 		// Such events will not exist and would've been already been sent successfully.
 		if os.IsNotExist(eErr) {
 			return nil
@@ -179,7 +182,7 @@ func NewWebhookTarget(id string, args WebhookArgs, doneCh <-chan struct{}) *Webh
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-webhook-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = NewQueueStore(queueDir, args.QueueLimit, eventExt)
 		if oErr := store.Open(); oErr != nil {
 			return nil
 		}
@@ -205,9 +208,9 @@ func NewWebhookTarget(id string, args WebhookArgs, doneCh <-chan struct{}) *Webh
 
 	if target.store != nil {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh)
+		eventKeyCh := ReplayItems(target.store, doneCh)
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh)
+		go SendItems(target.Send, eventKeyCh, doneCh)
 	}
 
 	return target
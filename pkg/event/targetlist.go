@@ -29,6 +29,22 @@ type Target interface {
 	Close() error
 }
 
+// compactTarget - target sending compact events.
+type compactTarget struct {
+	Target
+}
+
+// Save - saves the compact event to the target.
+func (target compactTarget) Save(eventData Event) error {
+	return target.Target.Save(eventData.Compact())
+}
+
+// NewCompactTarget - returns target which sends events without request
+// parameters and response elements to given target.
+func NewCompactTarget(target Target) Target {
+	return compactTarget{target}
+}
+
 // TargetList - holds list of targets indexed by target ID.
 type TargetList struct {
 	sync.RWMutex
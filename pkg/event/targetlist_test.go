@@ -17,7 +17,9 @@
 package event
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -275,6 +277,71 @@ func TestTargetListSend(t *testing.T) {
 	}
 }
 
+type compactExampleTarget struct {
+	ExampleTarget
+	events []Event
+}
+
+func (target *compactExampleTarget) Save(eventData Event) error {
+	target.events = append(target.events, eventData)
+	return nil
+}
+
+func TestCompactTarget(t *testing.T) {
+	exampleTarget := &compactExampleTarget{ExampleTarget: ExampleTarget{id: TargetID{"1", "testcase"}}}
+	target := NewCompactTarget(exampleTarget)
+	if target.ID() != exampleTarget.ID() {
+		t.Fatalf("expected target ID %v, got %v", exampleTarget.ID(), target.ID())
+	}
+
+	eventData := Event{
+		EventName:         ObjectCreatedPut,
+		RequestParameters: map[string]string{"sourceIPAddress": "10.1.1.1"},
+		ResponseElements:  map[string]string{"x-amz-request-id": "1562A792DAA53426"},
+	}
+	eventData.S3.Object.Key = "photos.jpg"
+	if err := target.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEvent := Event{EventName: ObjectCreatedPut, compact: true}
+	expectedEvent.S3.Object.Key = "photos.jpg"
+	if !reflect.DeepEqual(exampleTarget.events, []Event{expectedEvent}) {
+		t.Fatalf("expected %v, got %v", []Event{expectedEvent}, exampleTarget.events)
+	}
+	if eventData.RequestParameters == nil || eventData.ResponseElements == nil {
+		t.Fatal("original event is modified")
+	}
+
+	// Only compact events are encoded without request parameters and
+	// response elements, and they stay compact when decoded again.
+	testCases := []struct {
+		eventData     Event
+		expectCompact bool
+	}{
+		{Event{EventName: ObjectCreatedPut}, false},
+		{eventData, false},
+		{exampleTarget.events[0], true},
+	}
+	for i, testCase := range testCases {
+		data, err := json.Marshal(testCase.eventData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compact := !bytes.Contains(data, []byte(`"requestParameters"`)) && !bytes.Contains(data, []byte(`"responseElements"`))
+		if compact != testCase.expectCompact {
+			t.Fatalf("test %v: expected compact %v, got %s", i+1, testCase.expectCompact, data)
+		}
+		var decoded Event
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, testCase.eventData) {
+			t.Fatalf("test %v: expected %v, got %v", i+1, testCase.eventData, decoded)
+		}
+	}
+}
+
 func TestNewTargetList(t *testing.T) {
 	if result := NewTargetList(); result == nil {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
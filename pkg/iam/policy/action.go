@@ -85,6 +85,51 @@ const (
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
 
+	// PutObjectRetentionAction - PutObjectRetention Rest API action.
+	PutObjectRetentionAction = "s3:PutObjectRetention"
+
+	// GetObjectRetentionAction - GetObjectRetention Rest API action.
+	GetObjectRetentionAction = "s3:GetObjectRetention"
+
+	// PutObjectLegalHoldAction - PutObjectLegalHold Rest API action.
+	PutObjectLegalHoldAction = "s3:PutObjectLegalHold"
+
+	// GetObjectLegalHoldAction - GetObjectLegalHold Rest API action.
+	GetObjectLegalHoldAction = "s3:GetObjectLegalHold"
+
+	// BypassGovernanceRetentionAction - bypass governance retention for PutObjectRetention, PutObject and DeleteObject Rest API action.
+	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
+
+	// GetObjectTaggingAction - GetObjectTagging Rest API action.
+	GetObjectTaggingAction = "s3:GetObjectTagging"
+
+	// PutObjectTaggingAction - PutObjectTagging Rest API action.
+	PutObjectTaggingAction = "s3:PutObjectTagging"
+
+	// DeleteObjectTaggingAction - DeleteObjectTagging Rest API action.
+	DeleteObjectTaggingAction = "s3:DeleteObjectTagging"
+
+	// ReplicateObjectAction - write an object as a replica of an object of a replicated bucket.
+	ReplicateObjectAction = "s3:ReplicateObject"
+
+	// ReplicateDeleteAction - delete an object as a replica of a delete in a replicated bucket.
+	ReplicateDeleteAction = "s3:ReplicateDelete"
+
+	// PutBucketVersioningAction - PutBucketVersioning Rest API action.
+	PutBucketVersioningAction Action = "s3:PutBucketVersioning"
+
+	// GetBucketVersioningAction - GetBucketVersioning Rest API action.
+	GetBucketVersioningAction Action = "s3:GetBucketVersioning"
+
+	// ListBucketVersionsAction - ListObjectVersions Rest API action.
+	ListBucketVersionsAction Action = "s3:ListBucketVersions"
+
+	// GetObjectVersionAction - GetObject and HeadObject Rest API action on a specific version.
+	GetObjectVersionAction Action = "s3:GetObjectVersion"
+
+	// DeleteObjectVersionAction - DeleteObject Rest API action on a specific version.
+	DeleteObjectVersionAction Action = "s3:DeleteObjectVersion"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
@@ -110,6 +155,21 @@ var supportedActions = map[Action]struct{}{
 	PutBucketNotificationAction:      {},
 	PutBucketPolicyAction:            {},
 	PutObjectAction:                  {},
+	PutObjectRetentionAction:         {},
+	GetObjectRetentionAction:         {},
+	PutObjectLegalHoldAction:         {},
+	GetObjectLegalHoldAction:         {},
+	BypassGovernanceRetentionAction:  {},
+	GetObjectTaggingAction:           {},
+	PutObjectTaggingAction:           {},
+	DeleteObjectTaggingAction:        {},
+	ReplicateObjectAction:            {},
+	ReplicateDeleteAction:            {},
+	PutBucketVersioningAction:        {},
+	GetBucketVersioningAction:        {},
+	ListBucketVersionsAction:         {},
+	GetObjectVersionAction:           {},
+	DeleteObjectVersionAction:        {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -118,6 +178,18 @@ func (action Action) isObjectAction() bool {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
 	case ListMultipartUploadPartsAction, PutObjectAction, AllActions:
+		fallthrough
+	case PutObjectRetentionAction, GetObjectRetentionAction:
+		fallthrough
+	case PutObjectLegalHoldAction, GetObjectLegalHoldAction:
+		fallthrough
+	case BypassGovernanceRetentionAction:
+		fallthrough
+	case GetObjectTaggingAction, PutObjectTaggingAction, DeleteObjectTaggingAction:
+		fallthrough
+	case ReplicateObjectAction, ReplicateDeleteAction:
+		fallthrough
+	case GetObjectVersionAction, DeleteObjectVersionAction:
 		return true
 	}
 
@@ -195,6 +267,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzStorageClass,
+			condition.S3ExistingObjectTag,
 		}, condition.CommonKeys...)...),
 
 	HeadBucketAction: condition.NewKeySet(condition.CommonKeys...),
@@ -223,7 +296,58 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzCopySource,
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
+			condition.S3XAmzServerSideEncryptionAwsKmsKeyID,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 		}, condition.CommonKeys...)...),
+
+	PutObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	BypassGovernanceRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	ReplicateObjectAction: condition.NewKeySet(condition.CommonKeys...),
+
+	ReplicateDeleteAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	ListBucketVersionsAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3Prefix,
+			condition.S3Delimiter,
+			condition.S3MaxKeys,
+		}, condition.CommonKeys...)...),
+
+	GetObjectVersionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3XAmzServerSideEncryption,
+			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
+			condition.S3XAmzStorageClass,
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	DeleteObjectVersionAction: condition.NewKeySet(condition.CommonKeys...),
 }
@@ -32,7 +32,11 @@ func TestActionIsObjectAction(t *testing.T) {
 		{GetObjectAction, true},
 		{ListMultipartUploadPartsAction, true},
 		{PutObjectAction, true},
+		{GetObjectVersionAction, true},
+		{DeleteObjectVersionAction, true},
 		{CreateBucketAction, false},
+		{PutBucketVersioningAction, false},
+		{ListBucketVersionsAction, false},
 	}
 
 	for i, testCase := range testCases {
@@ -50,6 +54,11 @@ func TestActionIsValid(t *testing.T) {
 		expectedResult bool
 	}{
 		{AbortMultipartUploadAction, true},
+		{PutBucketVersioningAction, true},
+		{GetBucketVersioningAction, true},
+		{ListBucketVersionsAction, true},
+		{GetObjectVersionAction, true},
+		{DeleteObjectVersionAction, true},
 		{Action("foo"), false},
 	}
 
@@ -0,0 +1,65 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+var (
+	errAbortIncompleteMultipartUploadInvalidDays = errors.New("DaysAfterInitiation must be positive integer when used with AbortIncompleteMultipartUpload")
+	errAbortIncompleteMultipartUploadWithTags    = errors.New("AbortIncompleteMultipartUpload cannot be specified with Tags")
+)
+
+// AbortIncompleteMultipartUpload - abort action for incomplete multipart
+// uploads for a rule in lifecycle configuration.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation"`
+}
+
+// IsEmpty - returns whether abort of incomplete uploads is specified or not.
+func (a AbortIncompleteMultipartUpload) IsEmpty() bool {
+	return a == AbortIncompleteMultipartUpload{}
+}
+
+// Validate - validates the "AbortIncompleteMultipartUpload" element
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if a.DaysAfterInitiation <= 0 {
+		return errAbortIncompleteMultipartUploadInvalidDays
+	}
+	return nil
+}
+
+// IsDue - returns whether a multipart upload initiated at the given
+// time is to be aborted at now.
+func (a AbortIncompleteMultipartUpload) IsDue(initiated, now time.Time) bool {
+	return !now.Before(initiated.Add(time.Duration(a.DaysAfterInitiation) * 24 * time.Hour))
+}
+
+// MarshalXML is extended to leave out
+// <AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload> tags
+// when no abort is specified
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsEmpty() {
+		return nil
+	}
+	type abortWrapper AbortIncompleteMultipartUpload
+	return e.EncodeElement(abortWrapper(a), start)
+}
@@ -16,11 +16,9 @@
 
 package lifecycle
 
-// Action - policy action.
+// Policy actions of the bucket lifecycle APIs.
 // Refer https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazons3.html
 // for more information about available actions.
-type Action string
-
 const (
 	// PutBucketLifecycleAction - PutBucketLifecycle Rest API action.
 	PutBucketLifecycleAction = "s3:PutBucketLifecycle"
@@ -28,15 +28,43 @@ type And struct {
 	Tags    []Tag    `xml:"Tag,omitempty"`
 }
 
-var errAndUnsupported = errors.New("Specifying <And></And> tag is not supported")
+var (
+	errAndTooFewPredicates = errors.New("And must combine a prefix and at least one tag, or at least two tags")
+	errDuplicateTagKey     = errors.New("Duplicate Tag Keys are not allowed")
+)
 
-// UnmarshalXML is extended to indicate lack of support for And xml
-// tag in object lifecycle configuration
-func (a And) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errAndUnsupported
+// IsEmpty - returns true if the And tag is not set.
+func (a And) IsEmpty() bool {
+	return a.Prefix == "" && len(a.Tags) == 0
 }
 
-// MarshalXML is extended to leave out <And></And> tags
-func (a And) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+// Validate - validates the And element
+func (a And) Validate() error {
+	predicates := len(a.Tags)
+	if a.Prefix != "" {
+		predicates++
+	}
+	if predicates < 2 {
+		return errAndTooFewPredicates
+	}
+	keys := make(map[string]struct{}, len(a.Tags))
+	for _, t := range a.Tags {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		if _, ok := keys[t.Key]; ok {
+			return errDuplicateTagKey
+		}
+		keys[t.Key] = struct{}{}
+	}
 	return nil
 }
+
+// MarshalXML is extended to leave out empty <And></And> tags
+func (a And) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsEmpty() {
+		return nil
+	}
+	type andWrapper And
+	return e.EncodeElement(andWrapper(a), start)
+}
@@ -104,6 +104,11 @@ type Expiration struct {
 	Date    ExpirationDate `xml:"Date,omitempty"`
 }
 
+// IsEmpty - returns whether expiration is specified or not.
+func (e Expiration) IsEmpty() bool {
+	return e == Expiration{}
+}
+
 // Validate - validates the "Expiration" element
 func (e Expiration) Validate() error {
 	// Neither expiration days or date is specified
@@ -117,3 +122,22 @@ func (e Expiration) Validate() error {
 	}
 	return nil
 }
+
+// IsDue - returns whether an object last modified at modTime has
+// expired at the given time.
+func (e Expiration) IsDue(modTime, now time.Time) bool {
+	if !e.Date.IsZero() {
+		return !now.Before(e.Date.Time)
+	}
+	return !now.Before(modTime.Add(time.Duration(e.Days) * 24 * time.Hour))
+}
+
+// MarshalXML is extended to leave out <Expiration></Expiration> tags
+// when no expiration is specified
+func (e Expiration) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if e.IsEmpty() {
+		return nil
+	}
+	type expirationWrapper Expiration
+	return enc.EncodeElement(expirationWrapper(e), start)
+}
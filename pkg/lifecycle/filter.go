@@ -16,7 +16,13 @@
 
 package lifecycle
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+var errInvalidFilter = errors.New("Filter must have exactly one of Prefix, Tag, or And specified")
 
 // Filter - a filter for a lifecycle configuration Rule.
 type Filter struct {
@@ -28,5 +34,51 @@ type Filter struct {
 
 // Validate - validates the filter element
 func (f Filter) Validate() error {
+	switch {
+	case !f.And.IsEmpty():
+		if f.Prefix != "" || !f.Tag.IsEmpty() {
+			return errInvalidFilter
+		}
+		return f.And.Validate()
+	case !f.Tag.IsEmpty():
+		if f.Prefix != "" {
+			return errInvalidFilter
+		}
+		return f.Tag.Validate()
+	}
 	return nil
 }
+
+// GetPrefix - returns the prefix the filter applies to.
+func (f Filter) GetPrefix() string {
+	if !f.And.IsEmpty() {
+		return f.And.Prefix
+	}
+	return f.Prefix
+}
+
+// HasTags - returns true if the filter matches objects by tags.
+func (f Filter) HasTags() bool {
+	return !f.Tag.IsEmpty() || len(f.And.Tags) > 0
+}
+
+// TestTags - returns true if the given object tags satisfy all
+// the tags of the filter.
+func (f Filter) TestTags(tags map[string]string) bool {
+	filterTags := f.And.Tags
+	if !f.Tag.IsEmpty() {
+		filterTags = []Tag{f.Tag}
+	}
+	for _, t := range filterTags {
+		if v, ok := tags[t.Key]; !ok || v != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Test - returns true if the object with the given name and
+// tags is selected by the filter.
+func (f Filter) Test(object string, tags map[string]string) bool {
+	return strings.HasPrefix(object, f.GetPrefix()) && f.TestTags(tags)
+}
@@ -22,35 +22,106 @@ import (
 	"testing"
 )
 
-// TestUnsupportedFilters checks if parsing Filter xml with
-// unsupported elements returns appropriate errors
-func TestUnsupportedFilters(t *testing.T) {
+// TestParseFilters checks if parsing and validating Filter xml
+// returns appropriate errors
+func TestParseFilters(t *testing.T) {
 	testCases := []struct {
 		inputXML    string
 		expectedErr error
 	}{
-		{ // Filter with And tags
+		{ // Filter with a prefix
+			inputXML: ` <Filter>
+	                     <Prefix>logs/</Prefix>
+	                    </Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with a tag
+			inputXML: ` <Filter>
+	                     <Tag><Key>key1</Key><Value>value1</Value></Tag>
+	                    </Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with a prefix and tags combined by And
+			inputXML: ` <Filter>
+	                     <And>
+	                     <Prefix>logs/</Prefix>
+	                     <Tag><Key>key1</Key><Value>value1</Value></Tag>
+	                     <Tag><Key>key2</Key><Value>value2</Value></Tag>
+	                     </And>
+	                    </Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And combining only a prefix
 			inputXML: ` <Filter>
 	                     <And>
-	                     <Prefix></Prefix>
+	                     <Prefix>logs/</Prefix>
 	                     </And>
 	                    </Filter>`,
-			expectedErr: errAndUnsupported,
+			expectedErr: errAndTooFewPredicates,
 		},
-		{ // Filter with Tag tags
+		{ // Filter with And combining duplicate tag keys
 			inputXML: ` <Filter>
-	                     <Tag></Tag>
+	                     <And>
+	                     <Tag><Key>key1</Key><Value>value1</Value></Tag>
+	                     <Tag><Key>key1</Key><Value>value2</Value></Tag>
+	                     </And>
 	                    </Filter>`,
-			expectedErr: errTagUnsupported,
+			expectedErr: errDuplicateTagKey,
+		},
+		{ // Filter with a tag without key
+			inputXML: ` <Filter>
+	                     <Tag><Value>value1</Value></Tag>
+	                    </Filter>`,
+			expectedErr: errInvalidTagKey,
+		},
+		{ // Filter with both a prefix and a tag
+			inputXML: ` <Filter>
+	                     <Prefix>logs/</Prefix>
+	                     <Tag><Key>key1</Key><Value>value1</Value></Tag>
+	                    </Filter>`,
+			expectedErr: errInvalidFilter,
 		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
 			var filter Filter
 			err := xml.Unmarshal([]byte(tc.inputXML), &filter)
+			if err != nil {
+				t.Fatalf("%d: Expected no error but got %v", i+1, err)
+			}
+			err = filter.Validate()
 			if err != tc.expectedErr {
 				t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedErr, err)
 			}
 		})
 	}
 }
+
+// TestFilterTest checks if objects are matched by prefix and tags
+func TestFilterTest(t *testing.T) {
+	tagFilter := Filter{Tag: Tag{Key: "key1", Value: "value1"}}
+	andFilter := Filter{And: And{
+		Prefix: "logs/",
+		Tags:   []Tag{{Key: "key1", Value: "value1"}, {Key: "key2", Value: "value2"}},
+	}}
+	testCases := []struct {
+		filter         Filter
+		object         string
+		tags           map[string]string
+		expectedResult bool
+	}{
+		{Filter{Prefix: "logs/"}, "logs/a", nil, true},
+		{Filter{Prefix: "logs/"}, "data/a", nil, false},
+		{tagFilter, "data/a", map[string]string{"key1": "value1"}, true},
+		{tagFilter, "data/a", map[string]string{"key1": "value2"}, false},
+		{tagFilter, "data/a", nil, false},
+		{andFilter, "logs/a", map[string]string{"key1": "value1", "key2": "value2", "key3": "value3"}, true},
+		{andFilter, "logs/a", map[string]string{"key1": "value1"}, false},
+		{andFilter, "data/a", map[string]string{"key1": "value1", "key2": "value2"}, false},
+	}
+	for i, tc := range testCases {
+		if result := tc.filter.Test(tc.object, tc.tags); result != tc.expectedResult {
+			t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedResult, result)
+		}
+	}
+}
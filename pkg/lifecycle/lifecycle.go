@@ -21,6 +21,7 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"time"
 )
 
 var (
@@ -76,11 +77,69 @@ func (lc Lifecycle) Validate() error {
 		// N B Empty prefixes overlap with all prefixes
 		otherRules := lc.Rules[i+1:]
 		for _, otherRule := range otherRules {
-			if strings.HasPrefix(lc.Rules[i].Filter.Prefix, otherRule.Filter.Prefix) ||
-				strings.HasPrefix(otherRule.Filter.Prefix, lc.Rules[i].Filter.Prefix) {
+			// Rules filtering by tags may share a prefix.
+			if lc.Rules[i].Filter.HasTags() || otherRule.Filter.HasTags() {
+				continue
+			}
+			if strings.HasPrefix(lc.Rules[i].Filter.GetPrefix(), otherRule.Filter.GetPrefix()) ||
+				strings.HasPrefix(otherRule.Filter.GetPrefix(), lc.Rules[i].Filter.GetPrefix()) {
 				return errLifecycleOverlappingPrefix
 			}
 		}
 	}
 	return nil
 }
+
+// Action - lifecycle action due for an object.
+type Action int
+
+const (
+	// NoneAction - no lifecycle action is due for the object.
+	NoneAction Action = iota
+	// DeleteAction - the object has expired and is to be deleted.
+	DeleteAction
+	// TransitionAction - the object data is to be moved to a remote tier.
+	TransitionAction
+)
+
+// FilterActionableRules - returns the enabled rules which select the
+// object with the given name and tags.
+func (lc Lifecycle) FilterActionableRules(objName string, tags map[string]string) []Rule {
+	var rules []Rule
+	for _, rule := range lc.Rules {
+		if rule.Status != "Enabled" {
+			continue
+		}
+		if rule.Filter.Test(objName, tags) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ComputeAction - returns the action due at the given time for an object
+// last modified at modTime, along with the storage class to transition
+// the object to. Expiration takes precedence over transition.
+func (lc Lifecycle) ComputeAction(objName string, tags map[string]string, modTime, now time.Time) (Action, string) {
+	action, storageClass := NoneAction, ""
+	for _, rule := range lc.FilterActionableRules(objName, tags) {
+		if !rule.Expiration.IsEmpty() && rule.Expiration.IsDue(modTime, now) {
+			return DeleteAction, ""
+		}
+		if action == NoneAction && !rule.Transition.IsEmpty() && rule.Transition.IsDue(modTime, now) {
+			action, storageClass = TransitionAction, rule.Transition.StorageClass
+		}
+	}
+	return action, storageClass
+}
+
+// IsMultipartUploadExpired - returns whether an incomplete multipart
+// upload of the object initiated at the given time is to be aborted.
+func (lc Lifecycle) IsMultipartUploadExpired(objName string, initiated, now time.Time) bool {
+	for _, rule := range lc.FilterActionableRules(objName, nil) {
+		if !rule.AbortIncompleteMultipartUpload.IsEmpty() && rule.AbortIncompleteMultipartUpload.IsDue(initiated, now) {
+			return true
+		}
+	}
+	return false
+}
@@ -128,6 +128,11 @@ func TestMarshalLifecycleConfig(t *testing.T) {
 				Filter:     Filter{Prefix: "prefix-1"},
 				Expiration: Expiration{Date: ExpirationDate(midnightTS)},
 			},
+			{
+				Status:                         "Enabled",
+				Filter:                         Filter{Prefix: "prefix-2"},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 7},
+			},
 		},
 	}
 	b, err := xml.MarshalIndent(&lc, "", "\t")
@@ -158,3 +163,69 @@ func TestMarshalLifecycleConfig(t *testing.T) {
 		}
 	}
 }
+
+// TestComputeActions checks the actions due for objects selected by
+// prefix and tag filters
+func TestComputeActions(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration>
+	                              <Rule>
+	                              <Filter><Prefix>logs/</Prefix></Filter>
+	                              <Status>Enabled</Status>
+	                              <Expiration><Days>90</Days></Expiration>
+	                              <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+	                              </Rule>
+	                              <Rule>
+	                              <Filter><And><Prefix>tmp/</Prefix><Tag><Key>expire</Key><Value>true</Value></Tag></And></Filter>
+	                              <Status>Enabled</Status>
+	                              <Expiration><Days>1</Days></Expiration>
+	                              </Rule>
+	                              <Rule>
+	                              <Filter><Tag><Key>class</Key><Value>scratch</Value></Tag></Filter>
+	                              <Status>Enabled</Status>
+	                              <Expiration><Date>2019-04-20T00:00:00Z</Date></Expiration>
+	                              </Rule>
+	                              <Rule>
+	                              <Filter><Prefix>uploads/</Prefix></Filter>
+	                              <Status>Enabled</Status>
+	                              <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+	                              </Rule>
+	                              </LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	testCases := []struct {
+		objName        string
+		tags           map[string]string
+		modTime        time.Time
+		expectedAction Action
+	}{
+		{"logs/new", nil, now.Add(-10 * day), NoneAction},
+		{"logs/warm", nil, now.Add(-31 * day), TransitionAction},
+		// Expiration takes precedence over transition.
+		{"logs/old", nil, now.Add(-91 * day), DeleteAction},
+		{"tmp/file", map[string]string{"expire": "true"}, now.Add(-2 * day), DeleteAction},
+		{"tmp/file", map[string]string{"expire": "false"}, now.Add(-2 * day), NoneAction},
+		{"tmp/file", nil, now.Add(-2 * day), NoneAction},
+		{"data/file", map[string]string{"class": "scratch"}, now, DeleteAction},
+		{"uploads/file", nil, now.Add(-365 * day), NoneAction},
+	}
+
+	for i, tc := range testCases {
+		if action, _ := lc.ComputeAction(tc.objName, tc.tags, tc.modTime, now); action != tc.expectedAction {
+			t.Errorf("%d: Expected %v but got %v", i+1, tc.expectedAction, action)
+		}
+	}
+
+	if !lc.IsMultipartUploadExpired("uploads/file", now.Add(-8*day), now) {
+		t.Errorf("Expected upload initiated 8 days ago to be aborted")
+	}
+	if lc.IsMultipartUploadExpired("uploads/file", now.Add(-6*day), now) {
+		t.Errorf("Expected upload initiated 6 days ago not to be aborted")
+	}
+	if lc.IsMultipartUploadExpired("logs/file", now.Add(-365*day), now) {
+		t.Errorf("Expected upload without abort rule not to be aborted")
+	}
+}
@@ -29,16 +29,17 @@ type Rule struct {
 	Filter     Filter     `xml:"Filter"`
 	Expiration Expiration `xml:"Expiration,omitempty"`
 	Transition Transition `xml:"Transition,omitempty"`
-	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
-	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
-	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition    NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
 var (
 	errInvalidRuleID           = errors.New("ID must be less than 255 characters")
 	errEmptyRuleStatus         = errors.New("Status should not be empty")
 	errInvalidRuleStatus       = errors.New("Status must be set to either Enabled or Disabled")
-	errMissingExpirationAction = errors.New("No expiration action found")
+	errMissingExpirationAction = errors.New("No expiration, transition or abort incomplete multipart upload action found")
 )
 
 // isIDValid - checks if ID is valid or not.
@@ -65,9 +66,26 @@ func (r Rule) validateStatus() error {
 }
 
 func (r Rule) validateAction() error {
-	if r.Expiration == (Expiration{}) {
+	if r.Expiration.IsEmpty() && r.Transition.IsEmpty() && r.AbortIncompleteMultipartUpload.IsEmpty() {
 		return errMissingExpirationAction
 	}
+	if !r.Expiration.IsEmpty() {
+		if err := r.Expiration.Validate(); err != nil {
+			return err
+		}
+	}
+	if !r.Transition.IsEmpty() {
+		if err := r.Transition.Validate(); err != nil {
+			return err
+		}
+	}
+	if !r.AbortIncompleteMultipartUpload.IsEmpty() {
+		// Multipart uploads have no tags to filter by.
+		if r.Filter.HasTags() {
+			return errAbortIncompleteMultipartUploadWithTags
+		}
+		return r.AbortIncompleteMultipartUpload.Validate()
+	}
 	return nil
 }
 
@@ -82,5 +100,8 @@ func (r Rule) Validate() error {
 	if err := r.validateAction(); err != nil {
 		return err
 	}
+	if err := r.Filter.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
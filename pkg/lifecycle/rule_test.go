@@ -25,8 +25,8 @@ import (
 // TestUnsupportedRules checks if Rule xml with unsuported tags return
 // appropriate errors on parsing
 func TestUnsupportedRules(t *testing.T) {
-	// NoncurrentVersionTransition, NoncurrentVersionExpiration
-	// and Transition tags aren't supported
+	// NoncurrentVersionTransition and NoncurrentVersionExpiration
+	// tags aren't supported
 	unsupportedTestCases := []struct {
 		inputXML    string
 		expectedErr error
@@ -44,12 +44,6 @@ func TestUnsupportedRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errNoncurrentVersionExpirationUnsupported,
 		},
-		{ // Rule with unsupported Transition action
-			inputXML: ` <Rule>
-	                     <Transition></Transition>
-	                    </Rule>`,
-			expectedErr: errTransitionUnsupported,
-		},
 	}
 
 	for i, tc := range unsupportedTestCases {
@@ -76,6 +70,35 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errMissingExpirationAction,
 		},
+		{ // Rule with transition action missing a storage class
+			inputXML: ` <Rule>
+                            <Status>Enabled</Status>
+                            <Transition><Days>30</Days></Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionInvalidStorageClass,
+		},
+		{ // Rule with expiration action specifying both days and date
+			inputXML: ` <Rule>
+                            <Status>Enabled</Status>
+                            <Expiration><Days>3</Days><Date>2019-04-20T00:00:00Z</Date></Expiration>
+	                    </Rule>`,
+			expectedErr: errLifecycleInvalidExpiration,
+		},
+		{ // Rule with abort incomplete multipart upload action missing days
+			inputXML: ` <Rule>
+                            <Status>Enabled</Status>
+                            <AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadInvalidDays,
+		},
+		{ // Rule with abort incomplete multipart upload action filtering by tags
+			inputXML: ` <Rule>
+                            <Status>Enabled</Status>
+                            <Filter><Tag><Key>key</Key><Value>value</Value></Tag></Filter>
+                            <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadWithTags,
+		},
 		{ // Rule with ID longer than 255 characters
 			inputXML: ` <Rule>
 	                    <ID> babababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababababab </ID>
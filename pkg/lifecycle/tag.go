@@ -19,6 +19,7 @@ package lifecycle
 import (
 	"encoding/xml"
 	"errors"
+	"unicode/utf8"
 )
 
 // Tag - a tag for a lifecycle configuration Rule filter.
@@ -28,15 +29,32 @@ type Tag struct {
 	Value   string   `xml:"Value,omitempty"`
 }
 
-var errTagUnsupported = errors.New("Specifying <Tag></Tag> is not supported")
+var (
+	errInvalidTagKey   = errors.New("The TagKey you have provided is invalid")
+	errInvalidTagValue = errors.New("The TagValue you have provided is invalid")
+)
 
-// UnmarshalXML is extended to indicate lack of support for Tag
-// xml tag in object lifecycle configuration
-func (t Tag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errTagUnsupported
+// IsEmpty - returns true if the tag is not set.
+func (t Tag) IsEmpty() bool {
+	return t.Key == "" && t.Value == ""
 }
 
-// MarshalXML is extended to leave out <Tag></Tag> tags
-func (t Tag) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+// Validate - validates the tag element
+func (t Tag) Validate() error {
+	if t.Key == "" || utf8.RuneCountInString(t.Key) > 128 {
+		return errInvalidTagKey
+	}
+	if utf8.RuneCountInString(t.Value) > 256 {
+		return errInvalidTagValue
+	}
 	return nil
 }
+
+// MarshalXML is extended to leave out empty <Tag></Tag> tags
+func (t Tag) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsEmpty() {
+		return nil
+	}
+	type tagWrapper Tag
+	return e.EncodeElement(tagWrapper(t), start)
+}
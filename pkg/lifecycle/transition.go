@@ -19,25 +19,83 @@ package lifecycle
 import (
 	"encoding/xml"
 	"errors"
+	"time"
 )
 
+var (
+	errTransitionInvalidDays         = errors.New("Days must be positive integer when used with Transition")
+	errTransitionInvalid             = errors.New("Exactly one of Days or Date should be present inside Transition")
+	errTransitionInvalidStorageClass = errors.New("StorageClass must be specified inside Transition")
+)
+
+// TransitionDays is a type alias to unmarshal Days in Transition
+type TransitionDays int
+
+// UnmarshalXML parses number of days from Transition and validates if
+// greater than zero
+func (tDays *TransitionDays) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var numDays int
+	err := d.DecodeElement(&numDays, &startElement)
+	if err != nil {
+		return err
+	}
+	if numDays <= 0 {
+		return errTransitionInvalidDays
+	}
+	*tDays = TransitionDays(numDays)
+	return nil
+}
+
+// MarshalXML encodes number of days to transition if it is non-zero and
+// encodes empty string otherwise
+func (tDays *TransitionDays) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if *tDays == TransitionDays(0) {
+		return nil
+	}
+	return e.EncodeElement(int(*tDays), startElement)
+}
+
 // Transition - transition actions for a rule in lifecycle configuration.
+// StorageClass names the remote tier object data is moved to.
 type Transition struct {
-	XMLName      xml.Name `xml:"Transition"`
-	Days         int      `xml:"Days,omitempty"`
-	Date         string   `xml:"Date,omitempty"`
-	StorageClass string   `xml:"StorageClass"`
+	XMLName      xml.Name       `xml:"Transition"`
+	Days         TransitionDays `xml:"Days,omitempty"`
+	Date         ExpirationDate `xml:"Date,omitempty"`
+	StorageClass string         `xml:"StorageClass"`
+}
+
+// IsEmpty - returns whether transition is specified or not.
+func (t Transition) IsEmpty() bool {
+	return t == Transition{}
 }
 
-var errTransitionUnsupported = errors.New("Specifying <Transition></Transition> tag is not supported")
+// Validate - validates the "Transition" element
+func (t Transition) Validate() error {
+	// Exactly one of transition days or date is specified
+	if (t.Days == TransitionDays(0)) == t.Date.IsZero() {
+		return errTransitionInvalid
+	}
+	if t.StorageClass == "" {
+		return errTransitionInvalidStorageClass
+	}
+	return nil
+}
 
-// UnmarshalXML is extended to indicate lack of support for Transition
-// xml tag in object lifecycle configuration
-func (t Transition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errTransitionUnsupported
+// IsDue - returns whether an object last modified at modTime is due
+// for transition at the given time.
+func (t Transition) IsDue(modTime, now time.Time) bool {
+	if !t.Date.IsZero() {
+		return !now.Before(t.Date.Time)
+	}
+	return !now.Before(modTime.Add(time.Duration(t.Days) * 24 * time.Hour))
 }
 
 // MarshalXML is extended to leave out <Transition></Transition> tags
+// when no transition is specified
 func (t Transition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return nil
+	if t.IsEmpty() {
+		return nil
+	}
+	type transitionWrapper Transition
+	return e.EncodeElement(transitionWrapper(t), start)
 }
@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestInvalidTransition checks if Transition xml with invalid elements
+// returns appropriate errors on parsing and validation
+func TestInvalidTransition(t *testing.T) {
+	testCases := []struct {
+		inputXML    string
+		expectedErr error
+	}{
+		{ // Transition with days and storage class
+			inputXML: ` <Transition>
+                                    <Days>30</Days>
+                                    <StorageClass>WARM</StorageClass>
+                                    </Transition>`,
+			expectedErr: nil,
+		},
+		{ // Transition with date and storage class
+			inputXML: ` <Transition>
+                                    <Date>2019-04-20T00:00:00Z</Date>
+                                    <StorageClass>WARM</StorageClass>
+                                    </Transition>`,
+			expectedErr: nil,
+		},
+		{ // Transition with zero days
+			inputXML: ` <Transition>
+                                    <Days>0</Days>
+                                    <StorageClass>WARM</StorageClass>
+                                    </Transition>`,
+			expectedErr: errTransitionInvalidDays,
+		},
+		{ // Transition with neither number of days nor a date
+			inputXML: ` <Transition>
+                                    <StorageClass>WARM</StorageClass>
+                                    </Transition>`,
+			expectedErr: errTransitionInvalid,
+		},
+		{ // Transition with both number of days and a date
+			inputXML: ` <Transition>
+                                    <Days>30</Days>
+                                    <Date>2019-04-20T00:00:00Z</Date>
+                                    <StorageClass>WARM</StorageClass>
+                                    </Transition>`,
+			expectedErr: errTransitionInvalid,
+		},
+		{ // Transition without storage class
+			inputXML: ` <Transition>
+                                    <Days>30</Days>
+                                    </Transition>`,
+			expectedErr: errTransitionInvalidStorageClass,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			var transition Transition
+			err := xml.Unmarshal([]byte(tc.inputXML), &transition)
+			if err == nil {
+				err = transition.Validate()
+			}
+			if err != tc.expectedErr {
+				t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+// TestComputeTransitionAction checks which objects are due for transition
+func TestComputeTransitionAction(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration>
+	                              <Rule>
+	                              <Filter><Prefix>logs/</Prefix></Filter>
+	                              <Status>Enabled</Status>
+	                              <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+	                              </Rule>
+	                              <Rule>
+	                              <Filter><Prefix>data/</Prefix></Filter>
+	                              <Status>Disabled</Status>
+	                              <Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition>
+	                              </Rule>
+	                              <Rule>
+	                              <Filter><Prefix>archive/</Prefix></Filter>
+	                              <Status>Enabled</Status>
+	                              <Transition><Date>2019-04-20T00:00:00Z</Date><StorageClass>COLD</StorageClass></Transition>
+	                              </Rule>
+	                              </LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		objName              string
+		modTime              time.Time
+		expectedStorageClass string
+	}{
+		{"logs/old", now.Add(-31 * 24 * time.Hour), "WARM"},
+		{"logs/new", now.Add(-29 * 24 * time.Hour), ""},
+		{"data/old", now.Add(-31 * 24 * time.Hour), ""},
+		{"archive/new", now, "COLD"},
+		{"other/old", now.Add(-365 * 24 * time.Hour), ""},
+	}
+
+	for i, tc := range testCases {
+		expectedAction := TransitionAction
+		if tc.expectedStorageClass == "" {
+			expectedAction = NoneAction
+		}
+		if action, storageClass := lc.ComputeAction(tc.objName, nil, tc.modTime, now); action != expectedAction || storageClass != tc.expectedStorageClass {
+			t.Errorf("%d: Expected %v %q but got %v %q", i+1, expectedAction, tc.expectedStorageClass, action, storageClass)
+		}
+	}
+
+	// Transition is kept when the configuration is marshaled.
+	data, err := xml.Marshal(lc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ParseLifecycleConfig(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("<StorageClass>COLD</StorageClass>")) {
+		t.Fatalf("Expected transition in %s", data)
+	}
+}
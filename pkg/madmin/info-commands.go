@@ -158,6 +158,60 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 	return serversInfo, nil
 }
 
+// BucketUsageInfo holds the number of objects, their total size and
+// the histogram of their sizes in a single bucket.
+type BucketUsageInfo struct {
+	Size                  uint64            `json:"size"`
+	ObjectsCount          uint64            `json:"objectsCount"`
+	ObjectsSizesHistogram map[string]uint64 `json:"objectsSizesHistogram"`
+}
+
+// DataUsageInfo holds the data usage of the cluster as computed by the
+// last run of the background data usage crawler.
+type DataUsageInfo struct {
+	// LastUpdate is the time the crawler finished computing the usage.
+	LastUpdate time.Time `json:"lastUpdate"`
+
+	ObjectsCount          uint64            `json:"objectsCount"`
+	ObjectsTotalSize      uint64            `json:"objectsTotalSize"`
+	ObjectsSizesHistogram map[string]uint64 `json:"objectsSizesHistogram"`
+
+	BucketsCount uint64                     `json:"bucketsCount"`
+	BucketsUsage map[string]BucketUsageInfo `json:"bucketsUsage"`
+}
+
+// DataUsageInfo - returns the data usage of the cluster, i.e. the
+// number of objects and their total size per bucket. The usage is
+// computed periodically in the background, LastUpdate is zero if it
+// was never computed.
+func (adm *AdminClient) DataUsageInfo() (DataUsageInfo, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/datausageinfo"})
+	defer closeResponse(resp)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return DataUsageInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	// Unmarshal the server's json response
+	var dataUsageInfo DataUsageInfo
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	err = json.Unmarshal(respBytes, &dataUsageInfo)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	return dataUsageInfo, nil
+}
+
 // ServerDrivesPerfInfo holds informantion about address and write speed of
 // all drives in a single server node
 type ServerDrivesPerfInfo struct {
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// KMSKeyRotateStartSuccess - holds information about a successfully
+// started KMS key rotation.
+type KMSKeyRotateStartSuccess struct {
+	ClientToken   string    `json:"clientToken"`
+	ClientAddress string    `json:"clientAddress"`
+	StartTime     time.Time `json:"startTime"`
+}
+
+// KMSKeyRotateStopSuccess - holds information about a successfully
+// stopped KMS key rotation.
+type KMSKeyRotateStopSuccess KMSKeyRotateStartSuccess
+
+// KMSKeyRotateStatus - status of a KMS key rotation, the items are
+// the results since the status was requested the last time.
+type KMSKeyRotateStatus struct {
+	Summary        string    `json:"summary"`
+	FailureDetail  string    `json:"detail,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	ObjectsScanned int64     `json:"objectsScanned"`
+	ObjectsRotated int64     `json:"objectsRotated"`
+	ObjectsFailed  int64     `json:"objectsFailed"`
+
+	Items []KMSKeyRotateResultItem `json:"items,omitempty"`
+}
+
+// KMSKeyRotateResultItem - result of re-wrapping the sealed key of
+// an object version, Detail holds the error if it failed.
+type KMSKeyRotateResultItem struct {
+	ResultIndex int64  `json:"resultId"`
+	Bucket      string `json:"bucket"`
+	Object      string `json:"object"`
+	VersionID   string `json:"versionId,omitempty"`
+	KeyID       string `json:"keyId"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+// RotateKMSKey - starts a KMS key rotation of the SSE-S3 and SSE-KMS
+// objects under bucket/prefix, the sealed keys of the objects are
+// re-wrapped with the current version of their KMS master key. An
+// empty bucket rotates the keys of all buckets.
+//
+// On success a client token is returned which is used to request the
+// status of the rotation. Setting forceStart stops a rotation already
+// running on the same path and starts afresh, forceStop stops it.
+func (adm *AdminClient) RotateKMSKey(bucket, prefix, clientToken string, forceStart, forceStop bool) (
+	rotateStart KMSKeyRotateStartSuccess, rotateStatus KMSKeyRotateStatus, err error) {
+
+	if forceStart && forceStop {
+		return rotateStart, rotateStatus, ErrInvalidArgument("forceStart and forceStop set to true is not allowed")
+	}
+
+	path := fmt.Sprintf("/v1/kms/key/rotate/%s", bucket)
+	if bucket != "" && prefix != "" {
+		path += "/" + prefix
+	}
+
+	queryVals := make(url.Values)
+	if clientToken != "" {
+		queryVals.Set("clientToken", clientToken)
+	}
+	if forceStart {
+		queryVals.Set("forceStart", "true")
+	} else if forceStop {
+		queryVals.Set("forceStop", "true")
+	}
+
+	resp, err := adm.executeMethod("POST", requestData{
+		relPath:     path,
+		queryValues: queryVals,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return rotateStart, rotateStatus, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return rotateStart, rotateStatus, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return rotateStart, rotateStatus, err
+	}
+
+	// Was it a status request?
+	if clientToken == "" {
+		err = json.Unmarshal(respBytes, &rotateStart)
+	} else {
+		err = json.Unmarshal(respBytes, &rotateStatus)
+	}
+	return rotateStart, rotateStatus, err
+}
@@ -0,0 +1,355 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Mode - object retention mode.
+type Mode string
+
+const (
+	// Governance - retention can be bypassed by users holding the
+	// s3:BypassGovernanceRetention permission.
+	Governance Mode = "GOVERNANCE"
+
+	// Compliance - retention can not be bypassed by any user,
+	// including the owner, until the retention period expires.
+	Compliance Mode = "COMPLIANCE"
+)
+
+// IsValid - returns true if the retention mode is valid.
+func (m Mode) IsValid() bool {
+	return m == Governance || m == Compliance
+}
+
+// LegalHoldStatus - object legal hold status.
+type LegalHoldStatus string
+
+const (
+	// LegalHoldOn - legal hold is in place.
+	LegalHoldOn LegalHoldStatus = "ON"
+
+	// LegalHoldOff - legal hold is not in place.
+	LegalHoldOff LegalHoldStatus = "OFF"
+)
+
+// IsValid - returns true if the legal hold status is valid.
+func (s LegalHoldStatus) IsValid() bool {
+	return s == LegalHoldOn || s == LegalHoldOff
+}
+
+const (
+	// Enabled - the only valid value of ObjectLockEnabled.
+	Enabled = "Enabled"
+
+	// AmzObjectLockMode - object retention mode header.
+	AmzObjectLockMode = "X-Amz-Object-Lock-Mode"
+
+	// AmzObjectLockRetainUntilDate - object retain until date header.
+	AmzObjectLockRetainUntilDate = "X-Amz-Object-Lock-Retain-Until-Date"
+
+	// AmzObjectLockLegalHold - object legal hold header.
+	AmzObjectLockLegalHold = "X-Amz-Object-Lock-Legal-Hold"
+
+	// AmzObjectLockBypassGovernance - header requesting governance
+	// retention to be bypassed.
+	AmzObjectLockBypassGovernance = "X-Amz-Bypass-Governance-Retention"
+
+	// AmzBucketObjectLockEnabled - header enabling object lock on
+	// bucket creation.
+	AmzBucketObjectLockEnabled = "X-Amz-Bucket-Object-Lock-Enabled"
+)
+
+var (
+	errInvalidObjectLockEnabled = errors.New("ObjectLockEnabled must be set to Enabled")
+	errInvalidMode              = errors.New("Mode must be set to either GOVERNANCE or COMPLIANCE")
+	errInvalidPeriod            = errors.New("Exactly one of Days or Years must be specified as a positive integer")
+	errInvalidRetainUntilDate   = errors.New("RetainUntilDate must be provided in ISO 8601 format")
+	errPastRetainUntilDate      = errors.New("RetainUntilDate must be in the future")
+	errIncompleteRetention      = errors.New("Mode and RetainUntilDate must both be specified")
+	errInvalidLegalHoldStatus   = errors.New("Status must be set to either ON or OFF")
+)
+
+// DefaultRetention - default retention applied to new objects in a bucket.
+type DefaultRetention struct {
+	XMLName xml.Name `xml:"DefaultRetention"`
+	Mode    Mode     `xml:"Mode"`
+	Days    *uint64  `xml:"Days,omitempty"`
+	Years   *uint64  `xml:"Years,omitempty"`
+}
+
+// Validate - validates the default retention.
+func (dr DefaultRetention) Validate() error {
+	if !dr.Mode.IsValid() {
+		return errInvalidMode
+	}
+	switch {
+	case dr.Days != nil && dr.Years != nil:
+		return errInvalidPeriod
+	case dr.Days != nil && *dr.Days > 0:
+	case dr.Years != nil && *dr.Years > 0:
+	default:
+		return errInvalidPeriod
+	}
+	return nil
+}
+
+// RetainUntil - returns the retain until date of an object created at t.
+func (dr DefaultRetention) RetainUntil(t time.Time) time.Time {
+	if dr.Years != nil {
+		return t.AddDate(int(*dr.Years), 0, 0)
+	}
+	return t.AddDate(0, 0, int(*dr.Days))
+}
+
+// Rule - object lock rule.
+type Rule struct {
+	XMLName          xml.Name         `xml:"Rule"`
+	DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+}
+
+// Config - object lock configuration of a bucket.
+type Config struct {
+	XMLNS             string   `xml:"xmlns,attr,omitempty"`
+	XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string   `xml:"ObjectLockEnabled"`
+	Rule              *Rule    `xml:"Rule,omitempty"`
+}
+
+// Validate - validates the object lock configuration.
+func (c Config) Validate() error {
+	if c.ObjectLockEnabled != Enabled {
+		return errInvalidObjectLockEnabled
+	}
+	if c.Rule != nil {
+		return c.Rule.DefaultRetention.Validate()
+	}
+	return nil
+}
+
+// DefaultRetention - returns the default retention of the
+// configuration, ok is false if there is none.
+func (c Config) DefaultRetention() (dr DefaultRetention, ok bool) {
+	if c.Rule == nil {
+		return dr, false
+	}
+	return c.Rule.DefaultRetention, true
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// NewConfig - returns an object lock configuration without default retention.
+func NewConfig() *Config {
+	return &Config{
+		XMLNS:             "http://s3.amazonaws.com/doc/2006-03-01/",
+		ObjectLockEnabled: Enabled,
+	}
+}
+
+// RetentionDate - embedded type containing time.Time to
+// marshal and unmarshal RetainUntilDate.
+type RetentionDate struct {
+	time.Time
+}
+
+// UnmarshalXML parses date from RetainUntilDate and validates date format.
+func (rDate *RetentionDate) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var dateStr string
+	if err := d.DecodeElement(&dateStr, &startElement); err != nil {
+		return err
+	}
+	t, err := ParseRetainUntilDate(dateStr)
+	if err != nil {
+		return err
+	}
+	*rDate = RetentionDate{t}
+	return nil
+}
+
+// MarshalXML encodes the date if it is non-zero and encodes
+// empty string otherwise.
+func (rDate *RetentionDate) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if rDate.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(FormatRetainUntilDate(rDate.Time), startElement)
+}
+
+// ParseRetainUntilDate - parses a retain until date, while AWS
+// documentation mentions ISO 8601 in reality RFC 3339 compliant
+// dates are accepted.
+func ParseRetainUntilDate(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, errInvalidRetainUntilDate
+	}
+	return t.UTC(), nil
+}
+
+// FormatRetainUntilDate - formats a retain until date.
+func FormatRetainUntilDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ObjectRetention - retention configuration of an object.
+type ObjectRetention struct {
+	XMLNS           string        `xml:"xmlns,attr,omitempty"`
+	XMLName         xml.Name      `xml:"Retention"`
+	Mode            Mode          `xml:"Mode,omitempty"`
+	RetainUntilDate RetentionDate `xml:"RetainUntilDate,omitempty"`
+}
+
+// IsEmpty - returns true if no retention is configured.
+func (r ObjectRetention) IsEmpty() bool {
+	return r.Mode == "" && r.RetainUntilDate.IsZero()
+}
+
+// IsActive - returns true if the retention period has not expired at t.
+func (r ObjectRetention) IsActive(t time.Time) bool {
+	return r.Mode.IsValid() && r.RetainUntilDate.After(t)
+}
+
+// Validate - validates the object retention, an empty retention is
+// valid and removes an existing governance retention.
+func (r ObjectRetention) Validate(now time.Time) error {
+	if r.IsEmpty() {
+		return nil
+	}
+	if r.Mode == "" || r.RetainUntilDate.IsZero() {
+		return errIncompleteRetention
+	}
+	if !r.Mode.IsValid() {
+		return errInvalidMode
+	}
+	if !r.RetainUntilDate.After(now) {
+		return errPastRetainUntilDate
+	}
+	return nil
+}
+
+// ParseObjectRetention - parses data in given reader to ObjectRetention.
+func ParseObjectRetention(reader io.Reader) (*ObjectRetention, error) {
+	var r ObjectRetention
+	if err := xml.NewDecoder(reader).Decode(&r); err != nil {
+		return nil, err
+	}
+	if err := r.Validate(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ObjectLegalHold - legal hold configuration of an object.
+type ObjectLegalHold struct {
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name        `xml:"LegalHold"`
+	Status  LegalHoldStatus `xml:"Status,omitempty"`
+}
+
+// IsOn - returns true if legal hold is in place.
+func (l ObjectLegalHold) IsOn() bool {
+	return l.Status == LegalHoldOn
+}
+
+// ParseObjectLegalHold - parses data in given reader to ObjectLegalHold.
+func ParseObjectLegalHold(reader io.Reader) (*ObjectLegalHold, error) {
+	var l ObjectLegalHold
+	if err := xml.NewDecoder(reader).Decode(&l); err != nil {
+		return nil, err
+	}
+	if !l.Status.IsValid() {
+		return nil, errInvalidLegalHoldStatus
+	}
+	return &l, nil
+}
+
+// GetObjectRetentionMeta - returns the retention stored in object metadata.
+func GetObjectRetentionMeta(meta map[string]string) ObjectRetention {
+	var r ObjectRetention
+	r.Mode = Mode(strings.ToUpper(meta[AmzObjectLockMode]))
+	if t, err := ParseRetainUntilDate(meta[AmzObjectLockRetainUntilDate]); err == nil {
+		r.RetainUntilDate = RetentionDate{t}
+	}
+	return r
+}
+
+// GetObjectLegalHoldMeta - returns the legal hold stored in object metadata.
+func GetObjectLegalHoldMeta(meta map[string]string) ObjectLegalHold {
+	return ObjectLegalHold{Status: LegalHoldStatus(strings.ToUpper(meta[AmzObjectLockLegalHold]))}
+}
+
+// ParseObjectLockHeaders - parses the object lock headers of a write
+// request, either all or none of mode and retain until date must be set.
+func ParseObjectLockHeaders(h http.Header) (r ObjectRetention, l ObjectLegalHold, err error) {
+	mode, date := h.Get(AmzObjectLockMode), h.Get(AmzObjectLockRetainUntilDate)
+	if mode != "" || date != "" {
+		if mode == "" || date == "" {
+			return r, l, errIncompleteRetention
+		}
+		r.Mode = Mode(strings.ToUpper(mode))
+		t, err := ParseRetainUntilDate(date)
+		if err != nil {
+			return r, l, err
+		}
+		r.RetainUntilDate = RetentionDate{t}
+		if err = r.Validate(time.Now().UTC()); err != nil {
+			return r, l, err
+		}
+	}
+	if status := h.Get(AmzObjectLockLegalHold); status != "" {
+		l.Status = LegalHoldStatus(strings.ToUpper(status))
+		if !l.Status.IsValid() {
+			return r, l, errInvalidLegalHoldStatus
+		}
+	}
+	return r, l, nil
+}
+
+// IsObjectLockRequested - returns true if any object lock header is set.
+func IsObjectLockRequested(h http.Header) bool {
+	return h.Get(AmzObjectLockMode) != "" || h.Get(AmzObjectLockRetainUntilDate) != "" ||
+		h.Get(AmzObjectLockLegalHold) != ""
+}
+
+// IsBypassGovernanceRequested - returns true if the request asks for
+// governance retention to be bypassed.
+func IsBypassGovernanceRequested(h http.Header) bool {
+	return strings.EqualFold(h.Get(AmzObjectLockBypassGovernance), "true")
+}
+
+// IsBucketObjectLockRequested - returns true if a bucket creation request
+// asks for object lock to be enabled.
+func IsBucketObjectLockRequested(h http.Header) bool {
+	return strings.EqualFold(h.Get(AmzBucketObjectLockEnabled), "true")
+}
@@ -0,0 +1,201 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig      string
+		expectedErr      error
+		expectedDefault  bool
+		expectedDuration time.Duration
+	}{
+		{ // Object lock without default retention
+			inputConfig: `<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`,
+		},
+		{ // Default retention in days
+			inputConfig:      `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			expectedDefault:  true,
+			expectedDuration: 24 * time.Hour,
+		},
+		{ // Missing ObjectLockEnabled
+			inputConfig: `<ObjectLockConfiguration></ObjectLockConfiguration>`,
+			expectedErr: errInvalidObjectLockEnabled,
+		},
+		{ // Invalid mode
+			inputConfig: `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>LOCKED</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			expectedErr: errInvalidMode,
+		},
+		{ // Both days and years
+			inputConfig: `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>1</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			expectedErr: errInvalidPeriod,
+		},
+		{ // Zero days
+			inputConfig: `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>0</Days></DefaultRetention></Rule></ObjectLockConfiguration>`,
+			expectedErr: errInvalidPeriod,
+		},
+	}
+
+	now := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			c, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			dr, ok := c.DefaultRetention()
+			if ok != tc.expectedDefault {
+				t.Fatalf("expected default retention: %v, got: %v", tc.expectedDefault, ok)
+			}
+			if ok && dr.RetainUntil(now).Sub(now) != tc.expectedDuration {
+				t.Fatalf("expected retention of %v, got: %v", tc.expectedDuration, dr.RetainUntil(now).Sub(now))
+			}
+		})
+	}
+}
+
+func TestParseObjectRetention(t *testing.T) {
+	future := FormatRetainUntilDate(time.Now().Add(time.Hour))
+	testCases := []struct {
+		inputRetention string
+		expectedErr    error
+		expectedEmpty  bool
+	}{
+		{ // Governance retention
+			inputRetention: `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`,
+		},
+		{ // Empty retention removes governance retention
+			inputRetention: `<Retention></Retention>`,
+			expectedEmpty:  true,
+		},
+		{ // Retain until date in the past
+			inputRetention: `<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>2000-01-01T00:00:00Z</RetainUntilDate></Retention>`,
+			expectedErr:    errPastRetainUntilDate,
+		},
+		{ // Missing retain until date
+			inputRetention: `<Retention><Mode>COMPLIANCE</Mode></Retention>`,
+			expectedErr:    errIncompleteRetention,
+		},
+		{ // Invalid mode
+			inputRetention: `<Retention><Mode>LOCKED</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`,
+			expectedErr:    errInvalidMode,
+		},
+		{ // Invalid date format
+			inputRetention: `<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>tomorrow</RetainUntilDate></Retention>`,
+			expectedErr:    errInvalidRetainUntilDate,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			r, err := ParseObjectRetention(bytes.NewReader([]byte(tc.inputRetention)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if r.IsEmpty() != tc.expectedEmpty {
+				t.Fatalf("expected empty: %v, got: %v", tc.expectedEmpty, r.IsEmpty())
+			}
+		})
+	}
+}
+
+func TestParseObjectLegalHold(t *testing.T) {
+	testCases := []struct {
+		inputLegalHold string
+		expectedErr    error
+		expectedOn     bool
+	}{
+		{`<LegalHold><Status>ON</Status></LegalHold>`, nil, true},
+		{`<LegalHold><Status>OFF</Status></LegalHold>`, nil, false},
+		{`<LegalHold></LegalHold>`, errInvalidLegalHoldStatus, false},
+		{`<LegalHold><Status>MAYBE</Status></LegalHold>`, errInvalidLegalHoldStatus, false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			l, err := ParseObjectLegalHold(bytes.NewReader([]byte(tc.inputLegalHold)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+			if err == nil && l.IsOn() != tc.expectedOn {
+				t.Fatalf("expected legal hold on: %v, got: %v", tc.expectedOn, l.IsOn())
+			}
+		})
+	}
+}
+
+func TestParseObjectLockHeaders(t *testing.T) {
+	future := FormatRetainUntilDate(time.Now().Add(time.Hour))
+	testCases := []struct {
+		header            http.Header
+		expectedErr       error
+		expectedMode      Mode
+		expectedLegalHold LegalHoldStatus
+	}{
+		{
+			header:       http.Header{AmzObjectLockMode: []string{"governance"}, AmzObjectLockRetainUntilDate: []string{future}},
+			expectedMode: Governance,
+		},
+		{
+			header:            http.Header{AmzObjectLockLegalHold: []string{"ON"}},
+			expectedLegalHold: LegalHoldOn,
+		},
+		{
+			header:      http.Header{AmzObjectLockMode: []string{"COMPLIANCE"}},
+			expectedErr: errIncompleteRetention,
+		},
+		{
+			header:      http.Header{AmzObjectLockMode: []string{"COMPLIANCE"}, AmzObjectLockRetainUntilDate: []string{"2000-01-01T00:00:00Z"}},
+			expectedErr: errPastRetainUntilDate,
+		},
+		{
+			header:      http.Header{AmzObjectLockLegalHold: []string{"MAYBE"}},
+			expectedErr: errInvalidLegalHoldStatus,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			r, l, err := ParseObjectLockHeaders(tc.header)
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if r.Mode != tc.expectedMode {
+				t.Fatalf("expected mode: %v, got: %v", tc.expectedMode, r.Mode)
+			}
+			if l.Status != tc.expectedLegalHold {
+				t.Fatalf("expected legal hold: %v, got: %v", tc.expectedLegalHold, l.Status)
+			}
+		})
+	}
+}
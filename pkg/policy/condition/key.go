@@ -39,6 +39,10 @@ const (
 	// x-amz-server-side-encryption-customer-algorithm HTTP header applicable to PutObject API only.
 	S3XAmzServerSideEncryptionCustomerAlgorithm Key = "s3:x-amz-server-side-encryption-customer-algorithm"
 
+	// S3XAmzServerSideEncryptionAwsKmsKeyID - key representing
+	// x-amz-server-side-encryption-aws-kms-key-id HTTP header applicable to PutObject API only.
+	S3XAmzServerSideEncryptionAwsKmsKeyID Key = "s3:x-amz-server-side-encryption-aws-kms-key-id"
+
 	// S3XAmzMetadataDirective - key representing x-amz-metadata-directive HTTP header applicable to
 	// PutObject API only.
 	S3XAmzMetadataDirective Key = "s3:x-amz-metadata-directive"
@@ -59,6 +63,10 @@ const (
 	// S3MaxKeys - key representing max-keys query parameter of ListBucket API only.
 	S3MaxKeys Key = "s3:max-keys"
 
+	// S3ExistingObjectTag - key representing the value of a tag of the existing object,
+	// used as "s3:ExistingObjectTag/<tag-key>" in conditions of object APIs.
+	S3ExistingObjectTag Key = "s3:ExistingObjectTag"
+
 	// AWSReferer - key representing Referer header of any API.
 	AWSReferer Key = "aws:Referer"
 
@@ -92,6 +100,7 @@ var AllSupportedKeys = []Key{
 	S3XAmzCopySource,
 	S3XAmzServerSideEncryption,
 	S3XAmzServerSideEncryptionCustomerAlgorithm,
+	S3XAmzServerSideEncryptionAwsKmsKeyID,
 	S3XAmzMetadataDirective,
 	S3XAmzStorageClass,
 	S3LocationConstraint,
@@ -137,6 +146,11 @@ func substFuncFromValues(values map[string][]string) func(string) string {
 
 // IsValid - checks if key is valid or not.
 func (key Key) IsValid() bool {
+	// Keys with a variable part, such as "s3:ExistingObjectTag/<tag-key>".
+	if key.baseKey() != key {
+		return true
+	}
+
 	for _, supKey := range AllSupportedKeys {
 		if supKey == key {
 			return true
@@ -146,6 +160,22 @@ func (key Key) IsValid() bool {
 	return false
 }
 
+// baseKey - returns the key without its variable part, such as
+// "s3:ExistingObjectTag" for "s3:ExistingObjectTag/<tag-key>".
+func (key Key) baseKey() Key {
+	keyString := string(key)
+	prefix := string(S3ExistingObjectTag) + "/"
+	if strings.HasPrefix(keyString, prefix) && len(keyString) > len(prefix) {
+		return S3ExistingObjectTag
+	}
+	return key
+}
+
+// NewExistingObjectTagKey - returns the "s3:ExistingObjectTag/<tag-key>" key of a tag.
+func NewExistingObjectTagKey(tagKey string) Key {
+	return Key(string(S3ExistingObjectTag) + "/" + tagKey)
+}
+
 // MarshalJSON - encodes Key to JSON data.
 func (key Key) MarshalJSON() ([]byte, error) {
 	if !key.IsValid() {
@@ -214,7 +244,11 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	nset := make(KeySet)
 
 	for k := range set {
-		if _, ok := sset[k]; !ok {
+		if _, ok := sset[k]; ok {
+			continue
+		}
+		// Keys with a variable part are contained if their base key is.
+		if _, ok := sset[k.baseKey()]; !ok {
 			nset.Add(k)
 		}
 	}
@@ -38,6 +38,8 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{NewExistingObjectTagKey("project"), true},
+		{S3ExistingObjectTag, false},
 		{Key("foo"), false},
 	}
 
@@ -83,6 +85,7 @@ func TestKeyName(t *testing.T) {
 	}{
 		{S3XAmzCopySource, "x-amz-copy-source"},
 		{AWSReferer, "Referer"},
+		{NewExistingObjectTagKey("project"), "ExistingObjectTag/project"},
 	}
 
 	for i, testCase := range testCases {
@@ -148,6 +151,7 @@ func TestKeySetDifference(t *testing.T) {
 	}{
 		{NewKeySet(), NewKeySet(S3XAmzCopySource), NewKeySet()},
 		{NewKeySet(S3Prefix, S3Delimiter, S3MaxKeys), NewKeySet(S3Delimiter, S3MaxKeys), NewKeySet(S3Prefix)},
+		{NewKeySet(NewExistingObjectTagKey("project"), S3Prefix), NewKeySet(S3ExistingObjectTag), NewKeySet(S3Prefix)},
 	}
 
 	for i, testCase := range testCases {
@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+var (
+	errReplicationTooManyRules      = errors.New("Replication configuration allows a maximum of 1000 rules")
+	errReplicationNoRule            = errors.New("Replication configuration should have at least one rule")
+	errReplicationDuplicatePriority = errors.New("Replication configuration has rules with the same priority")
+	errReplicationDuplicateRuleID   = errors.New("Replication configuration has rules with the same ID")
+)
+
+// Config - Configuration for bucket replication.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	Role    string   `xml:"Role,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the replication configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) > 1000 {
+		return errReplicationTooManyRules
+	}
+	if len(c.Rules) == 0 {
+		return errReplicationNoRule
+	}
+
+	priorities := make(map[int]struct{}, len(c.Rules))
+	ids := make(map[string]struct{}, len(c.Rules))
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		// Priorities decide between rules selecting the same object.
+		if _, ok := priorities[r.Priority]; ok {
+			return errReplicationDuplicatePriority
+		}
+		priorities[r.Priority] = struct{}{}
+		if r.ID != "" {
+			if _, ok := ids[r.ID]; ok {
+				return errReplicationDuplicateRuleID
+			}
+			ids[r.ID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// FilterActionableRule - returns the enabled rule with the highest
+// priority which selects the object with the given name and tags.
+func (c Config) FilterActionableRule(objName string, tags map[string]string) (rule Rule, ok bool) {
+	for _, r := range c.Rules {
+		if r.Status != Enabled || !r.Filter.Test(objName, tags) {
+			continue
+		}
+		if !ok || r.Priority > rule.Priority {
+			rule, ok = r, true
+		}
+	}
+	return rule, ok
+}
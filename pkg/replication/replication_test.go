@@ -0,0 +1,157 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+const testDestination = `<Destination><Bucket>arn:minio:replication::dr:backup</Bucket></Destination>`
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		{ // Rule replicating all objects
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Prefix></Prefix></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Rule filtering by prefix and tag, replicating to a storage class
+			inputConfig: `<ReplicationConfiguration><Rule><ID>docs</ID><Priority>1</Priority><Status>Enabled</Status><Filter><And><Prefix>docs/</Prefix><Tag><Key>dr</Key><Value>yes</Value></Tag></And></Filter><Destination><Bucket>arn:minio:replication::dr:backup</Bucket><StorageClass>REDUCED_REDUNDANCY</StorageClass></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Rule replicating deletes
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // No rules
+			inputConfig: `<ReplicationConfiguration></ReplicationConfiguration>`,
+			expectedErr: errReplicationNoRule,
+		},
+		{ // Invalid rule status
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>On</Status><Filter></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidRuleStatus,
+		},
+		{ // Negative priority
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>-1</Priority><Status>Enabled</Status><Filter></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidRulePriority,
+		},
+		{ // Rules with the same priority
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Prefix>a/</Prefix></Filter>` + testDestination + `</Rule><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Prefix>b/</Prefix></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationDuplicatePriority,
+		},
+		{ // Rules with the same ID
+			inputConfig: `<ReplicationConfiguration><Rule><ID>r</ID><Priority>1</Priority><Status>Enabled</Status><Filter></Filter>` + testDestination + `</Rule><Rule><ID>r</ID><Priority>2</Priority><Status>Enabled</Status><Filter></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationDuplicateRuleID,
+		},
+		{ // Invalid delete marker replication status
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter></Filter><DeleteMarkerReplication><Status>On</Status></DeleteMarkerReplication>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidDeleteMarkerStatus,
+		},
+		{ // Delete marker replication with a tag filter
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Tag><Key>dr</Key><Value>yes</Value></Tag></Filter><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>` + testDestination + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errDeleteMarkerReplicationWithTags,
+		},
+		{ // Destination bucket is not an ARN
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter></Filter><Destination><Bucket>backup</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidDestinationARN,
+		},
+		{ // Destination ARN without bucket
+			inputConfig: `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter></Filter><Destination><Bucket>arn:minio:replication::dr:</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidDestinationARN,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDestinationParse(t *testing.T) {
+	target, bucket, err := Destination{Bucket: "arn:minio:replication::dr:backup"}.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "dr" || bucket != "backup" {
+		t.Fatalf("expected target dr and bucket backup, got %s and %s", target, bucket)
+	}
+}
+
+func TestFilterActionableRule(t *testing.T) {
+	config, err := ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration>` +
+		`<Rule><ID>all</ID><Priority>1</Priority><Status>Enabled</Status><Filter></Filter>` + testDestination + `</Rule>` +
+		`<Rule><ID>docs</ID><Priority>2</Priority><Status>Enabled</Status><Filter><Prefix>docs/</Prefix></Filter>` + testDestination + `</Rule>` +
+		`<Rule><ID>tagged</ID><Priority>3</Priority><Status>Enabled</Status><Filter><Tag><Key>dr</Key><Value>yes</Value></Tag></Filter>` + testDestination + `</Rule>` +
+		`<Rule><ID>disabled</ID><Priority>4</Priority><Status>Disabled</Status><Filter></Filter>` + testDestination + `</Rule>` +
+		`</ReplicationConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		objectName string
+		tags       map[string]string
+		expectedID string
+	}{
+		{"photos/a.jpg", nil, "all"},
+		{"docs/a.pdf", nil, "docs"},
+		{"docs/a.pdf", map[string]string{"dr": "yes"}, "tagged"},
+		{"docs/a.pdf", map[string]string{"dr": "no"}, "docs"},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.FilterActionableRule(tc.objectName, tc.tags)
+		if !ok {
+			t.Fatalf("Test %d: expected rule %s, got none", i+1, tc.expectedID)
+		}
+		if rule.ID != tc.expectedID {
+			t.Fatalf("Test %d: expected rule %s, got %s", i+1, tc.expectedID, rule.ID)
+		}
+	}
+
+	config.Rules = config.Rules[3:]
+	if _, ok := config.FilterActionableRule("photos/a.jpg", nil); ok {
+		t.Fatal("expected no rule to be actionable")
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	config := Config{
+		Rules: []Rule{{
+			Priority:    1,
+			Status:      Enabled,
+			Destination: Destination{Bucket: "arn:minio:replication::dr:backup"},
+		}},
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<ReplicationConfiguration><Rule><Priority>1</Priority><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><Destination><Bucket>arn:minio:replication::dr:backup</Bucket></Destination></Rule></ReplicationConfiguration>`
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, string(data))
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+// Status - status of a replication rule or of the replication of delete markers.
+type Status string
+
+const (
+	// Enabled - the rule or delete marker replication is active.
+	Enabled Status = "Enabled"
+
+	// Disabled - the rule or delete marker replication is inactive.
+	Disabled Status = "Disabled"
+)
+
+// destinationARNPrefix - prefix of the ARN of a destination bucket,
+// a complete ARN reads "arn:minio:replication::<target>:<bucket>"
+// where target names a remote target of the server configuration.
+const destinationARNPrefix = "arn:minio:replication::"
+
+var (
+	errInvalidRuleID                   = errors.New("ID must be less than 255 characters")
+	errInvalidRuleStatus               = errors.New("Status must be set to either Enabled or Disabled")
+	errInvalidRulePriority             = errors.New("Priority must be a non-negative integer")
+	errInvalidDeleteMarkerStatus       = errors.New("DeleteMarkerReplication status must be set to either Enabled or Disabled")
+	errDeleteMarkerReplicationWithTags = errors.New("DeleteMarkerReplication is not supported for rules filtering by tags")
+	errInvalidDestinationARN           = errors.New("Destination bucket must be an ARN of the form arn:minio:replication::<target>:<bucket>")
+)
+
+// DeleteMarkerReplication - whether deletes of objects selected by a
+// rule are replicated to the destination bucket.
+type DeleteMarkerReplication struct {
+	XMLName xml.Name `xml:"DeleteMarkerReplication"`
+	Status  Status   `xml:"Status"`
+}
+
+// IsEmpty - returns true if delete marker replication is not set.
+func (d DeleteMarkerReplication) IsEmpty() bool {
+	return d.Status == ""
+}
+
+// Validate - validates the delete marker replication element.
+func (d DeleteMarkerReplication) Validate() error {
+	switch d.Status {
+	case "", Enabled, Disabled:
+		return nil
+	}
+	return errInvalidDeleteMarkerStatus
+}
+
+// MarshalXML is extended to leave out empty <DeleteMarkerReplication></DeleteMarkerReplication> tags
+func (d DeleteMarkerReplication) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsEmpty() {
+		return nil
+	}
+	type deleteMarkerReplicationWrapper DeleteMarkerReplication
+	return e.EncodeElement(deleteMarkerReplicationWrapper(d), start)
+}
+
+// Destination - the bucket objects selected by a rule are replicated to.
+type Destination struct {
+	XMLName      xml.Name `xml:"Destination"`
+	Bucket       string   `xml:"Bucket"`
+	StorageClass string   `xml:"StorageClass,omitempty"`
+}
+
+// Parse - returns the remote target and the bucket named by the ARN
+// of the destination bucket.
+func (d Destination) Parse() (target, bucket string, err error) {
+	if !strings.HasPrefix(d.Bucket, destinationARNPrefix) {
+		return "", "", errInvalidDestinationARN
+	}
+	tokens := strings.Split(strings.TrimPrefix(d.Bucket, destinationARNPrefix), ":")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return "", "", errInvalidDestinationARN
+	}
+	return tokens[0], tokens[1], nil
+}
+
+// Validate - validates the destination element.
+func (d Destination) Validate() error {
+	_, _, err := d.Parse()
+	return err
+}
+
+// Rule - a rule for replication configuration.
+type Rule struct {
+	XMLName                 xml.Name                `xml:"Rule"`
+	ID                      string                  `xml:"ID,omitempty"`
+	Priority                int                     `xml:"Priority"`
+	Status                  Status                  `xml:"Status"`
+	Filter                  lifecycle.Filter        `xml:"Filter"`
+	DeleteMarkerReplication DeleteMarkerReplication `xml:"DeleteMarkerReplication,omitempty"`
+	Destination             Destination             `xml:"Destination"`
+}
+
+// Validate - validates the rule element.
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if r.Status != Enabled && r.Status != Disabled {
+		return errInvalidRuleStatus
+	}
+	if r.Priority < 0 {
+		return errInvalidRulePriority
+	}
+	if err := r.Filter.Validate(); err != nil {
+		return err
+	}
+	if err := r.DeleteMarkerReplication.Validate(); err != nil {
+		return err
+	}
+	// Deleted objects have no tags to filter by.
+	if r.ReplicatesDeletes() && r.Filter.HasTags() {
+		return errDeleteMarkerReplicationWithTags
+	}
+	return r.Destination.Validate()
+}
+
+// ReplicatesDeletes - returns true if deletes of objects selected by
+// the rule are replicated.
+func (r Rule) ReplicatesDeletes() bool {
+	return r.DeleteMarkerReplication.Status == Enabled
+}
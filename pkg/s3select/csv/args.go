@@ -20,6 +20,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"strings"
+
+	"github.com/minio/minio/pkg/s3select/sql"
 )
 
 const (
@@ -54,6 +56,12 @@ func (args *ReaderArgs) IsEmpty() bool {
 	return !args.unmarshaled
 }
 
+// HasHeader - returns whether the first record is a header, which is
+// either used or ignored.
+func (args *ReaderArgs) HasHeader() bool {
+	return args.FileHeaderInfo != none
+}
+
 // UnmarshalXML - decodes XML data.
 func (args *ReaderArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	// Make subtype to avoid recursive UnmarshalXML().
@@ -136,6 +144,16 @@ func (args *WriterArgs) IsEmpty() bool {
 	return !args.unmarshaled
 }
 
+// WriteCSVOpts - returns the CSV encoding options of the output.
+func (args *WriterArgs) WriteCSVOpts() sql.WriteCSVOpts {
+	return sql.WriteCSVOpts{
+		FieldDelimiter: []rune(args.FieldDelimiter)[0],
+		Quote:          []rune(args.QuoteCharacter)[0],
+		QuoteEscape:    []rune(args.QuoteEscapeCharacter)[0],
+		AlwaysQuote:    args.QuoteFields == always,
+	}
+}
+
 // UnmarshalXML - decodes XML data.
 func (args *WriterArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	// Make subtype to avoid recursive UnmarshalXML().
@@ -170,20 +188,20 @@ func (args *WriterArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) err
 		return fmt.Errorf("invalid FieldDelimiter '%v'", parsedArgs.FieldDelimiter)
 	}
 
-	switch parsedArgs.QuoteCharacter {
-	case "":
+	switch len([]rune(parsedArgs.QuoteCharacter)) {
+	case 0:
 		parsedArgs.QuoteCharacter = defaultQuoteCharacter
-	case defaultQuoteCharacter:
+	case 1:
 	default:
-		return fmt.Errorf("unsupported QuoteCharacter '%v'", parsedArgs.QuoteCharacter)
+		return fmt.Errorf("invalid QuoteCharacter '%v'", parsedArgs.QuoteCharacter)
 	}
 
-	switch parsedArgs.QuoteEscapeCharacter {
-	case "":
-		parsedArgs.QuoteEscapeCharacter = defaultQuoteEscapeCharacter
-	case defaultQuoteEscapeCharacter:
+	switch len([]rune(parsedArgs.QuoteEscapeCharacter)) {
+	case 0:
+		parsedArgs.QuoteEscapeCharacter = parsedArgs.QuoteCharacter
+	case 1:
 	default:
-		return fmt.Errorf("unsupported QuoteEscapeCharacter '%v'", parsedArgs.QuoteEscapeCharacter)
+		return fmt.Errorf("invalid QuoteEscapeCharacter '%v'", parsedArgs.QuoteEscapeCharacter)
 	}
 
 	*args = WriterArgs(parsedArgs)
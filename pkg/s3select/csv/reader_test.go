@@ -57,7 +57,7 @@ func TestRead(t *testing.T) {
 			if err != nil {
 				break
 			}
-			s, _ := record.MarshalCSV([]rune(c.fieldDelimiter)[0])
+			s, _ := record.MarshalCSV(sql.WriteCSVOpts{FieldDelimiter: []rune(c.fieldDelimiter)[0]})
 			result += string(s) + c.recordDelimiter
 		}
 		r.Close()
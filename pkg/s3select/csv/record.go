@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/bcicen/jstream"
 	"github.com/minio/minio/pkg/s3select/sql"
@@ -62,11 +63,27 @@ func (r *Record) Set(name string, value *sql.Value) error {
 }
 
 // MarshalCSV - encodes to CSV data.
-func (r *Record) MarshalCSV(fieldDelimiter rune) ([]byte, error) {
+func (r *Record) MarshalCSV(opts sql.WriteCSVOpts) ([]byte, error) {
+	return MarshalFields(r.csvRecord, opts)
+}
+
+// MarshalFields - encodes the fields as CSV record without the
+// record delimiter.
+func MarshalFields(fields []string, opts sql.WriteCSVOpts) ([]byte, error) {
+	if opts.Quote == 0 {
+		opts.Quote = '"'
+	}
+	if opts.QuoteEscape == 0 {
+		opts.QuoteEscape = opts.Quote
+	}
+	if opts.AlwaysQuote || opts.Quote != '"' || opts.QuoteEscape != '"' {
+		return marshalQuotedFields(fields, opts), nil
+	}
+
 	buf := new(bytes.Buffer)
 	w := csv.NewWriter(buf)
-	w.Comma = fieldDelimiter
-	if err := w.Write(r.csvRecord); err != nil {
+	w.Comma = opts.FieldDelimiter
+	if err := w.Write(fields); err != nil {
 		return nil, err
 	}
 	w.Flush()
@@ -78,6 +95,45 @@ func (r *Record) MarshalCSV(fieldDelimiter rune) ([]byte, error) {
 	return data[:len(data)-1], nil
 }
 
+// marshalQuotedFields - encodes the fields with custom quoting, which
+// encoding/csv does not support.
+func marshalQuotedFields(fields []string, opts sql.WriteCSVOpts) []byte {
+	quote, escape := string(opts.Quote), string(opts.QuoteEscape)
+	replacer := strings.NewReplacer(quote, escape+quote)
+	if escape != quote {
+		replacer = strings.NewReplacer(quote, escape+quote, escape, escape+escape)
+	}
+
+	buf := new(bytes.Buffer)
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteRune(opts.FieldDelimiter)
+		}
+		if !opts.AlwaysQuote && !fieldNeedsQuotes(field, opts) {
+			buf.WriteString(field)
+			continue
+		}
+		buf.WriteString(quote)
+		buf.WriteString(replacer.Replace(field))
+		buf.WriteString(quote)
+	}
+	return buf.Bytes()
+}
+
+// fieldNeedsQuotes - reports whether the field must be quoted, with
+// the same rules as encoding/csv.
+func fieldNeedsQuotes(field string, opts sql.WriteCSVOpts) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, opts.FieldDelimiter) ||
+		strings.ContainsRune(field, opts.Quote) || strings.ContainsRune(field, opts.QuoteEscape) ||
+		strings.ContainsAny(field, "\r\n") {
+		return true
+	}
+	return field[0] == ' ' || field[0] == '\t'
+}
+
 // MarshalJSON - encodes to JSON data.
 func (r *Record) MarshalJSON() ([]byte, error) {
 	var kvs jstream.KVS = make([]jstream.KV, len(r.columnNames))
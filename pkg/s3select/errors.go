@@ -92,7 +92,7 @@ func errInvalidRequestParameter(err error) *s3Error {
 func errObjectSerializationConflict(err error) *s3Error {
 	return &s3Error{
 		code:       "ObjectSerializationConflict",
-		message:    "InputSerialization specifies more than one format (CSV, JSON, or Parquet), or OutputSerialization specifies more than one format (CSV or JSON). InputSerialization and OutputSerialization can only specify one format each.",
+		message:    "InputSerialization specifies more than one format (CSV, JSON, or Parquet), or OutputSerialization specifies more than one format (CSV, JSON, or Parquet). InputSerialization and OutputSerialization can only specify one format each.",
 		statusCode: 400,
 		cause:      err,
 	}
@@ -101,7 +101,16 @@ func errObjectSerializationConflict(err error) *s3Error {
 func errInvalidExpressionType(err error) *s3Error {
 	return &s3Error{
 		code:       "InvalidExpressionType",
-		message:    "The ExpressionType is invalid. Only SQL expressions are supported.",
+		message:    "The ExpressionType is invalid. Only SQL and MinIOSQL expressions are supported.",
+		statusCode: 400,
+		cause:      err,
+	}
+}
+
+func errUnsupportedSyntax(err error) *s3Error {
+	return &s3Error{
+		code:       "UnsupportedSyntax",
+		message:    err.Error(),
 		statusCode: 400,
 		cause:      err,
 	}
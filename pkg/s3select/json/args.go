@@ -40,6 +40,11 @@ func (args *ReaderArgs) IsEmpty() bool {
 	return !args.unmarshaled
 }
 
+// IsLines - returns whether the JSON objects are separated by newlines.
+func (args *ReaderArgs) IsLines() bool {
+	return args.ContentType == lines
+}
+
 // UnmarshalXML - decodes XML data.
 func (args *ReaderArgs) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	// Make subtype to avoid recursive UnmarshalXML().
@@ -17,14 +17,13 @@
 package json
 
 import (
-	"bytes"
-	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/bcicen/jstream"
+	csvfmt "github.com/minio/minio/pkg/s3select/csv"
 	"github.com/minio/minio/pkg/s3select/sql"
 )
 
@@ -78,7 +77,7 @@ func (r *Record) Set(name string, value *sql.Value) error {
 }
 
 // MarshalCSV - encodes to CSV data.
-func (r *Record) MarshalCSV(fieldDelimiter rune) ([]byte, error) {
+func (r *Record) MarshalCSV(opts sql.WriteCSVOpts) ([]byte, error) {
 	var csvRecord []string
 	for _, kv := range r.KVS {
 		var columnValue string
@@ -95,19 +94,7 @@ func (r *Record) MarshalCSV(fieldDelimiter rune) ([]byte, error) {
 		csvRecord = append(csvRecord, columnValue)
 	}
 
-	buf := new(bytes.Buffer)
-	w := csv.NewWriter(buf)
-	w.Comma = fieldDelimiter
-	if err := w.Write(csvRecord); err != nil {
-		return nil, err
-	}
-	w.Flush()
-	if err := w.Error(); err != nil {
-		return nil, err
-	}
-
-	data := buf.Bytes()
-	return data[:len(data)-1], nil
+	return csvfmt.MarshalFields(csvRecord, opts)
 }
 
 // Raw - returns the underlying representation.
@@ -361,6 +361,26 @@ func (writer *messageWriter) SendRecord(payload []byte) error {
 	}
 }
 
+// recordsWriter - is an io.WriteCloser which sends the written data as
+// record payload, used to write Parquet output.
+type recordsWriter struct {
+	writer *messageWriter
+}
+
+func (w *recordsWriter) Write(p []byte) (int, error) {
+	// The payload is consumed asynchronously, so send a copy.
+	if err := w.writer.SendRecord(append([]byte{}, p...)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close - does nothing, the message writer is finished by the caller.
+func (w *recordsWriter) Close() error {
+	return nil
+}
+
 func (writer *messageWriter) flushRecords() bool {
 	if writer.payloadBufferIndex == 0 {
 		return true
@@ -0,0 +1,315 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bcicen/jstream"
+	csvfmt "github.com/minio/minio/pkg/s3select/csv"
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// Kinds of the values of an output record.
+const (
+	kindNull byte = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindTimestamp
+	kindString
+)
+
+var errInvalidRecordData = errors.New("invalid Parquet record data")
+
+// Record - is Parquet output record. Unlike JSON and CSV records, it
+// keeps the type of every value so that the Parquet schema can be
+// inferred from the output records.
+type Record struct {
+	names  []string
+	values []interface{} // nil, bool, int64, float64, time.Time or string
+}
+
+// Get - gets the value for a column name.
+func (r *Record) Get(name string) (*sql.Value, error) {
+	// Get is implemented directly in the sql package.
+	return nil, errors.New("not implemented here")
+}
+
+// Set - sets the value for a column name.
+func (r *Record) Set(name string, value *sql.Value) error {
+	var v interface{}
+	if value.IsNull() {
+		v = nil
+	} else if b, ok := value.ToBool(); ok {
+		v = b
+	} else if i, ok := value.ToInt(); ok {
+		v = i
+	} else if f, ok := value.ToFloat(); ok {
+		v = f
+	} else if t, ok := value.ToTimestamp(); ok {
+		v = t
+	} else if s, ok := value.ToString(); ok {
+		v = s
+	} else if b, ok := value.ToBytes(); ok {
+		v = string(b)
+	} else {
+		return fmt.Errorf("unsupported sql value %v and type %v", value, value.GetTypeString())
+	}
+
+	r.names = append(r.names, name)
+	r.values = append(r.values, v)
+	return nil
+}
+
+// MarshalCSV - encodes to CSV data.
+func (r *Record) MarshalCSV(opts sql.WriteCSVOpts) ([]byte, error) {
+	fields := make([]string, len(r.values))
+	for i, v := range r.values {
+		switch val := v.(type) {
+		case nil:
+			fields[i] = ""
+		case time.Time:
+			fields[i] = sql.FormatSQLTimestamp(val)
+		default:
+			fields[i] = fmt.Sprintf("%v", val)
+		}
+	}
+	return csvfmt.MarshalFields(fields, opts)
+}
+
+// MarshalJSON - encodes to JSON data.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.kvs())
+}
+
+// Raw - returns the underlying representation.
+func (r *Record) Raw() (sql.SelectObjectFormat, interface{}) {
+	return sql.SelectFmtParquet, r.kvs()
+}
+
+// Replace - replaces the columns of the record.
+func (r *Record) Replace(kvs jstream.KVS) error {
+	r.names, r.values = nil, nil
+	for _, kv := range kvs {
+		var v interface{}
+		switch val := kv.Value.(type) {
+		case nil, bool, int64, float64, string, time.Time:
+			v = val
+		case int:
+			v = int64(val)
+		default:
+			// Nested JSON values are written as JSON text.
+			data, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			v = string(data)
+		}
+		r.names = append(r.names, kv.Key)
+		r.values = append(r.values, v)
+	}
+	return nil
+}
+
+func (r *Record) kvs() jstream.KVS {
+	kvs := make(jstream.KVS, len(r.values))
+	for i, v := range r.values {
+		if t, ok := v.(time.Time); ok {
+			v = sql.FormatSQLTimestamp(t)
+		}
+		kvs[i] = jstream.KV{Key: r.names[i], Value: v}
+	}
+	return kvs
+}
+
+// MarshalBinary - encodes the record, including the value types, to
+// be decoded by UnmarshalBinary.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(data []byte, x uint64) []byte {
+		return append(data, buf[:binary.PutUvarint(buf[:], x)]...)
+	}
+	putString := func(data []byte, s string) []byte {
+		return append(putUvarint(data, uint64(len(s))), s...)
+	}
+
+	data := putUvarint(nil, uint64(len(r.values)))
+	for i, v := range r.values {
+		data = putString(data, r.names[i])
+		switch val := v.(type) {
+		case nil:
+			data = append(data, kindNull)
+		case bool:
+			data = append(data, kindBool)
+			if val {
+				data = append(data, 1)
+			} else {
+				data = append(data, 0)
+			}
+		case int64:
+			data = append(data, kindInt)
+			data = append(data, buf[:binary.PutVarint(buf[:], val)]...)
+		case float64:
+			data = append(data, kindFloat)
+			data = putUvarint(data, math.Float64bits(val))
+		case time.Time:
+			b, err := val.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, kindTimestamp)
+			data = putString(data, string(b))
+		case string:
+			data = append(data, kindString)
+			data = putString(data, val)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary - decodes data encoded by MarshalBinary.
+func (r *Record) UnmarshalBinary(data []byte) error {
+	uvarint := func() (uint64, error) {
+		x, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, errInvalidRecordData
+		}
+		data = data[n:]
+		return x, nil
+	}
+	readBytes := func() ([]byte, error) {
+		n, err := uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(data)) < n {
+			return nil, errInvalidRecordData
+		}
+		b := data[:n]
+		data = data[n:]
+		return b, nil
+	}
+
+	count, err := uvarint()
+	if err != nil {
+		return err
+	}
+	r.names, r.values = nil, nil
+	for ; count > 0; count-- {
+		name, err := readBytes()
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return errInvalidRecordData
+		}
+		kind := data[0]
+		data = data[1:]
+
+		var v interface{}
+		switch kind {
+		case kindNull:
+		case kindBool:
+			if len(data) == 0 {
+				return errInvalidRecordData
+			}
+			v = data[0] == 1
+			data = data[1:]
+		case kindInt:
+			i, n := binary.Varint(data)
+			if n <= 0 {
+				return errInvalidRecordData
+			}
+			v = i
+			data = data[n:]
+		case kindFloat:
+			bits, err := uvarint()
+			if err != nil {
+				return err
+			}
+			v = math.Float64frombits(bits)
+		case kindTimestamp:
+			b, err := readBytes()
+			if err != nil {
+				return err
+			}
+			var t time.Time
+			if err = t.UnmarshalBinary(b); err != nil {
+				return err
+			}
+			v = t
+		case kindString:
+			b, err := readBytes()
+			if err != nil {
+				return err
+			}
+			v = string(b)
+		default:
+			return errInvalidRecordData
+		}
+		r.names = append(r.names, string(name))
+		r.values = append(r.values, v)
+	}
+	return nil
+}
+
+// ToRecord - returns record as Parquet output record. Any record other
+// than a Parquet output record, which is the input record of a
+// `SELECT *` query, is converted using its columns.
+func ToRecord(record sql.Record) (*Record, error) {
+	if r, ok := record.(*Record); ok {
+		return r, nil
+	}
+
+	_, raw := record.Raw()
+	kvs, ok := raw.(jstream.KVS)
+	if !ok {
+		data, err := record.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := jstream.NewDecoder(bytes.NewReader(data), 0).ObjectAsKVS()
+		for v := range decoder.Stream() {
+			kvs, ok = v.Value.(jstream.KVS)
+		}
+		if err = decoder.Err(); err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("unsupported record %v", string(data))
+		}
+	}
+
+	r := NewRecord()
+	if err := r.Replace(kvs); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewRecord - creates new empty Parquet output record.
+func NewRecord() *Record {
+	return &Record{}
+}
@@ -0,0 +1,284 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	parquetgo "github.com/minio/parquet-go"
+	parquetgen "github.com/minio/parquet-go/gen-go/parquet"
+	"github.com/minio/parquet-go/schema"
+)
+
+// rowGroupRecords is the number of records per row group. The schema
+// is inferred from the records of the first row group.
+var rowGroupRecords = 1000
+
+type column struct {
+	name string // Parquet column name
+	kind byte
+}
+
+// Writer - Parquet record writer for S3Select.
+//
+// Every output column is an OPTIONAL column whose type is inferred
+// from its values in the first rowGroupRecords records: integer and
+// float values make a DOUBLE column, any other mix of types makes a
+// UTF8 column. Columns which are NULL only are UTF8 columns as well.
+type Writer struct {
+	writeCloser io.WriteCloser
+	writer      *parquetgo.Writer
+
+	columns []column
+	index   map[string]int // index of the column of an output name
+	pending []*Record      // records buffered for schema inference
+}
+
+// Write - writes single record.
+func (w *Writer) Write(record *Record) error {
+	if w.writer == nil {
+		w.pending = append(w.pending, record)
+		if len(w.pending) < rowGroupRecords {
+			return nil
+		}
+		return w.start()
+	}
+	return w.write(record)
+}
+
+// Close - writes all buffered records and the Parquet footer.
+func (w *Writer) Close() error {
+	if w.writer == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.writer.Close()
+}
+
+// start infers the schema from the buffered records, and writes them.
+func (w *Writer) start() error {
+	used := map[string]bool{}
+	for _, record := range w.pending {
+		for i, name := range record.names {
+			j, ok := w.index[name]
+			if !ok {
+				j = len(w.columns)
+				w.index[name] = j
+				w.columns = append(w.columns, column{name: columnName(name, used)})
+			}
+			w.columns[j].kind = mergeKind(w.columns[j].kind, valueKind(record.values[i]))
+		}
+	}
+
+	tree := schema.NewTree()
+	for _, col := range w.columns {
+		element, err := col.element()
+		if err != nil {
+			return err
+		}
+		if err = tree.Set(col.name, element); err != nil {
+			return err
+		}
+	}
+
+	writer, err := parquetgo.NewWriter(w.writeCloser, tree, rowGroupRecords)
+	if err != nil {
+		return err
+	}
+	w.writer = writer
+
+	for _, record := range w.pending {
+		if err = w.write(record); err != nil {
+			return err
+		}
+	}
+	w.pending = nil
+	return nil
+}
+
+func (w *Writer) write(record *Record) error {
+	values := make([]interface{}, len(w.columns))
+	for i, name := range record.names {
+		j, ok := w.index[name]
+		if !ok {
+			return fmt.Errorf("column %v is not part of the Parquet output schema", name)
+		}
+		values[j] = record.values[i]
+	}
+
+	data := make(map[string]*parquetgo.ColumnData, len(w.columns))
+	for i, col := range w.columns {
+		columnData, err := col.data(values[i])
+		if err != nil {
+			return err
+		}
+		data[col.name] = columnData
+	}
+	return w.writer.Write(data)
+}
+
+func (col *column) element() (*schema.Element, error) {
+	var valueType parquetgen.Type
+	var convertedType *parquetgen.ConvertedType
+	switch col.kind {
+	case kindBool:
+		valueType = parquetgen.Type_BOOLEAN
+	case kindInt:
+		valueType = parquetgen.Type_INT64
+	case kindFloat:
+		valueType = parquetgen.Type_DOUBLE
+	case kindTimestamp:
+		valueType = parquetgen.Type_INT64
+		convertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_TIMESTAMP_MILLIS)
+	default:
+		valueType = parquetgen.Type_BYTE_ARRAY
+		convertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_UTF8)
+	}
+
+	return schema.NewElement(col.name, parquetgen.FieldRepetitionType_OPTIONAL,
+		parquetgen.TypePtr(valueType), convertedType, nil, nil, nil)
+}
+
+// data returns the column data of a single value. A value which does
+// not match the column type is converted if possible.
+func (col *column) data(v interface{}) (*parquetgo.ColumnData, error) {
+	defLevel := int32(1)
+	if v == nil {
+		defLevel = 0
+	}
+
+	var values interface{}
+	switch col.kind {
+	case kindBool:
+		b, ok := v.(bool)
+		if !ok && v != nil {
+			return nil, col.typeMismatch(v)
+		}
+		values = []bool{b}
+	case kindInt:
+		i, ok := v.(int64)
+		if !ok && v != nil {
+			return nil, col.typeMismatch(v)
+		}
+		values = []int64{i}
+	case kindFloat:
+		var f float64
+		switch val := v.(type) {
+		case nil:
+		case float64:
+			f = val
+		case int64:
+			f = float64(val)
+		default:
+			return nil, col.typeMismatch(v)
+		}
+		values = []float64{f}
+	case kindTimestamp:
+		t, ok := v.(time.Time)
+		if !ok && v != nil {
+			return nil, col.typeMismatch(v)
+		}
+		var millis int64
+		if ok {
+			millis = t.UnixNano() / int64(time.Millisecond)
+		}
+		values = []int64{millis}
+	default:
+		var s string
+		switch val := v.(type) {
+		case nil:
+		case string:
+			s = val
+		case time.Time:
+			s = val.Format(time.RFC3339Nano)
+		default:
+			s = fmt.Sprintf("%v", val)
+		}
+		values = [][]byte{[]byte(s)}
+	}
+
+	return parquetgo.NewColumnData(values, []int32{defLevel}, []int32{0}), nil
+}
+
+func (col *column) typeMismatch(v interface{}) error {
+	return fmt.Errorf("value %v does not match the type of Parquet output column %v", v, col.name)
+}
+
+func valueKind(v interface{}) byte {
+	switch v.(type) {
+	case bool:
+		return kindBool
+	case int64:
+		return kindInt
+	case float64:
+		return kindFloat
+	case time.Time:
+		return kindTimestamp
+	case string:
+		return kindString
+	}
+	return kindNull
+}
+
+// mergeKind returns the column type of a column with values of kind
+// a and b.
+func mergeKind(a, b byte) byte {
+	switch {
+	case a == b || b == kindNull:
+		return a
+	case a == kindNull:
+		return b
+	case a == kindInt && b == kindFloat, a == kindFloat && b == kindInt:
+		return kindFloat
+	}
+	return kindString
+}
+
+// columnName returns a valid and unique Parquet column name for the
+// output name. Parquet column names consist of letters, digits and
+// underscores only.
+func columnName(name string, used map[string]bool) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			b[i] = '_'
+		}
+	}
+	base := string(b)
+	if base == "" {
+		base = "_"
+	}
+
+	name = base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%v_%v", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// NewWriter - creates new Parquet writer which writes the Parquet data
+// to writeCloser.
+func NewWriter(writeCloser io.WriteCloser) *Writer {
+	return &Writer{
+		writeCloser: writeCloser,
+		index:       map[string]int{},
+	}
+}
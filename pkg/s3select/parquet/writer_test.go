@@ -0,0 +1,157 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+type bufferWriteCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferWriteCloser) Close() error {
+	return nil
+}
+
+func readRecords(t *testing.T, data []byte) []string {
+	getReader := func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		end := int64(len(data))
+		if length > 0 && offset+length < end {
+			end = offset + length
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset:end])), nil
+	}
+
+	reader, err := NewReader(getReader, &ReaderArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	var records []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := record.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, string(data))
+	}
+	return records
+}
+
+func TestWriter(t *testing.T) {
+	ts := time.Date(2019, 6, 1, 12, 30, 0, 0, time.UTC)
+	rows := [][]*sql.Value{
+		{sql.FromString("a"), sql.FromInt(1), sql.FromInt(10), sql.FromBool(true), sql.FromTimestamp(ts), sql.FromNull()},
+		{sql.FromString("b"), sql.FromNull(), sql.FromFloat(2.5), sql.FromBool(false), sql.FromTimestamp(ts.Add(time.Second)), sql.FromBytes([]byte("x"))},
+		{sql.FromBytes([]byte("c")), sql.FromInt(3), sql.FromInt(-4), sql.FromNull(), sql.FromNull(), sql.FromInt(5)},
+	}
+	names := []string{"name", "count", "amount", "flag", "time", "other value"}
+	expected := []string{
+		`{"name":"a","count":1,"amount":10,"flag":true,"time":1559392200000,"other_value":null}`,
+		`{"name":"b","count":null,"amount":2.5,"flag":false,"time":1559392201000,"other_value":"x"}`,
+		`{"name":"c","count":3,"amount":-4,"flag":null,"time":null,"other_value":"5"}`,
+	}
+
+	defer func(n int) { rowGroupRecords = n }(rowGroupRecords)
+
+	// With two records per row group, the schema is inferred from
+	// the first two records only.
+	for _, n := range []int{1000, 2} {
+		rowGroupRecords = n
+		out := &bufferWriteCloser{}
+		writer := NewWriter(out)
+		for _, row := range rows {
+			record := NewRecord()
+			for i, value := range row {
+				if err := record.Set(names[i], value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Pass the records in their binary form, as
+			// S3Select does.
+			data, err := record.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			record = NewRecord()
+			if err = record.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if err = writer.Write(record); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		records := readRecords(t, out.Bytes())
+		if !reflect.DeepEqual(records, expected) {
+			t.Fatalf("%d records per row group: got %v - want %v", n, records, expected)
+		}
+	}
+}
+
+func TestWriterTypeMismatch(t *testing.T) {
+	defer func(n int) { rowGroupRecords = n }(rowGroupRecords)
+	rowGroupRecords = 1
+
+	writer := NewWriter(&bufferWriteCloser{})
+	record := NewRecord()
+	record.Set("flag", sql.FromBool(true))
+	if err := writer.Write(record); err != nil {
+		t.Fatal(err)
+	}
+
+	record = NewRecord()
+	record.Set("flag", sql.FromInt(1))
+	if err := writer.Write(record); err == nil {
+		t.Fatal("expected an error for an integer value of a BOOLEAN column")
+	}
+}
+
+func TestWriterNoRecords(t *testing.T) {
+	out := &bufferWriteCloser{}
+	if err := NewWriter(out).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := out.Bytes()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatalf("invalid Parquet data %v", data)
+	}
+}
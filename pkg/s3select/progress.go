@@ -22,7 +22,10 @@ import (
 	"io"
 	"sync/atomic"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/pierrec/lz4"
 )
 
 type countUpReader struct {
@@ -50,6 +53,7 @@ type progressReader struct {
 	rc              io.ReadCloser
 	scannedReader   *countUpReader
 	processedReader *countUpReader
+	closeFn         func() // releases the decompressor, if any
 }
 
 func (pr *progressReader) Read(p []byte) (n int, err error) {
@@ -57,6 +61,9 @@ func (pr *progressReader) Read(p []byte) (n int, err error) {
 }
 
 func (pr *progressReader) Close() error {
+	if pr.closeFn != nil {
+		pr.closeFn()
+	}
 	return pr.rc.Close()
 }
 
@@ -67,6 +74,7 @@ func (pr *progressReader) Stats() (bytesScanned, bytesProcessed int64) {
 func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressReader, error) {
 	scannedReader := newCountUpReader(rc)
 	var r io.Reader
+	var closeFn func()
 	var err error
 
 	switch compType {
@@ -78,6 +86,17 @@ func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressRea
 		}
 	case bzip2Type:
 		r = bzip2.NewReader(scannedReader)
+	case zstdType:
+		var decoder *zstd.Decoder
+		if decoder, err = zstd.NewReader(scannedReader); err != nil {
+			return nil, errTruncatedInput(err)
+		}
+		r, closeFn = decoder, decoder.Close
+	case lz4Type:
+		r = lz4.NewReader(scannedReader)
+	case snappyType:
+		// Snappy framing format, as written by snappy.NewWriter().
+		r = snappy.NewReader(scannedReader)
 	default:
 		return nil, errInvalidCompressionFormat(fmt.Errorf("unknown compression type '%v'", compType))
 	}
@@ -86,5 +105,6 @@ func newProgressReader(rc io.ReadCloser, compType CompressionType) (*progressRea
 		rc:              rc,
 		scannedReader:   scannedReader,
 		processedReader: newCountUpReader(r),
+		closeFn:         closeFn,
 	}, nil
 }
@@ -0,0 +1,256 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3select
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// ScanRange - represents elements inside <ScanRange/> in request XML.
+//
+// Only the records which begin within the scan range are processed,
+// a record which begins within the range and ends after it is
+// processed to completion. A scan range without Start covers the last
+// End bytes of the object.
+type ScanRange struct {
+	Start *int64 `xml:"Start"`
+	End   *int64 `xml:"End"`
+}
+
+// validate - checks the scan range and whether it can be used for
+// the input.
+func (r *ScanRange) validate(input *InputSerialization) error {
+	switch {
+	case r.Start == nil && r.End == nil:
+		return errors.New("ScanRange must have a Start or an End")
+	case r.Start != nil && *r.Start < 0, r.End != nil && *r.End < 0:
+		return errors.New("ScanRange Start and End must not be negative")
+	case r.Start != nil && r.End != nil && *r.Start > *r.End:
+		return errors.New("ScanRange Start must not be greater than End")
+	}
+
+	switch {
+	case input.CompressionType != noneType:
+		return errors.New("ScanRange is only supported for uncompressed objects")
+	case input.format == parquetFormat:
+		return errors.New("ScanRange is not supported for Parquet objects")
+	case input.format == csvFormat && input.CSVArgs.AllowQuotedRecordDelimiter:
+		return errors.New("ScanRange is not supported for CSV objects with AllowQuotedRecordDelimiter")
+	case input.format == jsonFormat && !input.JSONArgs.IsLines():
+		return errors.New("ScanRange is only supported for JSON objects of type LINES")
+	}
+	return nil
+}
+
+// offsets - returns the offsets of the first and the last byte of the
+// scan range. The last offset is -1 if the range extends to the end of
+// the object.
+func (r *ScanRange) offsets(objectSize int64) (start, end int64, err error) {
+	if r.Start == nil {
+		if objectSize < 0 {
+			return 0, 0, errors.New("object size is required for a ScanRange without Start")
+		}
+
+		start = objectSize - *r.End
+		if start < 0 {
+			start = 0
+		}
+		return start, -1, nil
+	}
+
+	end = -1
+	if r.End != nil {
+		end = *r.End
+	}
+	return *r.Start, end, nil
+}
+
+// scanRangeReader - reads the records which begin within a byte range
+// of the object, where records are separated by delim. The underlying
+// reader starts at the offset of the range, or before it to find out
+// whether a record begins at the offset.
+type scanRangeReader struct {
+	rc io.ReadCloser
+	r  *bufio.Reader
+
+	delim     []byte
+	last      []byte // last len(delim) bytes read
+	skip      bool   // whether the first record begins before the range
+	remaining int64  // bytes left in the range, -1 if unlimited
+	eof       bool
+}
+
+func newScanRangeReader(rc io.ReadCloser, delim []byte, skip bool, remaining int64) *scanRangeReader {
+	r := &scanRangeReader{
+		rc:        rc,
+		r:         bufio.NewReader(rc),
+		delim:     delim,
+		skip:      skip,
+		remaining: remaining,
+	}
+	if !skip {
+		// The first record begins at the start of the reader.
+		r.last = append(r.last, delim...)
+	}
+	return r
+}
+
+func (r *scanRangeReader) track(p []byte) {
+	r.last = append(r.last, p...)
+	if n := len(r.last) - len(r.delim); n > 0 {
+		r.last = append(r.last[:0], r.last[n:]...)
+	}
+	if r.remaining > 0 {
+		r.remaining -= int64(len(p))
+		if r.remaining < 0 {
+			r.remaining = 0
+		}
+	}
+}
+
+// atRecordStart - returns whether the next byte begins a record.
+func (r *scanRangeReader) atRecordStart() bool {
+	return bytes.Equal(r.last, r.delim)
+}
+
+func (r *scanRangeReader) Read(p []byte) (n int, err error) {
+	if r.skip {
+		r.skip = false
+		for !r.atRecordStart() {
+			c, err := r.r.ReadByte()
+			if err != nil {
+				return 0, err
+			}
+			r.track([]byte{c})
+		}
+	}
+
+	if r.eof || len(p) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		return 0, nil
+	}
+
+	if r.remaining != 0 {
+		if r.remaining > 0 && int64(len(p)) > r.remaining {
+			p = p[:r.remaining]
+		}
+		n, err = r.r.Read(p)
+		r.track(p[:n])
+		return n, err
+	}
+
+	// The range is read, complete the current record.
+	for n < len(p) && !r.atRecordStart() {
+		if p[n], err = r.r.ReadByte(); err != nil {
+			return n, err
+		}
+		r.track(p[n : n+1])
+		n++
+	}
+	if r.atRecordStart() {
+		r.eof = true
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+func (r *scanRangeReader) Close() error {
+	return r.rc.Close()
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// openScanRange - opens the object for reading the records which begin
+// within the scan range. With header set, the first record of the
+// object is read as well, if the range does not include it.
+func (s3Select *S3Select) openScanRange(getReader func(offset, length int64) (io.ReadCloser, error), delim []byte, header bool) (io.ReadCloser, error) {
+	if s3Select.ScanRange == nil {
+		return getReader(0, -1)
+	}
+
+	start, end, err := s3Select.ScanRange.offsets(s3Select.objectSize)
+	if err != nil {
+		return nil, errInvalidRequestParameter(err)
+	}
+	if s3Select.objectSize >= 0 && start >= s3Select.objectSize {
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	if start == 0 {
+		rc, err := getReader(0, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := int64(-1)
+		if end >= 0 {
+			remaining = end + 1
+		}
+		return newScanRangeReader(rc, delim, false, remaining), nil
+	}
+
+	// Read the delimiter before the start of the range, if any, to
+	// find out whether a record begins at the start.
+	offset := start - int64(len(delim))
+	if offset < 0 {
+		offset = 0
+	}
+	rc, err := getReader(offset, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := int64(-1)
+	if end >= 0 {
+		remaining = end - offset + 1
+	}
+	r := newScanRangeReader(rc, delim, true, remaining)
+	if !header {
+		return r, nil
+	}
+
+	hrc, err := getReader(0, -1)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	headerRecord, err := ioutil.ReadAll(newScanRangeReader(hrc, delim, false, 1))
+	hrc.Close()
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	if !bytes.HasSuffix(headerRecord, delim) {
+		// The object has a single record, which begins
+		// before the range.
+		r.Close()
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	return &readCloser{io.MultiReader(bytes.NewReader(headerRecord), r), r}, nil
+}
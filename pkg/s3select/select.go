@@ -44,15 +44,27 @@ const (
 type CompressionType string
 
 const (
-	noneType  CompressionType = "none"
-	gzipType  CompressionType = "gzip"
-	bzip2Type CompressionType = "bzip2"
+	noneType   CompressionType = "none"
+	gzipType   CompressionType = "gzip"
+	bzip2Type  CompressionType = "bzip2"
+	zstdType   CompressionType = "zstd"
+	lz4Type    CompressionType = "lz4"
+	snappyType CompressionType = "snappy"
 )
 
 const (
 	maxRecordSize = 1 << 20 // 1 MiB
 )
 
+const (
+	// sqlExpressionType is the SQL dialect of S3 Select.
+	sqlExpressionType = "sql"
+
+	// minioSQLExpressionType is the SQL dialect of S3 Select
+	// with MinIO extensions, like GROUP BY and ORDER BY.
+	minioSQLExpressionType = "miniosql"
+)
+
 // UnmarshalXML - decodes XML data.
 func (c *CompressionType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var s string
@@ -66,7 +78,7 @@ func (c *CompressionType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 	}
 
 	switch parsedType {
-	case noneType, gzipType, bzip2Type:
+	case noneType, gzipType, bzip2Type, zstdType, lz4Type, snappyType:
 	default:
 		return errInvalidCompressionFormat(fmt.Errorf("invalid compression format '%v'", s))
 	}
@@ -133,8 +145,9 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 
 // OutputSerialization - represents elements inside <OutputSerialization/> in request XML.
 type OutputSerialization struct {
-	CSVArgs     csv.WriterArgs  `xml:"CSV"`
-	JSONArgs    json.WriterArgs `xml:"JSON"`
+	CSVArgs     csv.WriterArgs     `xml:"CSV"`
+	JSONArgs    json.WriterArgs    `xml:"JSON"`
+	ParquetArgs parquet.WriterArgs `xml:"Parquet"`
 	unmarshaled bool
 	format      string
 }
@@ -162,8 +175,12 @@ func (output *OutputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartE
 		parsedOutput.format = jsonFormat
 		found++
 	}
+	if !parsedOutput.ParquetArgs.IsEmpty() {
+		parsedOutput.format = parquetFormat
+		found++
+	}
 	if found != 1 {
-		return errObjectSerializationConflict(fmt.Errorf("either CSV or JSON should be present in OutputSerialization"))
+		return errObjectSerializationConflict(fmt.Errorf("either CSV, JSON or Parquet should be present in OutputSerialization"))
 	}
 
 	*output = OutputSerialization(parsedOutput)
@@ -186,10 +203,12 @@ type S3Select struct {
 	Input          InputSerialization  `xml:"InputSerialization"`
 	Output         OutputSerialization `xml:"OutputSerialization"`
 	Progress       RequestProgress     `xml:"RequestProgress"`
+	ScanRange      *ScanRange          `xml:"ScanRange"`
 
 	statement      *sql.SelectStatement
 	progressReader *progressReader
 	recordReader   recordReader
+	objectSize     int64
 }
 
 var (
@@ -218,7 +237,7 @@ func (s3Select *S3Select) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 	}
 
 	parsedS3Select.ExpressionType = strings.ToLower(parsedS3Select.ExpressionType)
-	if parsedS3Select.ExpressionType != "sql" {
+	if parsedS3Select.ExpressionType != sqlExpressionType && parsedS3Select.ExpressionType != minioSQLExpressionType {
 		return errInvalidExpressionType(fmt.Errorf("invalid expression type '%v'", parsedS3Select.ExpressionType))
 	}
 
@@ -235,18 +254,36 @@ func (s3Select *S3Select) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 		return err
 	}
 
+	if extensions := statement.Extensions(); len(extensions) > 0 && parsedS3Select.ExpressionType == sqlExpressionType {
+		return errUnsupportedSyntax(fmt.Errorf("%s is not supported by the SQL expression type. It is a MinIO extension which requires the expression type MinIOSQL", strings.Join(extensions, " and ")))
+	}
+
 	parsedS3Select.statement = &statement
 
+	if parsedS3Select.ScanRange != nil {
+		if err = parsedS3Select.ScanRange.validate(&parsedS3Select.Input); err != nil {
+			return errInvalidRequestParameter(err)
+		}
+	}
+
 	*s3Select = S3Select(parsedS3Select)
 	return nil
 }
 
+// SetObjectSize - sets the size of the object to be queried, which is
+// required for a ScanRange without Start.
+func (s3Select *S3Select) SetObjectSize(size int64) {
+	s3Select.objectSize = size
+}
+
 func (s3Select *S3Select) outputRecord() sql.Record {
 	switch s3Select.Output.format {
 	case csvFormat:
 		return csv.NewRecord()
 	case jsonFormat:
 		return json.NewRecord(sql.SelectFmtJSON)
+	case parquetFormat:
+		return parquet.NewRecord()
 	}
 
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
@@ -265,7 +302,8 @@ func (s3Select *S3Select) getProgress() (bytesScanned, bytesProcessed int64) {
 func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadCloser, error)) error {
 	switch s3Select.Input.format {
 	case csvFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openScanRange(getReader, []byte(s3Select.Input.CSVArgs.RecordDelimiter),
+			s3Select.Input.CSVArgs.HasHeader())
 		if err != nil {
 			return err
 		}
@@ -284,7 +322,7 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 
 		return nil
 	case jsonFormat:
-		rc, err := getReader(0, -1)
+		rc, err := s3Select.openScanRange(getReader, []byte("\n"), false)
 		if err != nil {
 			return err
 		}
@@ -309,7 +347,7 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 func (s3Select *S3Select) marshal(record sql.Record) ([]byte, error) {
 	switch s3Select.Output.format {
 	case csvFormat:
-		data, err := record.MarshalCSV([]rune(s3Select.Output.CSVArgs.FieldDelimiter)[0])
+		data, err := record.MarshalCSV(s3Select.Output.CSVArgs.WriteCSVOpts())
 		if err != nil {
 			return nil, err
 		}
@@ -322,6 +360,15 @@ func (s3Select *S3Select) marshal(record sql.Record) ([]byte, error) {
 		}
 
 		return append(data, []byte(s3Select.Output.JSONArgs.RecordDelimiter)...), nil
+	case parquetFormat:
+		// Parquet output records are written by the Parquet
+		// writer, so keep the value types.
+		parquetRecord, err := parquet.ToRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		return parquetRecord.MarshalBinary()
 	}
 
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
@@ -339,31 +386,66 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 	var outputRecord sql.Record
 	var err error
 	var data []byte
-	sendRecord := func() bool {
-		if outputRecord == nil {
+	var sendFailed bool
+	var parquetWriter *parquet.Writer
+	if s3Select.Output.format == parquetFormat {
+		parquetWriter = parquet.NewWriter(&recordsWriter{writer})
+	}
+	sendData := func(data []byte) bool {
+		if len(data) > maxRecordSize {
+			writer.FinishWithError("OverMaxRecordSize", "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.")
+			sendFailed = true
+			return false
+		}
+
+		if parquetWriter != nil {
+			record := parquet.NewRecord()
+			err := record.UnmarshalBinary(data)
+			if err == nil {
+				err = parquetWriter.Write(record)
+			}
+			if err != nil {
+				writer.FinishWithError("InternalError", err.Error())
+				sendFailed = true
+				return false
+			}
+
 			return true
 		}
 
-		if data, err = s3Select.marshal(outputRecord); err != nil {
+		if err := writer.SendRecord(data); err != nil {
+			// FIXME: log this error.
+			sendFailed = true
 			return false
 		}
 
-		if len(data) > maxRecordSize {
-			writer.FinishWithError("OverMaxRecordSize", "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.")
-			return false
+		return true
+	}
+	sendRecord := func() bool {
+		if outputRecord == nil {
+			return true
 		}
 
-		if err = writer.SendRecord(data); err != nil {
-			// FIXME: log this error.
-			err = nil
+		if data, err = s3Select.marshal(outputRecord); err != nil {
 			return false
 		}
 
-		return true
+		return sendData(data)
+	}
+	closeOutput := func() error {
+		if parquetWriter == nil {
+			return nil
+		}
+
+		return parquetWriter.Close()
 	}
 
 	for {
 		if s3Select.statement.LimitReached() {
+			if err = closeOutput(); err != nil {
+				break
+			}
+
 			if err = writer.Finish(s3Select.getProgress()); err != nil {
 				// FIXME: log this error.
 				err = nil
@@ -376,7 +458,12 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 				break
 			}
 
-			if s3Select.statement.IsAggregated() {
+			if s3Select.statement.HasHeldResults() {
+				err = s3Select.statement.WriteHeldResults(s3Select.outputRecord, s3Select.marshal, sendData)
+				if err != nil || sendFailed {
+					break
+				}
+			} else if s3Select.statement.IsAggregated() {
 				outputRecord = s3Select.outputRecord()
 				if err = s3Select.statement.AggregateResult(outputRecord); err != nil {
 					break
@@ -387,6 +474,10 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 				}
 			}
 
+			if err = closeOutput(); err != nil {
+				break
+			}
+
 			if err = writer.Finish(s3Select.getProgress()); err != nil {
 				// FIXME: log this error.
 				err = nil
@@ -408,7 +499,17 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 				break
 			}
 
-			if !sendRecord() {
+			if s3Select.statement.IsOrdered() {
+				if outputRecord == nil {
+					continue
+				}
+				if data, err = s3Select.marshal(outputRecord); err != nil {
+					break
+				}
+				if err = s3Select.statement.HoldResult(inputRecord, data); err != nil {
+					break
+				}
+			} else if !sendRecord() {
 				break
 			}
 		}
@@ -421,6 +522,7 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 
 // Close - closes opened S3 object.
 func (s3Select *S3Select) Close() error {
+	s3Select.statement.Close()
 	return s3Select.recordReader.Close()
 }
 
@@ -431,5 +533,7 @@ func NewS3Select(r io.Reader) (*S3Select, error) {
 		return nil, err
 	}
 
+	s3Select.objectSize = -1
+
 	return s3Select, nil
 }
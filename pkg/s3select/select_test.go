@@ -18,12 +18,21 @@ package s3select
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/minio/pkg/s3select/parquet"
+	"github.com/pierrec/lz4"
 )
 
 type testResponseWriter struct {
@@ -310,3 +319,476 @@ func TestParquetInput(t *testing.T) {
 		}
 	}
 }
+
+func TestCSVInputMinIOSQL(t *testing.T) {
+	var requestXML = `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.city, SUM(s.amount) AS total FROM S3Object s GROUP BY s.city ORDER BY total DESC</Expression>
+    <ExpressionType>%s</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>
+`
+
+	var csvData = []byte(`city,amount
+paris,3
+berlin,5
+rome,1
+paris,7
+`)
+
+	_, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, "SQL")))
+	if serr, ok := err.(SelectError); !ok || serr.ErrorCode() != "UnsupportedSyntax" {
+		t.Fatalf("expected UnsupportedSyntax error for the SQL expression type, got %v", err)
+	}
+
+	s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, "MinIOSQL")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(csvData)), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &testResponseWriter{}
+	s3Select.Evaluate(w)
+	s3Select.Close()
+
+	if !bytes.Contains(w.response, []byte("paris,10\nberlin,5\nrome,1\n")) {
+		t.Fatalf("received response does not contain the expected records")
+	}
+}
+
+// recordsPayload returns the concatenated payload of all Records
+// messages of the response.
+func recordsPayload(t *testing.T, response []byte) []byte {
+	var payload []byte
+	for len(response) > 0 {
+		if len(response) < 16 {
+			t.Fatalf("truncated message")
+		}
+		totalLen := binary.BigEndian.Uint32(response[0:4])
+		headersLen := binary.BigEndian.Uint32(response[4:8])
+		message := response[:totalLen]
+		response = response[totalLen:]
+
+		headers := message[12 : 12+headersLen]
+		var eventType string
+		for len(headers) > 0 {
+			nameLen := int(headers[0])
+			name := string(headers[1 : 1+nameLen])
+			headers = headers[1+nameLen+1:] // skip the header value type
+			valueLen := int(binary.BigEndian.Uint16(headers[0:2]))
+			if name == ":event-type" {
+				eventType = string(headers[2 : 2+valueLen])
+			}
+			headers = headers[2+valueLen:]
+		}
+		if eventType == "Records" {
+			payload = append(payload, message[12+headersLen:totalLen-4]...)
+		}
+	}
+	return payload
+}
+
+func TestParquetOutput(t *testing.T) {
+	var requestXML = `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <Parquet>
+        </Parquet>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>
+`
+
+	var csvData = []byte(`city,amount,note
+paris,3,first
+berlin,5.5,
+rome,1
+`)
+
+	var testTable = []struct {
+		query    string
+		expected []string
+	}{
+		{
+			"SELECT s.city, CAST(s.amount AS FLOAT) AS amount, s.amount > 4 AS big, s.note FROM S3Object s",
+			[]string{
+				`{"city":"paris","amount":3,"big":false,"note":"first"}`,
+				`{"city":"berlin","amount":5.5,"big":true,"note":""}`,
+				`{"city":"rome","amount":1,"big":false,"note":null}`,
+			},
+		},
+		{
+			"SELECT * FROM S3Object s WHERE s.city != 'rome'",
+			[]string{
+				`{"city":"paris","amount":"3","note":"first"}`,
+				`{"city":"berlin","amount":"5.5","note":""}`,
+			},
+		},
+		{
+			"SELECT COUNT(*) AS n FROM S3Object",
+			[]string{
+				`{"n":3}`,
+			},
+		},
+		{
+			"SELECT s.city FROM S3Object s WHERE s.city = 'london'",
+			nil,
+		},
+	}
+
+	for i, testCase := range testTable {
+		s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, testCase.query)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(csvData)), nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		data := recordsPayload(t, w.response)
+		if !bytes.HasPrefix(data, []byte("PAR1")) {
+			t.Fatalf("Test %d: no Parquet output in response %q", i, w.response)
+		}
+		reader, err := parquet.NewReader(func(offset, length int64) (io.ReadCloser, error) {
+			if offset < 0 {
+				offset = int64(len(data)) + offset
+			}
+			return ioutil.NopCloser(bytes.NewReader(data[offset:])), nil
+		}, &parquet.ReaderArgs{})
+		if err != nil {
+			t.Fatalf("Test %d: invalid Parquet output: %v", i, err)
+		}
+
+		var records []string
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Test %d: %v", i, err)
+			}
+			data, err := record.MarshalJSON()
+			if err != nil {
+				t.Fatalf("Test %d: %v", i, err)
+			}
+			records = append(records, string(data))
+		}
+		reader.Close()
+
+		if !reflect.DeepEqual(records, testCase.expected) {
+			t.Fatalf("Test %d: got %v - want %v", i, records, testCase.expected)
+		}
+	}
+}
+
+func TestCSVOutputQuoting(t *testing.T) {
+	var requestXML = `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.name, s.note FROM S3Object s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+            <FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+            %s
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>
+`
+
+	var csvData = []byte(`name,note
+a,plain
+b,"say ""hi"""
+c,'x'
+`)
+
+	var testTable = []struct {
+		outputArgs string
+		expected   string
+	}{
+		{
+			"",
+			"a,plain\nb,\"say \"\"hi\"\"\"\nc,'x'\n",
+		},
+		{
+			"<QuoteFields>ALWAYS</QuoteFields><FieldDelimiter>;</FieldDelimiter>",
+			"\"a\";\"plain\"\n\"b\";\"say \"\"hi\"\"\"\n\"c\";\"'x'\"\n",
+		},
+		{
+			"<QuoteCharacter>'</QuoteCharacter>",
+			"a,plain\nb,say \"hi\"\nc,'''x'''\n",
+		},
+		{
+			`<QuoteCharacter>'</QuoteCharacter><QuoteEscapeCharacter>\</QuoteEscapeCharacter><QuoteFields>ALWAYS</QuoteFields>`,
+			"'a','plain'\n'b','say \"hi\"'\n'c','\\'x\\''\n",
+		},
+	}
+
+	for i, testCase := range testTable {
+		s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, testCase.outputArgs)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(csvData)), nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		if payload := string(recordsPayload(t, w.response)); payload != testCase.expected {
+			t.Fatalf("Test %d: got %q - want %q", i, payload, testCase.expected)
+		}
+	}
+}
+
+func TestCompressedInput(t *testing.T) {
+	var requestXML = `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.id FROM S3Object s WHERE s.value = 'b'</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>%s</CompressionType>
+        <JSON>
+            <Type>LINES</Type>
+        </JSON>
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>
+`
+
+	var jsonData []byte
+	for i := 0; i < 1000; i++ {
+		jsonData = append(jsonData, fmt.Sprintf(`{"id":%d,"value":"%c"}`+"\n", i, 'a'+i%3)...)
+	}
+
+	compress := func(newWriter func(w io.Writer) (io.WriteCloser, error)) []byte {
+		var buf bytes.Buffer
+		w, err := newWriter(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(jsonData); err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	var testTable = []struct {
+		compressionType string
+		data            []byte
+	}{
+		{"NONE", jsonData},
+		{"GZIP", compress(func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		})},
+		{"ZSTD", compress(func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w)
+		})},
+		{"LZ4", compress(func(w io.Writer) (io.WriteCloser, error) {
+			return lz4.NewWriter(w), nil
+		})},
+		{"SNAPPY", compress(func(w io.Writer) (io.WriteCloser, error) {
+			return snappy.NewBufferedWriter(w), nil
+		})},
+	}
+
+	var expected []byte
+	for i := 1; i < 1000; i += 3 {
+		expected = append(expected, fmt.Sprintf("%d\n", i)...)
+	}
+
+	for _, testCase := range testTable {
+		s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, testCase.compressionType)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := testCase.data
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		}); err != nil {
+			t.Fatalf("%v: %v", testCase.compressionType, err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+
+		if payload := recordsPayload(t, w.response); !bytes.Equal(payload, expected) {
+			t.Fatalf("%v: received records do not match the expected records", testCase.compressionType)
+		}
+	}
+}
+
+func TestScanRange(t *testing.T) {
+	var requestXML = `
+<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>SELECT s.id FROM S3Object s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV>
+        </CSV>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+    <ScanRange>%s</ScanRange>
+</SelectObjectContentRequest>
+`
+
+	var csvData, jsonData, expected []byte
+	csvData = append(csvData, "id,value\n"...)
+	for i := 0; i < 100; i++ {
+		csvData = append(csvData, fmt.Sprintf("%d,%s\n", i, strings.Repeat("x", i%7))...)
+		jsonData = append(jsonData, fmt.Sprintf(`{"id":%d,"value":"%s"}`+"\n", i, strings.Repeat("x", i%7))...)
+		expected = append(expected, fmt.Sprintf("%d\n", i)...)
+	}
+
+	selectRange := func(input string, data []byte, scanRange string) []byte {
+		s3Select, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, input, scanRange)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s3Select.SetObjectSize(int64(len(data)))
+
+		if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data[offset:])), nil
+		}); err != nil {
+			t.Fatalf("%v: %v", scanRange, err)
+		}
+
+		w := &testResponseWriter{}
+		s3Select.Evaluate(w)
+		s3Select.Close()
+		return recordsPayload(t, w.response)
+	}
+
+	var testTable = []struct {
+		name  string
+		input string
+		data  []byte
+	}{
+		{"CSV", "<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>", csvData},
+		{"JSON", "<JSON><Type>LINES</Type></JSON>", jsonData},
+	}
+
+	for _, testCase := range testTable {
+		// The records of consecutive ranges are the records of the
+		// whole object, for any range size.
+		for _, size := range []int{1, 7, 50, 1000} {
+			var payload []byte
+			for start := 0; start < len(testCase.data); start += size {
+				scanRange := fmt.Sprintf("<Start>%d</Start><End>%d</End>", start, start+size-1)
+				payload = append(payload, selectRange(testCase.input, testCase.data, scanRange)...)
+			}
+			if !bytes.Equal(payload, expected) {
+				t.Fatalf("%v: range size %d: got %q - want %q", testCase.name, size, payload, expected)
+			}
+		}
+
+		// The records which begin within the last 50 bytes, and after
+		// offset 100.
+		tail := selectRange(testCase.input, testCase.data, "<End>50</End>")
+		rest := selectRange(testCase.input, testCase.data, fmt.Sprintf("<Start>%d</Start>", len(testCase.data)-50))
+		if !bytes.Equal(tail, rest) || !bytes.HasSuffix(expected, tail) || len(tail) == 0 {
+			t.Fatalf("%v: unexpected records %q of the last 50 bytes", testCase.name, tail)
+		}
+		if payload := selectRange(testCase.input, testCase.data, "<Start>100</Start>"); !bytes.HasSuffix(expected, payload) || len(payload) == 0 {
+			t.Fatalf("%v: unexpected records %q after offset 100", testCase.name, payload)
+		}
+	}
+
+	var errorTable = []struct {
+		input     string
+		scanRange string
+	}{
+		{"<CSV></CSV>", ""},
+		{"<CSV></CSV>", "<Start>-1</Start>"},
+		{"<CSV></CSV>", "<Start>10</Start><End>5</End>"},
+		{"<JSON><Type>DOCUMENT</Type></JSON>", "<Start>0</Start>"},
+		{"<Parquet></Parquet>", "<Start>0</Start>"},
+	}
+	for _, testCase := range errorTable {
+		if _, err := NewS3Select(strings.NewReader(fmt.Sprintf(requestXML, testCase.input, testCase.scanRange))); err == nil {
+			t.Fatalf("%v %v: expected an error", testCase.input, testCase.scanRange)
+		}
+	}
+
+	// Records of CSV objects with quoted record delimiters can not be
+	// found from an arbitrary offset.
+	start := int64(0)
+	input := InputSerialization{CompressionType: noneType, format: csvFormat}
+	input.CSVArgs.AllowQuotedRecordDelimiter = true
+	if err := (&ScanRange{Start: &start}).validate(&input); err == nil {
+		t.Fatal("expected an error for CSV with AllowQuotedRecordDelimiter")
+	}
+	input.CSVArgs.AllowQuotedRecordDelimiter = false
+	if err := (&ScanRange{Start: &start}).validate(&input); err != nil {
+		t.Fatal(err)
+	}
+}
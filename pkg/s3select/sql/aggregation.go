@@ -59,6 +59,38 @@ func newAggVal(fn FuncName) *aggVal {
 	}
 }
 
+// merge - merges the partial aggregate b of the aggregation function
+// fn into a, e.g. to combine the aggregates of a group which have been
+// spilled to disk.
+func (a *aggVal) merge(fn FuncName, b *aggVal) (err error) {
+	if !b.seen && b.runningCount == 0 {
+		return nil
+	}
+
+	switch fn {
+	case aggFnCount:
+		a.runningCount += b.runningCount
+
+	case aggFnAvg:
+		a.runningCount += b.runningCount
+		err = a.runningSum.arithOp(opPlus, b.runningSum)
+
+	case aggFnSum:
+		err = a.runningSum.arithOp(opPlus, b.runningSum)
+
+	case aggFnMin:
+		err = a.runningMin.minmax(b.runningMin, false, !a.seen)
+
+	case aggFnMax:
+		err = a.runningMax.minmax(b.runningMax, true, !a.seen)
+
+	default:
+		err = errInvalidAggregation
+	}
+	a.seen = true
+	return err
+}
+
 // evalAggregationNode - performs partial computation using the
 // current row and stores the result.
 //
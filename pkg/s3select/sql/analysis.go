@@ -215,6 +215,7 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 	case aggFnAvg, aggFnMax, aggFnMin, aggFnSum, aggFnCount:
 		// Initialize accumulator
 		e.aggregate = newAggVal(funcName)
+		s.aggregates = append(s.aggregates, e)
 
 		var exprA qProp
 		if funcName == aggFnCount {
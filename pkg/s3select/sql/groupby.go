@@ -0,0 +1,394 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// GROUP BY - the input rows are partitioned into groups by the values
+// of the GROUP BY expressions and every aggregation function is
+// computed per group. Each group holds its own partial aggregates,
+// which are swapped into the aggregation nodes of the AST while a row
+// of the group is aggregated or the result of the group is evaluated.
+//
+// At most maxGroupsInMemory groups are held in memory. Beyond that,
+// the groups are written to a temporary file sorted by their key, and
+// the sorted runs are merged after all rows have been processed.
+
+// maxGroupsInMemory is the max. number of groups held in memory
+// before they are spilled to disk.
+var maxGroupsInMemory = 100000
+
+var errGroupByAll = errors.New("SELECT * cannot be used with GROUP BY")
+
+type group struct {
+	values []*Value  // values of the GROUP BY expressions
+	aggs   []*aggVal // partial aggregates, one per aggregation function
+}
+
+// spilledGroup is the serialized form of a group.
+type spilledGroup struct {
+	Key    []byte
+	Values []spilledValue
+	Aggs   []spilledAggVal
+}
+
+type groupTable struct {
+	aggFuncs []*FuncExpr
+	groups   map[string]*group
+	spills   []*spillFile
+}
+
+func newGroupTable(aggFuncs []*FuncExpr) *groupTable {
+	return &groupTable{
+		aggFuncs: aggFuncs,
+		groups:   map[string]*group{},
+	}
+}
+
+// analyzeGroupBy - checks that every select expression is either an
+// aggregation or one of the GROUP BY expressions.
+func (e *SelectStatement) analyzeGroupBy() error {
+	s := e.selectAST
+	if s.Expression.All {
+		return errGroupByAll
+	}
+
+	for _, expr := range s.GroupBy {
+		qp := expr.analyze(s)
+		if qp.err != nil {
+			return fmt.Errorf("GROUP BY clause error: %v", qp.err)
+		}
+		if qp.isAggregation {
+			return errors.New("GROUP BY clause cannot have an aggregation")
+		}
+	}
+
+	e.selectGroupIndex = make([]int, len(s.Expression.Expressions))
+	for i, expr := range s.Expression.Expressions {
+		e.selectGroupIndex[i] = indexOfExpression(s.GroupBy, expr.Expression)
+		if e.selectGroupIndex[i] >= 0 {
+			continue
+		}
+
+		qp := expr.analyze(s)
+		if qp.err != nil {
+			return qp.err
+		}
+		if qp.isRowFunc {
+			return fmt.Errorf("Select expression %d must be an aggregation or appear in the GROUP BY clause", i+1)
+		}
+	}
+
+	e.selectQProp = qProp{isAggregation: true}
+	return nil
+}
+
+// indexOfExpression returns the index of the expression in exprs
+// which is identical to expr, or -1.
+func indexOfExpression(exprs []*Expression, expr *Expression) int {
+	for i := range exprs {
+		if reflect.DeepEqual(exprs[i], expr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// aggregateGroupRow - aggregates the input record into its group.
+func (e *SelectStatement) aggregateGroupRow(input Record) error {
+	values := make([]*Value, len(e.selectAST.GroupBy))
+	for i, expr := range e.selectAST.GroupBy {
+		v, err := expr.evalNode(input)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+
+	g, err := e.groups.get(values)
+	if err != nil {
+		return err
+	}
+	e.groups.use(g)
+
+	for _, expr := range e.selectAST.Expression.Expressions {
+		if err = expr.aggregateRow(input); err != nil {
+			return err
+		}
+	}
+	for _, term := range e.orderTerms {
+		if term.selectIndex < 0 {
+			if err = term.expr.aggregateRow(input); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeGroups - evaluates the select expressions for every group and
+// either sends the results or holds them back for ordering.
+func (e *SelectStatement) writeGroups(newRecord func() Record, marshal func(Record) ([]byte, error), write func([]byte) error) error {
+	exprs := e.selectAST.Expression.Expressions
+	return e.groups.forEach(func(g *group) (err error) {
+		output := newRecord()
+		values := make([]*Value, len(exprs))
+		for i, expr := range exprs {
+			if j := e.selectGroupIndex[i]; j >= 0 {
+				values[i] = g.values[j]
+			} else if values[i], err = expr.evalNode(nil); err != nil {
+				return err
+			}
+			if err = output.Set(e.outputName(i), values[i]); err != nil {
+				return err
+			}
+		}
+
+		data, err := marshal(output)
+		if err != nil {
+			return err
+		}
+		if e.results == nil {
+			return write(data)
+		}
+
+		key := make([]*Value, len(e.orderTerms))
+		for i, term := range e.orderTerms {
+			switch {
+			case term.selectIndex >= 0:
+				key[i] = values[term.selectIndex]
+			case term.groupIndex >= 0:
+				key[i] = g.values[term.groupIndex]
+			default:
+				if key[i], err = term.expr.evalNode(nil); err != nil {
+					return err
+				}
+			}
+		}
+		return e.results.add(key, data)
+	})
+}
+
+// get returns the group of the given GROUP BY values. A new group is
+// created if there is none.
+func (t *groupTable) get(values []*Value) (*group, error) {
+	key := groupKey(values)
+	g, ok := t.groups[key]
+	if ok {
+		return g, nil
+	}
+
+	if len(t.groups) >= maxGroupsInMemory {
+		if err := t.spill(); err != nil {
+			return nil, err
+		}
+	}
+	g = &group{
+		values: values,
+		aggs:   make([]*aggVal, len(t.aggFuncs)),
+	}
+	for i, fn := range t.aggFuncs {
+		g.aggs[i] = newAggVal(fn.getFunctionName())
+	}
+	t.groups[key] = g
+	return g, nil
+}
+
+// use makes the aggregation functions operate on the partial
+// aggregates of g.
+func (t *groupTable) use(g *group) {
+	for i, fn := range t.aggFuncs {
+		fn.aggregate = g.aggs[i]
+	}
+}
+
+func (t *groupTable) sortedKeys() []string {
+	keys := make([]string, 0, len(t.groups))
+	for key := range t.groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// spill writes all groups held in memory to a new spill file.
+func (t *groupTable) spill() error {
+	file, err := newSpillFile()
+	if err != nil {
+		return err
+	}
+	t.spills = append(t.spills, file)
+
+	for _, key := range t.sortedKeys() {
+		g := t.groups[key]
+		err = file.write(&spilledGroup{
+			Key:    []byte(key),
+			Values: spillValues(g.values),
+			Aggs:   spillAggVals(g.aggs),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	t.groups = map[string]*group{}
+	return nil
+}
+
+// forEach calls fn for every group in the order of the group keys.
+// The aggregation functions operate on the partial aggregates of the
+// group while fn is called.
+func (t *groupTable) forEach(fn func(g *group) error) error {
+	if len(t.spills) == 0 {
+		for _, key := range t.sortedKeys() {
+			g := t.groups[key]
+			t.use(g)
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(t.groups) > 0 {
+		if err := t.spill(); err != nil {
+			return err
+		}
+	}
+
+	heads := make([]spilledGroup, len(t.spills))
+	next := func(run int) (bool, error) {
+		heads[run] = spilledGroup{}
+		if err := t.spills[run].read(&heads[run]); err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	h := &runHeap{less: func(a, b int) bool {
+		return bytes.Compare(heads[a].Key, heads[b].Key) < 0
+	}}
+	for run, file := range t.spills {
+		if err := file.rewind(); err != nil {
+			return err
+		}
+		ok, err := next(run)
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.runs = append(h.runs, run)
+		}
+	}
+	heap.Init(h)
+
+	var current *group
+	var currentKey []byte
+	for h.Len() > 0 {
+		run := h.runs[0]
+		head := heads[run]
+		if current != nil && bytes.Equal(head.Key, currentKey) {
+			for i, agg := range unspillAggVals(head.Aggs) {
+				if err := current.aggs[i].merge(t.aggFuncs[i].getFunctionName(), agg); err != nil {
+					return err
+				}
+			}
+		} else {
+			if current != nil {
+				t.use(current)
+				if err := fn(current); err != nil {
+					return err
+				}
+			}
+			current = &group{
+				values: unspillValues(head.Values),
+				aggs:   unspillAggVals(head.Aggs),
+			}
+			currentKey = head.Key
+		}
+
+		ok, err := next(run)
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	if current != nil {
+		t.use(current)
+		return fn(current)
+	}
+	return nil
+}
+
+func (t *groupTable) close() {
+	for _, file := range t.spills {
+		file.remove()
+	}
+	t.spills = nil
+}
+
+// groupKey returns an encoding of the GROUP BY values which is equal
+// for two rows iff the rows belong to the same group.
+func groupKey(values []*Value) string {
+	var key, buf []byte
+	var length [binary.MaxVarintLen64]byte
+	for _, v := range values {
+		switch v.vType {
+		case typeBool:
+			if v.value.(bool) {
+				buf = []byte{1}
+			} else {
+				buf = []byte{0}
+			}
+		case typeString:
+			buf = []byte(v.value.(string))
+		case typeInt:
+			buf = make([]byte, 8)
+			binary.BigEndian.PutUint64(buf, uint64(v.value.(int64)))
+		case typeFloat:
+			buf = make([]byte, 8)
+			binary.BigEndian.PutUint64(buf, math.Float64bits(v.value.(float64)))
+		case typeTimestamp:
+			buf = []byte(v.value.(time.Time).UTC().Format(time.RFC3339Nano))
+		case typeBytes:
+			buf = v.value.([]byte)
+		default:
+			buf = nil
+		}
+		key = append(key, byte(v.vType))
+		key = append(key, length[:binary.PutUvarint(length[:], uint64(len(buf)))]...)
+		key = append(key, buf...)
+	}
+	return string(key)
+}
@@ -0,0 +1,431 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ORDER BY - the results are held back, as marshaled output records
+// along with the values of the ORDER BY terms, until all rows have
+// been processed. Once the held results exceed maxHeldResultsSize,
+// they are sorted and written to a temporary file, and the sorted
+// runs are merged when the results are written. Results with equal
+// ORDER BY values are written in input order.
+//
+// An ORDER BY term may refer to a select expression by its alias. An
+// ORDER BY term of a query without GROUP BY is evaluated on the input
+// row, an ORDER BY term of a query with GROUP BY must either be an
+// aggregation or one of the GROUP BY expressions.
+
+// maxHeldResultsSize is the max. size of the results held in memory
+// before they are spilled to disk.
+var maxHeldResultsSize = 64 << 20
+
+type orderTerm struct {
+	expr *Expression
+
+	// Index of the identical select expression or -1
+	selectIndex int
+
+	// Index of the identical GROUP BY expression or -1
+	groupIndex int
+
+	desc bool
+}
+
+type heldResult struct {
+	key  []*Value
+	data []byte
+}
+
+// spilledResult is the serialized form of a held result.
+type spilledResult struct {
+	Key  []spilledValue
+	Data []byte
+}
+
+type resultSorter struct {
+	terms   []orderTerm
+	results []heldResult
+	size    int
+	spills  []*spillFile
+}
+
+// analyzeOrderBy - resolves the ORDER BY terms and checks that they
+// can be evaluated for the results of the query.
+func (e *SelectStatement) analyzeOrderBy() error {
+	s := e.selectAST
+	grouped := len(s.GroupBy) > 0
+	if e.selectQProp.isAggregation && !grouped {
+		// The query returns a single row, so there is
+		// nothing to order.
+		return nil
+	}
+
+	for i, term := range s.OrderBy {
+		t := orderTerm{
+			expr:        term.Expression,
+			selectIndex: -1,
+			groupIndex:  -1,
+			desc:        term.Desc,
+		}
+		if name, ok := getIdentifier(term.Expression); ok {
+			t.selectIndex = e.indexOfAlias(name)
+		}
+		if t.selectIndex < 0 {
+			n := len(s.aggregates)
+			qp := term.Expression.analyze(s)
+			if qp.err != nil {
+				return fmt.Errorf("ORDER BY clause error: %v", qp.err)
+			}
+
+			t.selectIndex = indexOfSelectExpression(s.Expression.Expressions, term.Expression)
+			switch {
+			case t.selectIndex >= 0:
+				// The term is evaluated as select expression,
+				// so drop its aggregation functions again.
+				s.aggregates = s.aggregates[:n]
+			case grouped:
+				t.groupIndex = indexOfExpression(s.GroupBy, term.Expression)
+				if t.groupIndex < 0 && qp.isRowFunc {
+					return fmt.Errorf("ORDER BY term %d must be an aggregation or appear in the GROUP BY clause", i+1)
+				}
+			case qp.isAggregation:
+				return errors.New("ORDER BY clause cannot have an aggregation without GROUP BY")
+			}
+		}
+		e.orderTerms = append(e.orderTerms, t)
+	}
+
+	e.results = &resultSorter{terms: e.orderTerms}
+	return nil
+}
+
+// getIdentifier checks if the given expression is a single identifier,
+// which may refer to the alias of a select expression.
+func getIdentifier(e *Expression) (string, bool) {
+	if len(e.And) > 1 ||
+		len(e.And[0].Condition) > 1 ||
+		e.And[0].Condition[0].Not != nil ||
+		e.And[0].Condition[0].Operand.ConditionRHS != nil {
+		return "", false
+	}
+
+	operand := e.And[0].Condition[0].Operand.Operand
+	if operand.Right != nil ||
+		operand.Left.Right != nil ||
+		operand.Left.Left.Negated != nil ||
+		operand.Left.Left.Primary.JPathExpr == nil ||
+		len(operand.Left.Left.Primary.JPathExpr.PathExpr) > 0 {
+		return "", false
+	}
+	return operand.Left.Left.Primary.JPathExpr.BaseKey.String(), true
+}
+
+func (e *SelectStatement) indexOfAlias(name string) int {
+	for i, expr := range e.selectAST.Expression.Expressions {
+		if expr.As != "" && strings.EqualFold(expr.As, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexOfSelectExpression(exprs []*AliasedExpression, expr *Expression) int {
+	for i := range exprs {
+		if reflect.DeepEqual(exprs[i].Expression, expr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HoldResult - holds back the marshaled output record data of the
+// input record until all input records have been processed. Applies
+// only to ordered queries without aggregation.
+func (e *SelectStatement) HoldResult(input Record, data []byte) error {
+	key := make([]*Value, len(e.orderTerms))
+	for i, term := range e.orderTerms {
+		var err error
+		if term.selectIndex >= 0 {
+			key[i], err = e.selectAST.Expression.Expressions[term.selectIndex].evalNode(input)
+		} else {
+			key[i], err = term.expr.evalNode(input)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return e.results.add(key, data)
+}
+
+// compare compares the ORDER BY values of two results.
+func (r *resultSorter) compare(a, b []*Value) int {
+	for i, term := range r.terms {
+		c := compareValues(a[i], b[i])
+		if term.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func (r *resultSorter) add(key []*Value, data []byte) error {
+	r.results = append(r.results, heldResult{key: key, data: data})
+	r.size += len(data)
+	for _, v := range key {
+		r.size += 32
+		if b, ok := v.value.([]byte); ok {
+			r.size += len(b)
+		} else if s, ok := v.value.(string); ok {
+			r.size += len(s)
+		}
+	}
+	if r.size > maxHeldResultsSize {
+		return r.spill()
+	}
+	return nil
+}
+
+func (r *resultSorter) sort() {
+	sort.SliceStable(r.results, func(i, j int) bool {
+		return r.compare(r.results[i].key, r.results[j].key) < 0
+	})
+}
+
+// spill writes all results held in memory to a new spill file.
+func (r *resultSorter) spill() error {
+	file, err := newSpillFile()
+	if err != nil {
+		return err
+	}
+	r.spills = append(r.spills, file)
+
+	r.sort()
+	for _, result := range r.results {
+		err = file.write(&spilledResult{
+			Key:  spillValues(result.key),
+			Data: result.data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	r.results, r.size = nil, 0
+	return nil
+}
+
+// forEach calls fn for the data of every result in order.
+func (r *resultSorter) forEach(fn func(data []byte) error) error {
+	if len(r.spills) == 0 {
+		r.sort()
+		for _, result := range r.results {
+			if err := fn(result.data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(r.results) > 0 {
+		if err := r.spill(); err != nil {
+			return err
+		}
+	}
+
+	heads := make([]heldResult, len(r.spills))
+	next := func(run int) (bool, error) {
+		var result spilledResult
+		if err := r.spills[run].read(&result); err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, err
+		}
+		heads[run] = heldResult{key: unspillValues(result.Key), data: result.Data}
+		return true, nil
+	}
+
+	// Results with equal keys are taken from the earlier run
+	// first to keep the input order.
+	h := &runHeap{less: func(a, b int) bool {
+		c := r.compare(heads[a].key, heads[b].key)
+		return c < 0 || c == 0 && a < b
+	}}
+	for run, file := range r.spills {
+		if err := file.rewind(); err != nil {
+			return err
+		}
+		ok, err := next(run)
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.runs = append(h.runs, run)
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		run := h.runs[0]
+		if err := fn(heads[run].data); err != nil {
+			return err
+		}
+
+		ok, err := next(run)
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+func (r *resultSorter) close() {
+	for _, file := range r.spills {
+		file.remove()
+	}
+	r.spills = nil
+}
+
+// compareValues orders values of different types as NULL, booleans,
+// numbers, timestamps and strings. Untyped values are compared as
+// numbers if they can be parsed as such and as strings otherwise.
+func compareValues(a, b *Value) int {
+	rankA, rankB := valueRank(a), valueRank(b)
+	switch {
+	case rankA < rankB:
+		return -1
+	case rankA > rankB:
+		return 1
+	}
+
+	switch rankA {
+	case rankBool:
+		boolA, _ := a.ToBool()
+		boolB, _ := b.ToBool()
+		switch {
+		case boolA == boolB:
+			return 0
+		case boolB:
+			return -1
+		}
+		return 1
+
+	case rankNumber:
+		intA, okA := numericInt(a)
+		intB, okB := numericInt(b)
+		if okA && okB {
+			switch {
+			case intA < intB:
+				return -1
+			case intA > intB:
+				return 1
+			}
+			return 0
+		}
+		floatA, floatB := numericFloat(a), numericFloat(b)
+		switch {
+		case floatA < floatB:
+			return -1
+		case floatA > floatB:
+			return 1
+		}
+		return 0
+
+	case rankTimestamp:
+		timeA, _ := a.ToTimestamp()
+		timeB, _ := b.ToTimestamp()
+		switch {
+		case timeA.Before(timeB):
+			return -1
+		case timeA.After(timeB):
+			return 1
+		}
+		return 0
+
+	case rankString:
+		return bytes.Compare(stringBytes(a), stringBytes(b))
+	}
+	return 0
+}
+
+const (
+	rankNull = iota
+	rankBool
+	rankNumber
+	rankTimestamp
+	rankString
+)
+
+func valueRank(v *Value) int {
+	switch v.vType {
+	case typeBool:
+		return rankBool
+	case typeInt, typeFloat:
+		return rankNumber
+	case typeTimestamp:
+		return rankTimestamp
+	case typeString:
+		return rankString
+	case typeBytes:
+		if _, ok := v.bytesToFloat(); ok {
+			return rankNumber
+		}
+		return rankString
+	}
+	return rankNull
+}
+
+func numericInt(v *Value) (int64, bool) {
+	if v.vType == typeBytes {
+		return v.bytesToInt()
+	}
+	return v.ToInt()
+}
+
+func numericFloat(v *Value) float64 {
+	if v.vType == typeBytes {
+		f, _ := v.bytesToFloat()
+		return f
+	}
+	f, _ := v.ToFloat()
+	return f
+}
+
+func stringBytes(v *Value) []byte {
+	if s, ok := v.ToString(); ok {
+		return []byte(s)
+	}
+	b, _ := v.ToBytes()
+	return b
+}
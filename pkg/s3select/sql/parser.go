@@ -75,7 +75,20 @@ type Select struct {
 	Expression *SelectExpression `parser:"\"SELECT\" @@"`
 	From       *TableExpression  `parser:"\"FROM\" @@"`
 	Where      *Expression       `parser:"( \"WHERE\" @@ )?"`
+	GroupBy    []*Expression     `parser:"( \"GROUP\" \"BY\" @@ ( \",\" @@ )* )?"`
+	OrderBy    []*OrderByTerm    `parser:"( \"ORDER\" \"BY\" @@ ( \",\" @@ )* )?"`
 	Limit      *LitValue         `parser:"( \"LIMIT\" @@ )?"`
+
+	// Used during analysis to collect the aggregation funcs
+	aggregates []*FuncExpr
+}
+
+// OrderByTerm represents an expression of the ORDER BY clause and
+// its sort direction. GROUP BY and ORDER BY are MinIO extensions of
+// the S3 Select SQL dialect.
+type OrderByTerm struct {
+	Expression *Expression `parser:"@@"`
+	Desc       bool        `parser:"( \"ASC\" | @\"DESC\" )?"`
 }
 
 // SelectExpression represents the items requested in the select
@@ -325,7 +338,7 @@ type Identifier struct {
 var (
 	sqlLexer = lexer.Must(lexer.Regexp(`(\s+)` +
 		`|(?P<Timeword>(?i)\b(?:YEAR|MONTH|DAY|HOUR|MINUTE|SECOND|TIMEZONE_HOUR|TIMEZONE_MINUTE)\b)` +
-		`|(?P<Keyword>(?i)\b(?:SELECT|FROM|TOP|DISTINCT|ALL|WHERE|GROUP|BY|HAVING|UNION|MINUS|EXCEPT|INTERSECT|ORDER|LIMIT|OFFSET|TRUE|FALSE|NULL|IS|NOT|ANY|SOME|BETWEEN|AND|OR|LIKE|ESCAPE|AS|IN|BOOL|INT|INTEGER|STRING|FLOAT|DECIMAL|NUMERIC|TIMESTAMP|AVG|COUNT|MAX|MIN|SUM|COALESCE|NULLIF|CAST|DATE_ADD|DATE_DIFF|EXTRACT|TO_STRING|TO_TIMESTAMP|UTCNOW|CHAR_LENGTH|CHARACTER_LENGTH|LOWER|SUBSTRING|TRIM|UPPER|LEADING|TRAILING|BOTH|FOR)\b)` +
+		`|(?P<Keyword>(?i)\b(?:SELECT|FROM|TOP|DISTINCT|ALL|WHERE|GROUP|BY|HAVING|UNION|MINUS|EXCEPT|INTERSECT|ORDER|ASC|DESC|LIMIT|OFFSET|TRUE|FALSE|NULL|IS|NOT|ANY|SOME|BETWEEN|AND|OR|LIKE|ESCAPE|AS|IN|BOOL|INT|INTEGER|STRING|FLOAT|DECIMAL|NUMERIC|TIMESTAMP|AVG|COUNT|MAX|MIN|SUM|COALESCE|NULLIF|CAST|DATE_ADD|DATE_DIFF|EXTRACT|TO_STRING|TO_TIMESTAMP|UTCNOW|CHAR_LENGTH|CHARACTER_LENGTH|LOWER|SUBSTRING|TRIM|UPPER|LEADING|TRAILING|BOTH|FOR)\b)` +
 		`|(?P<Ident>[a-zA-Z_][a-zA-Z0-9_]*)` +
 		`|(?P<QuotIdent>"([^"]*("")?)*")` +
 		`|(?P<Number>\d*\.?\d+([eE][-+]?\d+)?)` +
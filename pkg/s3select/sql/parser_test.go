@@ -355,6 +355,9 @@ func TestSelectParsing(t *testing.T) {
 		"select * from s3object where name > 2 or value > 1 or word > 2",
 		"select s.word.id + 2 from s3object s",
 		"select 1-2-3 from s3object s limit 1",
+		"select s.city, count(*) from s3object s group by s.city",
+		"select s.a, s.b, sum(s.c) from s3object s where s.c > 0 group by s.a, s.b order by s.a, sum(s.c) desc limit 5",
+		"select s.name from s3object s order by s.age asc, s.name",
 	}
 	for i, tc := range cases {
 		err := p.ParseString(tc, &s)
@@ -32,11 +32,19 @@ const (
 	SelectFmtParquet
 )
 
+// WriteCSVOpts - encoding options for CSV output.
+type WriteCSVOpts struct {
+	FieldDelimiter rune
+	Quote          rune // Defaults to '"' if zero.
+	QuoteEscape    rune // Defaults to Quote if zero.
+	AlwaysQuote    bool // Quote all fields instead of only the fields which require quoting.
+}
+
 // Record - is a type containing columns and their values.
 type Record interface {
 	Get(name string) (*Value, error)
 	Set(name string, value *Value) error
-	MarshalCSV(fieldDelimiter rune) ([]byte, error)
+	MarshalCSV(opts WriteCSVOpts) ([]byte, error)
 	MarshalJSON() ([]byte, error)
 
 	// Returns underlying representation
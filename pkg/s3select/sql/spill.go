@@ -0,0 +1,197 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// spillFile is a temporary file holding a sorted run of groups or
+// results which did not fit into memory.
+type spillFile struct {
+	file *os.File
+	w    *bufio.Writer
+	enc  *gob.Encoder
+	dec  *gob.Decoder
+}
+
+func newSpillFile() (*spillFile, error) {
+	file, err := ioutil.TempFile("", "s3select-spill-")
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(file)
+	return &spillFile{file: file, w: w, enc: gob.NewEncoder(w)}, nil
+}
+
+func (s *spillFile) write(v interface{}) error {
+	return s.enc.Encode(v)
+}
+
+// rewind flushes the written entries, the following reads start
+// at the first entry.
+func (s *spillFile) rewind() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.dec = gob.NewDecoder(bufio.NewReader(s.file))
+	return nil
+}
+
+// read reads the next entry into v. It returns io.EOF after the
+// last entry.
+func (s *spillFile) read(v interface{}) error {
+	return s.dec.Decode(v)
+}
+
+func (s *spillFile) remove() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+// runHeap is a min-heap of sorted runs, ordered by their current
+// entries. It is used to merge spilled runs.
+type runHeap struct {
+	runs []int
+	less func(a, b int) bool // compares the current entries of two runs
+}
+
+var _ heap.Interface = (*runHeap)(nil)
+
+func (h *runHeap) Len() int           { return len(h.runs) }
+func (h *runHeap) Less(i, j int) bool { return h.less(h.runs[i], h.runs[j]) }
+func (h *runHeap) Swap(i, j int)      { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+func (h *runHeap) Push(x interface{}) { h.runs = append(h.runs, x.(int)) }
+func (h *runHeap) Pop() interface{} {
+	n := len(h.runs) - 1
+	run := h.runs[n]
+	h.runs = h.runs[:n]
+	return run
+}
+
+// spilledValue is the serialized form of a Value.
+type spilledValue struct {
+	Type  vType
+	Int   int64
+	Float float64
+	Bool  bool
+	Bytes []byte
+	Time  time.Time
+}
+
+func spillValue(v *Value) *spilledValue {
+	if v == nil {
+		return nil
+	}
+	s := &spilledValue{Type: v.vType}
+	switch v.vType {
+	case typeBool:
+		s.Bool = v.value.(bool)
+	case typeString:
+		s.Bytes = []byte(v.value.(string))
+	case typeInt:
+		s.Int = v.value.(int64)
+	case typeFloat:
+		s.Float = v.value.(float64)
+	case typeTimestamp:
+		s.Time = v.value.(time.Time)
+	case typeBytes:
+		s.Bytes = v.value.([]byte)
+	}
+	return s
+}
+
+func (s *spilledValue) value() *Value {
+	if s == nil {
+		return nil
+	}
+	switch s.Type {
+	case typeBool:
+		return FromBool(s.Bool)
+	case typeString:
+		return FromString(string(s.Bytes))
+	case typeInt:
+		return FromInt(s.Int)
+	case typeFloat:
+		return FromFloat(s.Float)
+	case typeTimestamp:
+		return FromTimestamp(s.Time)
+	case typeBytes:
+		return FromBytes(s.Bytes)
+	}
+	return FromNull()
+}
+
+func spillValues(values []*Value) []spilledValue {
+	s := make([]spilledValue, len(values))
+	for i, v := range values {
+		s[i] = *spillValue(v)
+	}
+	return s
+}
+
+func unspillValues(s []spilledValue) []*Value {
+	values := make([]*Value, len(s))
+	for i := range s {
+		values[i] = s[i].value()
+	}
+	return values
+}
+
+// spilledAggVal is the serialized form of a partial aggregate.
+type spilledAggVal struct {
+	Sum, Min, Max *spilledValue
+	Count         int64
+	Seen          bool
+}
+
+func spillAggVals(aggs []*aggVal) []spilledAggVal {
+	s := make([]spilledAggVal, len(aggs))
+	for i, a := range aggs {
+		s[i] = spilledAggVal{
+			Sum:   spillValue(a.runningSum),
+			Min:   spillValue(a.runningMin),
+			Max:   spillValue(a.runningMax),
+			Count: a.runningCount,
+			Seen:  a.seen,
+		}
+	}
+	return s
+}
+
+func unspillAggVals(s []spilledAggVal) []*aggVal {
+	aggs := make([]*aggVal, len(s))
+	for i, a := range s {
+		aggs[i] = &aggVal{
+			runningSum:   a.Sum.value(),
+			runningMin:   a.Min.value(),
+			runningMax:   a.Max.value(),
+			runningCount: a.Count,
+			seen:         a.Seen,
+		}
+	}
+	return aggs
+}
@@ -45,6 +45,19 @@ type SelectStatement struct {
 
 	// Count of rows that have been output.
 	outputCount int64
+
+	// Index of the GROUP BY expression of each select expression,
+	// or -1 if it is an aggregation (only for GROUP BY queries)
+	selectGroupIndex []int
+
+	// The resolved ORDER BY terms
+	orderTerms []orderTerm
+
+	// The groups of GROUP BY queries
+	groups *groupTable
+
+	// The results held back for ordering
+	results *resultSorter
 }
 
 // ParseSelectStatement - parses a select query from the given string
@@ -86,14 +99,43 @@ func ParseSelectStatement(s string) (stmt SelectStatement, err error) {
 	}
 
 	// Analyze main select expression
-	stmt.selectQProp = selectAST.Expression.analyze(&selectAST)
-	err = stmt.selectQProp.err
+	if len(selectAST.GroupBy) > 0 {
+		err = stmt.analyzeGroupBy()
+	} else {
+		stmt.selectQProp = selectAST.Expression.analyze(&selectAST)
+		err = stmt.selectQProp.err
+	}
 	if err != nil {
 		err = errQueryAnalysisFailure(err)
+		return
+	}
+
+	// Analyze order by clause
+	if len(selectAST.OrderBy) > 0 {
+		if err = stmt.analyzeOrderBy(); err != nil {
+			err = errQueryAnalysisFailure(err)
+			return
+		}
+	}
+
+	if len(selectAST.GroupBy) > 0 {
+		stmt.groups = newGroupTable(selectAST.aggregates)
 	}
 	return
 }
 
+// Extensions returns the MinIO extensions of the S3 Select SQL
+// dialect used by the statement, e.g. "GROUP BY".
+func (e *SelectStatement) Extensions() (extensions []string) {
+	if len(e.selectAST.GroupBy) > 0 {
+		extensions = append(extensions, "GROUP BY")
+	}
+	if len(e.selectAST.OrderBy) > 0 {
+		extensions = append(extensions, "ORDER BY")
+	}
+	return extensions
+}
+
 func validateTableName(from *TableExpression) error {
 	if strings.ToLower(from.Table.BaseKey.String()) != baseTableName {
 		return errBadTableName(errors.New("table name must be `s3object`"))
@@ -162,6 +204,58 @@ func (e *SelectStatement) IsAggregated() bool {
 	return e.selectQProp.isAggregation
 }
 
+// IsOrdered returns if the results of the statement are ordered. The
+// results of a non-aggregation query are passed to HoldResult then.
+func (e *SelectStatement) IsOrdered() bool {
+	return e.results != nil
+}
+
+// HasHeldResults returns if the results of the statement are held
+// back until all input records have been processed and must be written
+// by WriteHeldResults. It applies to GROUP BY and ORDER BY queries.
+func (e *SelectStatement) HasHeldResults() bool {
+	return e.groups != nil || e.results != nil
+}
+
+var errStopWriting = errors.New("stop writing held results")
+
+// WriteHeldResults - writes the results of a GROUP BY or ORDER BY
+// query after all input records have been processed. The output
+// records are created by newRecord and serialized by marshal. It
+// stops without an error when write returns false.
+func (e *SelectStatement) WriteHeldResults(newRecord func() Record, marshal func(Record) ([]byte, error), write func([]byte) bool) error {
+	writeData := func(data []byte) error {
+		if e.LimitReached() || !write(data) {
+			return errStopWriting
+		}
+		e.outputCount++
+		return nil
+	}
+
+	var err error
+	if e.groups != nil {
+		err = e.writeGroups(newRecord, marshal, writeData)
+	}
+	if err == nil && e.results != nil {
+		err = e.results.forEach(writeData)
+	}
+	if err == errStopWriting {
+		err = nil
+	}
+	return err
+}
+
+// Close - removes the temporary files of the statement.
+func (e *SelectStatement) Close() error {
+	if e.groups != nil {
+		e.groups.close()
+	}
+	if e.results != nil {
+		e.results.close()
+	}
+	return nil
+}
+
 // AggregateResult - returns the aggregated result after all input
 // records have been processed. Applies only to aggregation queries.
 func (e *SelectStatement) AggregateResult(output Record) error {
@@ -208,6 +302,10 @@ func (e *SelectStatement) AggregateRow(input Record) error {
 		return nil
 	}
 
+	if e.groups != nil {
+		return e.aggregateGroupRow(input)
+	}
+
 	for _, expr := range e.selectAST.Expression.Expressions {
 		err := expr.aggregateRow(input)
 		if err != nil {
@@ -231,7 +329,7 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 		// .. WHERE ..`
 
 		// Update count of records output.
-		if e.limitValue > -1 {
+		if e.limitValue > -1 && !e.IsOrdered() {
 			e.outputCount++
 		}
 
@@ -244,24 +342,31 @@ func (e *SelectStatement) Eval(input, output Record) (Record, error) {
 			return nil, err
 		}
 
-		// Pick output column names
-		if expr.As != "" {
-			output.Set(expr.As, v)
-		} else if comp, ok := getLastKeypathComponent(expr.Expression); ok {
-			output.Set(comp, v)
-		} else {
-			output.Set(fmt.Sprintf("_%d", i+1), v)
-		}
+		output.Set(e.outputName(i), v)
 	}
 
-	// Update count of records output.
-	if e.limitValue > -1 {
+	// Update count of records output. Ordered results are
+	// counted when they are written.
+	if e.limitValue > -1 && !e.IsOrdered() {
 		e.outputCount++
 	}
 
 	return output, nil
 }
 
+// outputName picks the output column name of the i-th select
+// expression.
+func (e *SelectStatement) outputName(i int) string {
+	expr := e.selectAST.Expression.Expressions[i]
+	if expr.As != "" {
+		return expr.As
+	}
+	if comp, ok := getLastKeypathComponent(expr.Expression); ok {
+		return comp
+	}
+	return fmt.Sprintf("_%d", i+1)
+}
+
 // LimitReached - returns true if the number of records output has
 // reached the value of the `LIMIT` clause.
 func (e *SelectStatement) LimitReached() bool {
@@ -0,0 +1,280 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bcicen/jstream"
+)
+
+type testRecord struct {
+	kvs jstream.KVS
+}
+
+func (r *testRecord) Get(name string) (*Value, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *testRecord) Set(name string, value *Value) error {
+	r.kvs = append(r.kvs, jstream.KV{Key: name, Value: value.value})
+	return nil
+}
+
+func (r *testRecord) MarshalCSV(opts WriteCSVOpts) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *testRecord) MarshalJSON() ([]byte, error) {
+	return r.kvs.MarshalJSON()
+}
+
+func (r *testRecord) Raw() (SelectObjectFormat, interface{}) {
+	return SelectFmtJSON, r.kvs
+}
+
+func (r *testRecord) Replace(kvs jstream.KVS) error {
+	r.kvs = kvs
+	return nil
+}
+
+var testStatementRows = []jstream.KVS{
+	{{Key: "city", Value: "paris"}, {Key: "amount", Value: int64(3)}, {Key: "id", Value: int64(1)}},
+	{{Key: "city", Value: "berlin"}, {Key: "amount", Value: int64(5)}, {Key: "id", Value: int64(2)}},
+	{{Key: "city", Value: "paris"}, {Key: "amount", Value: int64(7)}, {Key: "id", Value: int64(3)}},
+	{{Key: "city", Value: "rome"}, {Key: "amount", Value: int64(1)}, {Key: "id", Value: int64(4)}},
+	{{Key: "city", Value: "berlin"}, {Key: "amount", Value: int64(5)}, {Key: "id", Value: int64(5)}},
+	{{Key: "city", Value: "paris"}, {Key: "amount", Value: int64(2)}, {Key: "id", Value: int64(6)}},
+}
+
+func evalStatement(query string, rows []jstream.KVS) ([]string, error) {
+	stmt, err := ParseSelectStatement(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var results []string
+	marshal := func(r Record) ([]byte, error) { return r.MarshalJSON() }
+	for _, row := range rows {
+		if stmt.LimitReached() {
+			break
+		}
+		input := &testRecord{kvs: row}
+		if stmt.IsAggregated() {
+			if err = stmt.AggregateRow(input); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		output, err := stmt.Eval(input, &testRecord{})
+		if err != nil {
+			return nil, err
+		}
+		if output == nil {
+			continue
+		}
+		data, err := marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		if stmt.IsOrdered() {
+			if err = stmt.HoldResult(input, data); err != nil {
+				return nil, err
+			}
+		} else {
+			results = append(results, string(data))
+		}
+	}
+
+	if stmt.HasHeldResults() {
+		newRecord := func() Record { return &testRecord{} }
+		err = stmt.WriteHeldResults(newRecord, marshal, func(data []byte) bool {
+			results = append(results, string(data))
+			return true
+		})
+	} else if stmt.IsAggregated() {
+		output := &testRecord{}
+		if err = stmt.AggregateResult(output); err == nil {
+			data, _ := marshal(output)
+			results = append(results, string(data))
+		}
+	}
+	return results, err
+}
+
+func TestGroupByOrderBy(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{
+			query: "SELECT s.city, COUNT(*) AS n, SUM(s.amount) AS total, MIN(s.amount), MAX(s.amount) FROM S3Object s GROUP BY s.city ORDER BY s.city",
+			expected: []string{
+				`{"city":"berlin","n":2,"total":10,"_4":5,"_5":5}`,
+				`{"city":"paris","n":3,"total":12,"_4":2,"_5":7}`,
+				`{"city":"rome","n":1,"total":1,"_4":1,"_5":1}`,
+			},
+		},
+		{
+			query: "SELECT s.city, SUM(s.amount) AS total FROM S3Object s GROUP BY s.city ORDER BY total DESC",
+			expected: []string{
+				`{"city":"paris","total":12}`,
+				`{"city":"berlin","total":10}`,
+				`{"city":"rome","total":1}`,
+			},
+		},
+		{
+			query: "SELECT s.city FROM S3Object s WHERE s.amount > 1 GROUP BY s.city ORDER BY COUNT(*) ASC, s.city DESC LIMIT 2",
+			expected: []string{
+				`{"city":"berlin"}`,
+				`{"city":"paris"}`,
+			},
+		},
+		{
+			query: "SELECT COUNT(*) FROM S3Object s GROUP BY s.amount ORDER BY s.amount DESC",
+			expected: []string{
+				`{"_1":1}`,
+				`{"_1":2}`,
+				`{"_1":1}`,
+				`{"_1":1}`,
+				`{"_1":1}`,
+			},
+		},
+		{
+			query: "SELECT s.id FROM S3Object s ORDER BY s.amount DESC, s.id",
+			expected: []string{
+				`{"id":3}`,
+				`{"id":2}`,
+				`{"id":5}`,
+				`{"id":1}`,
+				`{"id":6}`,
+				`{"id":4}`,
+			},
+		},
+		{
+			query: "SELECT s.id AS x FROM S3Object s WHERE s.city = 'paris' ORDER BY x DESC LIMIT 2",
+			expected: []string{
+				`{"x":6}`,
+				`{"x":3}`,
+			},
+		},
+		{
+			query: "SELECT * FROM S3Object s ORDER BY s.amount LIMIT 1",
+			expected: []string{
+				`{"city":"rome","amount":1,"id":4}`,
+			},
+		},
+		{
+			query: "SELECT SUM(s.amount) FROM S3Object s ORDER BY s.id",
+			expected: []string{
+				`{"_1":23}`,
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		results, err := evalStatement(testCase.query, testStatementRows)
+		if err != nil {
+			t.Fatalf("Test %d: failed to evaluate statement: %v", i, err)
+		}
+		if !reflect.DeepEqual(results, testCase.expected) {
+			t.Fatalf("Test %d: got %v - want %v", i, results, testCase.expected)
+		}
+	}
+}
+
+func TestGroupByOrderBySpill(t *testing.T) {
+	var rows []jstream.KVS
+	for i := int64(0); i < 1000; i++ {
+		rows = append(rows, jstream.KVS{
+			{Key: "key", Value: i % 97},
+			{Key: "value", Value: (i * 7919) % 1000},
+		})
+	}
+	queries := []string{
+		"SELECT s.key, COUNT(*), SUM(s.value), AVG(s.value), MIN(s.value), MAX(s.value) FROM S3Object s GROUP BY s.key ORDER BY s.key",
+		"SELECT s.key, MAX(s.value) AS m FROM S3Object s GROUP BY s.key ORDER BY m DESC, s.key",
+		"SELECT s.value, s.key FROM S3Object s ORDER BY s.key, s.value DESC",
+		"SELECT s.value FROM S3Object s ORDER BY s.key LIMIT 50",
+	}
+
+	groups, size := maxGroupsInMemory, maxHeldResultsSize
+	defer func() {
+		maxGroupsInMemory, maxHeldResultsSize = groups, size
+	}()
+
+	for i, query := range queries {
+		expected, err := evalStatement(query, rows)
+		if err != nil {
+			t.Fatalf("Test %d: failed to evaluate statement: %v", i, err)
+		}
+
+		maxGroupsInMemory, maxHeldResultsSize = 10, 1000
+		results, err := evalStatement(query, rows)
+		maxGroupsInMemory, maxHeldResultsSize = groups, size
+		if err != nil {
+			t.Fatalf("Test %d: failed to evaluate statement with spilling: %v", i, err)
+		}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("Test %d: results differ when spilling to disk", i)
+		}
+	}
+}
+
+func TestGroupByOrderByAnalysis(t *testing.T) {
+	queries := []string{
+		"SELECT * FROM S3Object s GROUP BY s.city",
+		"SELECT s.city, s.id FROM S3Object s GROUP BY s.city",
+		"SELECT COUNT(*) FROM S3Object s GROUP BY COUNT(*)",
+		"SELECT COUNT(*) FROM S3Object s GROUP BY s.city ORDER BY s.id",
+		"SELECT s.id FROM S3Object s ORDER BY SUM(s.amount)",
+		"SELECT s.id FROM S3Object s ORDER BY s.id ASCENDING",
+	}
+	for i, query := range queries {
+		if _, err := ParseSelectStatement(query); err == nil {
+			t.Fatalf("Test %d: expected the statement %q to be rejected", i, query)
+		}
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	testCases := []struct {
+		a, b     *Value
+		expected int
+	}{
+		{FromNull(), FromBool(false), -1},
+		{FromBool(false), FromBool(true), -1},
+		{FromBool(true), FromInt(0), -1},
+		{FromInt(2), FromFloat(2.5), -1},
+		{FromFloat(2), FromInt(2), 0},
+		{FromBytes([]byte("10")), FromBytes([]byte("9")), 1},
+		{FromBytes([]byte("1.5")), FromInt(2), -1},
+		{FromInt(100), FromString("1"), -1},
+		{FromBytes([]byte("abc")), FromString("abd"), -1},
+		{FromString("b"), FromBytes([]byte("a")), 1},
+		{FromInt(7), FromInt(7), 0},
+	}
+	for i, testCase := range testCases {
+		if c := compareValues(testCase.a, testCase.b); c != testCase.expected {
+			t.Fatalf("Test %d: got %d - want %d", i, c, testCase.expected)
+		}
+	}
+}
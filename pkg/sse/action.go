@@ -0,0 +1,26 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+const (
+	// PutEncryptionConfigurationAction - PutBucketEncryption and
+	// DeleteBucketEncryption Rest API action.
+	PutEncryptionConfigurationAction = "s3:PutEncryptionConfiguration"
+
+	// GetEncryptionConfigurationAction - GetBucketEncryption Rest API action.
+	GetEncryptionConfigurationAction = "s3:GetEncryptionConfiguration"
+)
@@ -0,0 +1,110 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sse
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Algorithm - server side encryption algorithm applied by default.
+type Algorithm string
+
+const (
+	// AES256 - SSE-S3, objects are encrypted with keys managed by the server.
+	AES256 Algorithm = "AES256"
+
+	// AWSKms - SSE-KMS, objects are encrypted with keys derived from
+	// a KMS master key.
+	AWSKms Algorithm = "aws:kms"
+)
+
+var (
+	errConfigNoRule         = errors.New("Encryption configuration should have exactly one rule")
+	errInvalidAlgorithm     = errors.New("SSEAlgorithm must be set to either AES256 or aws:kms")
+	errKeyIDWithoutKMS      = errors.New("KMSMasterKeyID is only allowed with the aws:kms SSEAlgorithm")
+	errMissingDefaultAction = errors.New("ApplyServerSideEncryptionByDefault is missing")
+)
+
+// ApplySSEByDefault - default encryption applied to new objects.
+type ApplySSEByDefault struct {
+	SSEAlgorithm   Algorithm `xml:"SSEAlgorithm"`
+	KMSMasterKeyID string    `xml:"KMSMasterKeyID,omitempty"`
+}
+
+// Rule - encryption rule of a bucket.
+type Rule struct {
+	DefaultEncryptionAction *ApplySSEByDefault `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// Config - Configuration for bucket default encryption.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the encryption configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) != 1 {
+		return errConfigNoRule
+	}
+	action := c.Rules[0].DefaultEncryptionAction
+	if action == nil {
+		return errMissingDefaultAction
+	}
+	switch action.SSEAlgorithm {
+	case AES256:
+		if action.KMSMasterKeyID != "" {
+			return errKeyIDWithoutKMS
+		}
+	case AWSKms:
+	default:
+		return errInvalidAlgorithm
+	}
+	return nil
+}
+
+// Algorithm - returns the algorithm applied by default to new objects.
+func (c Config) Algorithm() Algorithm {
+	if len(c.Rules) == 0 || c.Rules[0].DefaultEncryptionAction == nil {
+		return ""
+	}
+	return c.Rules[0].DefaultEncryptionAction.SSEAlgorithm
+}
+
+// KeyID - returns the KMS master key ID applied by default to new
+// objects, empty if the default KMS master key is used.
+func (c Config) KeyID() string {
+	if len(c.Rules) == 0 || c.Rules[0].DefaultEncryptionAction == nil {
+		return ""
+	}
+	return c.Rules[0].DefaultEncryptionAction.KMSMasterKeyID
+}
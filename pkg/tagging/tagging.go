@@ -0,0 +1,153 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	// MaxObjectTags - maximum number of tags of an object.
+	MaxObjectTags = 10
+
+	// maxTagKeyLength - maximum length of a tag key in unicode characters.
+	maxTagKeyLength = 128
+
+	// maxTagValueLength - maximum length of a tag value in unicode characters.
+	maxTagValueLength = 256
+)
+
+var (
+	errTooManyTags       = errors.New("Object tags cannot be greater than 10")
+	errInvalidTagKey     = errors.New("The TagKey you have provided is invalid")
+	errInvalidTagValue   = errors.New("The TagValue you have provided is invalid")
+	errDuplicateTagKey   = errors.New("Cannot provide multiple Tags with the same key")
+	errInvalidTagsFormat = errors.New("Tags must be provided in URL query format")
+)
+
+// Tag - a key/value pair tagging an object.
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// Validate - validates the tag key and value.
+func (t Tag) Validate() error {
+	if t.Key == "" || utf8.RuneCountInString(t.Key) > maxTagKeyLength {
+		return errInvalidTagKey
+	}
+	if utf8.RuneCountInString(t.Value) > maxTagValueLength {
+		return errInvalidTagValue
+	}
+	return nil
+}
+
+// TagSet - set of tags.
+type TagSet struct {
+	Tags []Tag `xml:"Tag"`
+}
+
+// Tagging - tags of an object as sent and returned by the tagging APIs.
+type Tagging struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  TagSet   `xml:"TagSet"`
+}
+
+// Validate - validates the number of tags, each tag and uniqueness of keys.
+func (t Tagging) Validate() error {
+	if len(t.TagSet.Tags) > MaxObjectTags {
+		return errTooManyTags
+	}
+	keys := make(map[string]struct{}, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return errDuplicateTagKey
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// Count - returns the number of tags.
+func (t Tagging) Count() int {
+	return len(t.TagSet.Tags)
+}
+
+// ToMap - returns the tags as a map of keys to values.
+func (t Tagging) ToMap() map[string]string {
+	m := make(map[string]string, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		m[tag.Key] = tag.Value
+	}
+	return m
+}
+
+// String - returns the tags URL query encoded, as accepted
+// by the x-amz-tagging header.
+func (t Tagging) String() string {
+	values := make(url.Values, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		values.Set(tag.Key, tag.Value)
+	}
+	return values.Encode()
+}
+
+// ParseTagging - parses data in given reader to Tagging, the
+// parsed tags must be validated by the caller.
+func ParseTagging(reader io.Reader) (*Tagging, error) {
+	var t Tagging
+	if err := xml.NewDecoder(reader).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ParseObjectTags - parses URL query encoded tags, as sent in
+// the x-amz-tagging header, to Tagging.
+func ParseObjectTags(s string) (*Tagging, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, errInvalidTagsFormat
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var t Tagging
+	for _, key := range keys {
+		if len(values[key]) != 1 {
+			return nil, errDuplicateTagKey
+		}
+		t.TagSet.Tags = append(t.TagSet.Tags, Tag{Key: key, Value: values[key][0]})
+	}
+	if err = t.Validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tagging
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseTagging(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value>value</Value></Tag></TagSet></Tagging>`, nil},
+		{`<Tagging><TagSet></TagSet></Tagging>`, nil},
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value></Value></Tag></TagSet></Tagging>`, nil},
+		{`<Tagging><TagSet><Tag><Key></Key><Value>value</Value></Tag></TagSet></Tagging>`, errInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value>value</Value></Tag><Tag><Key>key</Key><Value>value</Value></Tag></TagSet></Tagging>`, errDuplicateTagKey},
+		{`<Tagging><TagSet><Tag><Key>` + strings.Repeat("k", maxTagKeyLength+1) + `</Key><Value>value</Value></Tag></TagSet></Tagging>`, errInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>key</Key><Value>` + strings.Repeat("v", maxTagValueLength+1) + `</Value></Tag></TagSet></Tagging>`, errInvalidTagValue},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseTagging(strings.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err = tags.Validate(); err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	if _, err := ParseTagging(strings.NewReader(`<Tagging><TagSet>`)); err == nil {
+		t.Errorf("expected malformed XML to fail")
+	}
+}
+
+func TestParseObjectTags(t *testing.T) {
+	var tooMany []string
+	for i := 0; i <= MaxObjectTags; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("key%d=value", i))
+	}
+
+	testCases := []struct {
+		tags          string
+		expectedCount int
+		expectedErr   error
+	}{
+		{"", 0, nil},
+		{"key=value", 1, nil},
+		{"key1=value1&key2=", 2, nil},
+		{"key%20with%20space=value%2Bplus", 1, nil},
+		{"key=value1&key=value2", 0, errDuplicateTagKey},
+		{"=value", 0, errInvalidTagKey},
+		{"key=%zz", 0, errInvalidTagsFormat},
+		{strings.Join(tooMany, "&"), 0, errTooManyTags},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseObjectTags(testCase.tags)
+		if err != testCase.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && tags.Count() != testCase.expectedCount {
+			t.Errorf("Test %d: expected %d tags, got %d", i+1, testCase.expectedCount, tags.Count())
+		}
+	}
+}
+
+func TestTaggingString(t *testing.T) {
+	tags, err := ParseTagging(bytes.NewBufferString(`<Tagging><TagSet><Tag><Key>b key</Key><Value>b&amp;value</Value></Tag><Tag><Key>a</Key><Value>1</Value></Tag></TagSet></Tagging>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := tags.String()
+	if expected := "a=1&b+key=b%26value"; s != expected {
+		t.Fatalf("expected %s, got %s", expected, s)
+	}
+
+	parsed, err := ParseObjectTags(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := parsed.ToMap()
+	if len(m) != 2 || m["a"] != "1" || m["b key"] != "b&value" {
+		t.Errorf("unexpected tags %v", m)
+	}
+}
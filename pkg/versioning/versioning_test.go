@@ -0,0 +1,91 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig       string
+		expectedErr       error
+		expectedEnabled   bool
+		expectedSuspended bool
+	}{
+		{ // Versioning enabled
+			inputConfig:     `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Versioning suspended
+			inputConfig:       `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`,
+			expectedErr:       nil,
+			expectedSuspended: true,
+		},
+		{ // Versioning enabled with MFA delete explicitly disabled
+			inputConfig:     `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Disabled</MfaDelete></VersioningConfiguration>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Missing status
+			inputConfig: `<VersioningConfiguration></VersioningConfiguration>`,
+			expectedErr: errInvalidStatus,
+		},
+		{ // Invalid status
+			inputConfig: `<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`,
+			expectedErr: errInvalidStatus,
+		},
+		{ // MFA delete is unsupported
+			inputConfig: `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`,
+			expectedErr: errMFADeleteEnabled,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			v, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err: %v, got: %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if v.Enabled() != tc.expectedEnabled {
+				t.Fatalf("expected enabled: %v, got: %v", tc.expectedEnabled, v.Enabled())
+			}
+			if v.Suspended() != tc.expectedSuspended {
+				t.Fatalf("expected suspended: %v, got: %v", tc.expectedSuspended, v.Suspended())
+			}
+		})
+	}
+}
+
+func TestMarshalVersioning(t *testing.T) {
+	v := Versioning{Status: Enabled}
+	data, err := xml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, string(data))
+	}
+}